@@ -121,6 +121,12 @@ func (m *Metrics) AddDurationValue(key string, value time.Duration) {
 	m.LocalCacheMeasurementsMs = append(m.LocalCacheMeasurementsMs, protos.IntMapEntry{Key: key, Value: valueMs})
 }
 
+// StateVersion identifies a version of the deployment state.
+type StateVersion struct {
+	Lineage string
+	Serial  int
+}
+
 type Bundle struct {
 	// BundleRootPath is the local path to the root directory of the bundle.
 	// It is set when we instantiate a new bundle instance.
@@ -190,6 +196,11 @@ type Bundle struct {
 	// (direct only) deployment implementation and state
 	DeploymentBundle direct.DeploymentBundle
 
+	// (direct only) RemoteState is the version of the remote state when it was
+	// pulled, or nil if it was not pulled. Writing the remote state fails if it
+	// changed since. The zero value means there was no remote state.
+	RemoteState *StateVersion
+
 	// if true, we skip approval checks for deploy, destroy resources and delete
 	// files
 	AutoApprove bool
@@ -198,6 +209,14 @@ type Bundle struct {
 	// When non-empty, only the specified resources are included in deployment.
 	Select []string
 
+	// StopOnError (direct only) stops starting resources after one fails. By default
+	// the resources that do not depend on a failed resource are still deployed.
+	StopOnError bool
+
+	// RetryFailed (direct only) limits the deployment to the resources that failed,
+	// were skipped or were interrupted in earlier deployments.
+	RetryFailed bool
+
 	// MigratingToDirect is set when the direct engine is requested but the existing
 	// state still uses terraform, so the state is migrated to the direct engine after
 	// this deploy. Resources that only the direct engine supports are skipped by this
@@ -56,6 +56,11 @@ type Bundle struct {
 	// Databricks CLI version constraints required to run the bundle.
 	DatabricksCliVersion string `json:"databricks_cli_version,omitempty"`
 
+	// Paths to policy files or directories with policy rules that the bundle
+	// configuration must satisfy. Relative paths are resolved against the
+	// directory of the configuration file that declares them.
+	Policies []string `json:"policies,omitempty"`
+
 	// A stable generated UUID for the bundle. This is normally serialized by
 	// Databricks first party template when a user runs bundle init.
 	Uuid string `json:"uuid,omitempty"`
@@ -5,6 +5,15 @@ type Deployment struct {
 	// running jobs or pipelines in the workspace. Defaults to false.
 	FailOnActiveRuns bool `json:"fail_on_active_runs,omitempty"`
 
+	// KeepDeployments is the number of successful deployments kept in the
+	// workspace state directory for "bundle deployment rollback". Defaults to 5.
+	KeepDeployments int `json:"keep_deployments,omitempty"`
+
 	// Lock configures locking behavior on deployment.
 	Lock Lock `json:"lock,omitempty"`
+
+	// StatePath is the UC Volume path to store the state of the direct
+	// deployment engine in, under <bundle name>/<target>. Defaults to the
+	// workspace state path.
+	StatePath string `json:"state_path,omitempty"`
 }
@@ -88,10 +88,43 @@ type (
 
 // These hook names are subject to change and currently experimental
 const (
-	ScriptPreInit    ScriptHook = "preinit"
-	ScriptPostInit   ScriptHook = "postinit"
-	ScriptPreBuild   ScriptHook = "prebuild"
-	ScriptPostBuild  ScriptHook = "postbuild"
-	ScriptPreDeploy  ScriptHook = "predeploy"
-	ScriptPostDeploy ScriptHook = "postdeploy"
+	ScriptPreInit     ScriptHook = "preinit"
+	ScriptPostInit    ScriptHook = "postinit"
+	ScriptPreBuild    ScriptHook = "prebuild"
+	ScriptPostBuild   ScriptHook = "postbuild"
+	ScriptPreDeploy   ScriptHook = "predeploy"
+	ScriptPostDeploy  ScriptHook = "postdeploy"
+	ScriptPreDestroy  ScriptHook = "predestroy"
+	ScriptPostDestroy ScriptHook = "postdestroy"
+	ScriptPrePlan     ScriptHook = "preplan"
+
+	// The prerun and postrun hooks run before and after "bundle run" runs a resource.
+	// See [ScriptRunKeyEnv] and related constants for the environment variables passed to them.
+	ScriptPreRun  ScriptHook = "prerun"
+	ScriptPostRun ScriptHook = "postrun"
+)
+
+// Environment variables passed to the prerun and postrun hooks.
+const (
+	// ScriptRunKeyEnv is the key of the resource that is run.
+	ScriptRunKeyEnv = "DATABRICKS_BUNDLE_RUN_KEY"
+
+	// ScriptRunIDEnv is the ID of the run (e.g. job run ID or pipeline update ID).
+	// Only passed to postrun, and only for resources that have one.
+	ScriptRunIDEnv = "DATABRICKS_BUNDLE_RUN_ID"
+
+	// ScriptRunStateEnv is the result state of the run: SUCCESS, FAILED, or
+	// STARTED if the run was not waited for (--no-wait). Only passed to postrun.
+	ScriptRunStateEnv = "DATABRICKS_BUNDLE_RUN_STATE"
+
+	// ScriptRunErrorEnv is the error message if the run failed.
+	// Only passed to postrun.
+	ScriptRunErrorEnv = "DATABRICKS_BUNDLE_RUN_ERROR"
 )
+
+// TargetExperimental defines experimental settings that can be overridden per target.
+type TargetExperimental struct {
+	// Scripts overrides the scripts for individual hooks in the selected target.
+	// The preinit hook runs before a target is selected and cannot be overridden.
+	Scripts map[ScriptHook]Command `json:"scripts,omitempty"`
+}
@@ -0,0 +1,12 @@
+package config
+
+// Moved records that a resource was renamed in the bundle configuration.
+// Deployments move the resource to its new key in the deployment state
+// instead of deleting it and creating a new one.
+type Moved struct {
+	// From is the previous key of the resource, e.g. resources.jobs.etl.
+	From string `json:"from"`
+
+	// To is the current key of the resource, e.g. resources.jobs.etl_daily.
+	To string `json:"to"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"path/filepath"
 	"slices"
 	"strings"
 
@@ -38,6 +39,14 @@ func (m *selectTarget) Apply(_ context.Context, b *bundle.Bundle) diag.Diagnosti
 		return diag.Errorf("%s: no such target. Available targets: %s", m.name, strings.Join(slices.Collect(maps.Keys(b.Config.Targets)), ", "))
 	}
 
+	// The variable file of a target is relative to the file that defines it.
+	// Resolve it here, since the locations of the targets section are no
+	// longer available after it has been removed below.
+	if target.VarFile != "" && !filepath.IsAbs(target.VarFile) {
+		loc := b.Config.GetLocation("targets." + m.name + ".var_file")
+		target.VarFile = filepath.Join(filepath.Dir(loc.File), target.VarFile)
+	}
+
 	// Merge specified target into root configuration structure.
 	err := b.Config.MergeTargetOverrides(m.name)
 	if err != nil {
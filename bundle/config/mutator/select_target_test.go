@@ -1,11 +1,14 @@
 package mutator_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/databricks/cli/bundle"
 	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/config/mutator"
+	"github.com/databricks/cli/bundle/internal/bundletest"
+	"github.com/databricks/cli/libs/dyn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -41,3 +44,20 @@ func TestSelectTargetNotFound(t *testing.T) {
 	diags := bundle.Apply(t.Context(), b, mutator.SelectTarget("doesnt-exist"))
 	require.Error(t, diags.Error(), "no targets defined")
 }
+
+func TestSelectTargetResolvesVarFile(t *testing.T) {
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Targets: map[string]*config.Target{
+				"default": {
+					VarFile: ".env.dev",
+				},
+			},
+		},
+	}
+	bundletest.SetLocation(b, "targets.default.var_file", []dyn.Location{{File: filepath.Join("targets", "dev.yml")}})
+
+	diags := bundle.Apply(t.Context(), b, mutator.SelectTarget("default"))
+	require.NoError(t, diags.Error())
+	assert.Equal(t, filepath.Join("targets", ".env.dev"), b.Target.VarFile)
+}
@@ -0,0 +1,80 @@
+package mutator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/engine"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/structs/structaccess"
+	"github.com/databricks/cli/libs/structs/structpath"
+)
+
+type validateLifecycleIgnoreChanges struct {
+	engine engine.EngineType
+}
+
+// ValidateLifecycleIgnoreChanges returns a mutator that errors when an entry in
+// lifecycle.ignore_changes is not a field path of the resource.
+// Wildcards and [key='value'] selectors are only supported in direct deployment mode,
+// because Terraform only accepts attribute references with numeric indices.
+func ValidateLifecycleIgnoreChanges(e engine.EngineType) bundle.Mutator {
+	return &validateLifecycleIgnoreChanges{engine: e}
+}
+
+func (m *validateLifecycleIgnoreChanges) Name() string {
+	return "ValidateLifecycleIgnoreChanges"
+}
+
+func (m *validateLifecycleIgnoreChanges) Apply(_ context.Context, b *bundle.Bundle) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, group := range b.Config.Resources.AllResources() {
+		for key, resource := range group.Resources {
+			for i, field := range resource.GetLifecycle().GetIgnoreChanges() {
+				err := m.validate(reflect.TypeOf(resource), field)
+				if err == nil {
+					continue
+				}
+				path := dyn.NewPath(
+					dyn.Key("resources"),
+					dyn.Key(group.Description.PluralName),
+					dyn.Key(key),
+					dyn.Key("lifecycle"),
+					dyn.Key("ignore_changes"),
+					dyn.Index(i),
+				)
+				diags = diags.Append(diag.Diagnostic{
+					Severity:  diag.Error,
+					Summary:   fmt.Sprintf("invalid lifecycle.ignore_changes entry %q: %s", field, err),
+					Locations: b.Config.GetLocations(path.String()),
+					Paths:     []dyn.Path{path},
+				})
+			}
+		}
+	}
+	return diags
+}
+
+func (m *validateLifecycleIgnoreChanges) validate(typ reflect.Type, field string) error {
+	pattern, err := structpath.ParsePattern(field)
+	if err != nil {
+		return err
+	}
+
+	if !m.engine.IsDirect() {
+		for _, node := range pattern.AsSlice() {
+			if node.DotStar() || node.BracketStar() {
+				return errors.New("wildcards are only supported in direct deployment mode")
+			}
+			if _, _, ok := node.KeyValue(); ok {
+				return errors.New("[key='value'] selectors are only supported in direct deployment mode")
+			}
+		}
+	}
+
+	return structaccess.ValidatePattern(typ, pattern)
+}
@@ -0,0 +1,80 @@
+package mutator_test
+
+import (
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/engine"
+	"github.com/databricks/cli/bundle/config/mutator"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLifecycleIgnoreChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		engine  engine.EngineType
+		field   string
+		summary string
+	}{
+		{
+			name:   "nested field",
+			engine: engine.EngineTerraform,
+			field:  "schedule.pause_status",
+		},
+		{
+			name:   "indexed field",
+			engine: engine.EngineTerraform,
+			field:  "tasks[0].new_cluster.num_workers",
+		},
+		{
+			name:   "wildcard in direct mode",
+			engine: engine.EngineDirect,
+			field:  "tasks[*].new_cluster.num_workers",
+		},
+		{
+			name:    "wildcard in terraform mode",
+			engine:  engine.EngineTerraform,
+			field:   "tasks[*].new_cluster.num_workers",
+			summary: `invalid lifecycle.ignore_changes entry "tasks[*].new_cluster.num_workers": wildcards are only supported in direct deployment mode`,
+		},
+		{
+			name:    "key-value selector in terraform mode",
+			engine:  engine.EngineTerraform,
+			field:   "tasks[task_key='main'].new_cluster",
+			summary: `invalid lifecycle.ignore_changes entry "tasks[task_key='main'].new_cluster": [key='value'] selectors are only supported in direct deployment mode`,
+		},
+		{
+			name:    "unknown field",
+			engine:  engine.EngineDirect,
+			field:   "schedule.unknown",
+			summary: `invalid lifecycle.ignore_changes entry "schedule.unknown": schedule.unknown: field "unknown" not found in jobs.CronSchedule`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &bundle.Bundle{
+				Config: config.Root{
+					Resources: config.Resources{
+						Jobs: map[string]*resources.Job{
+							"my_job": {
+								BaseResource: resources.BaseResource{
+									Lifecycle: resources.Lifecycle{IgnoreChanges: []string{tt.field}},
+								},
+							},
+						},
+					},
+				},
+			}
+			diags := bundle.Apply(t.Context(), b, mutator.ValidateLifecycleIgnoreChanges(tt.engine))
+			if tt.summary == "" {
+				assert.Empty(t, diags)
+				return
+			}
+			assert.True(t, diags.HasError())
+			assert.Equal(t, tt.summary, diags[0].Summary)
+		})
+	}
+}
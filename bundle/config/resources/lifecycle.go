@@ -3,12 +3,21 @@ package resources
 // LifecycleConfig is implemented by Lifecycle and LifecycleWithStarted.
 type LifecycleConfig interface {
 	HasPreventDestroy() bool
+	GetIgnoreChanges() []string
+	HasCreateBeforeDestroy() bool
 }
 
 // Lifecycle contains base lifecycle settings supported by all resources.
 type Lifecycle struct {
 	// Lifecycle setting to prevent the resource from being destroyed.
 	PreventDestroy bool `json:"prevent_destroy,omitempty"`
+
+	// Field paths whose changes are ignored when updating the resource.
+	// The values are only used when the resource is created.
+	IgnoreChanges []string `json:"ignore_changes,omitempty"`
+
+	// Lifecycle setting to create the replacement before destroying the resource when it must be recreated.
+	CreateBeforeDestroy bool `json:"create_before_destroy,omitempty"`
 }
 
 // HasPreventDestroy returns true if prevent_destroy is set.
@@ -16,6 +25,16 @@ func (l Lifecycle) HasPreventDestroy() bool {
 	return l.PreventDestroy
 }
 
+// GetIgnoreChanges returns the field paths listed in ignore_changes.
+func (l Lifecycle) GetIgnoreChanges() []string {
+	return l.IgnoreChanges
+}
+
+// HasCreateBeforeDestroy returns true if create_before_destroy is set.
+func (l Lifecycle) HasCreateBeforeDestroy() bool {
+	return l.CreateBeforeDestroy
+}
+
 // LifecycleWithStarted contains lifecycle settings for resources that support lifecycle.started.
 // It is used by apps, clusters, and sql_warehouses.
 type LifecycleWithStarted struct {
@@ -5,8 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/databricks/cli/bundle/config/resources"
@@ -60,6 +62,10 @@ type Root struct { //nolint:recvcheck // value receivers for read-only accessors
 	// to deploy in this bundle (e.g. jobs, pipelines, etc.).
 	Resources Resources `json:"resources,omitempty"`
 
+	// Moved lists resources that were renamed in the configuration, so that
+	// deployments update them under their new key instead of recreating them.
+	Moved []Moved `json:"moved,omitempty"`
+
 	// Targets can be used to differentiate settings and resources between
 	// bundle deployment targets (e.g. development, staging, production).
 	// Note that this field is set to 'nil' by the SelectTarget mutator;
@@ -294,6 +300,44 @@ func (r *Root) InitializeVariables(vars []string) error {
 	return nil
 }
 
+// InitializeVariablesFromFile assigns values to variables from a .env-style file.
+// Variables that already have a value, e.g. from the --var flag, are not changed.
+// Names in the file that are not defined in the bundle are reported as warnings,
+// so that a single file can be shared by bundles that use different variables.
+func (r *Root) InitializeVariablesFromFile(path string) diag.Diagnostics {
+	values, err := variable.ReadVarFile(path)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		val := values[name]
+		v, ok := r.Variables[name]
+		if !ok {
+			diags = diags.Append(diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("variable %s in %s has not been defined and is ignored", name, path),
+			})
+			continue
+		}
+
+		if v.IsComplex() {
+			return diags.Extend(diag.Errorf("setting variables of complex type via --var-file is not supported: %s", name))
+		}
+
+		if v.HasValue() {
+			continue
+		}
+
+		err := v.Set(val)
+		if err != nil {
+			return diags.Extend(diag.Errorf("failed to assign value from %s to %s: %s", path, name, err))
+		}
+	}
+	return diags
+}
+
 func (r *Root) Merge(other *Root) error {
 	// Merge dynamic configuration values.
 	return r.Mutate(func(root dyn.Value) (dyn.Value, error) {
@@ -347,6 +391,7 @@ func (r *Root) MergeTargetOverrides(name string) error {
 		"sync",
 		"permissions",
 		"presets",
+		"experimental",
 	} {
 		if root, err = mergeField(root, target, f); err != nil {
 			return fmt.Errorf("failed to merge target=%s field=%s: %w", name, f, err)
@@ -2,10 +2,13 @@ package config
 
 import (
 	"encoding/json"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/databricks/cli/bundle/config/variable"
+	"github.com/databricks/cli/internal/testutil"
+	"github.com/databricks/cli/libs/diag"
 	"github.com/databricks/cli/libs/dyn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -91,6 +94,49 @@ func TestInitializeVariablesUndefinedVariables(t *testing.T) {
 	assert.ErrorContains(t, err, "variable bar has not been defined")
 }
 
+func TestInitializeVariablesFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	testutil.WriteFile(t, path, "foo=123\nBUNDLE_VAR_bar=456\n")
+
+	root := &Root{
+		Variables: map[string]*variable.Variable{
+			"foo": {
+				Description: "a variable set by --var",
+			},
+			"bar": {
+				Description: "a required variable",
+			},
+		},
+	}
+
+	err := root.InitializeVariables([]string{"foo=abc"})
+	require.NoError(t, err)
+	diags := root.InitializeVariablesFromFile(path)
+	require.Empty(t, diags)
+	assert.Equal(t, "abc", root.Variables["foo"].Value)
+	assert.Equal(t, "456", root.Variables["bar"].Value)
+}
+
+func TestInitializeVariablesFromFileUndefinedVariables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	testutil.WriteFile(t, path, "bar=567\n")
+
+	root := &Root{
+		Variables: map[string]*variable.Variable{
+			"foo": {
+				Description: "A required variable",
+			},
+		},
+	}
+
+	diags := root.InitializeVariablesFromFile(path)
+	require.NoError(t, diags.Error())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "variable bar in "+path+" has not been defined and is ignored", diags[0].Summary)
+	assert.False(t, root.Variables["foo"].HasValue())
+}
+
 func TestRootMergeTargetOverridesWithMode(t *testing.T) {
 	root := &Root{
 		Bundle: Bundle{},
@@ -105,6 +151,34 @@ func TestRootMergeTargetOverridesWithMode(t *testing.T) {
 	assert.Equal(t, Development, root.Bundle.Mode)
 }
 
+func TestRootMergeTargetOverridesWithScripts(t *testing.T) {
+	root := &Root{
+		Experimental: &Experimental{
+			Scripts: map[ScriptHook]Command{
+				ScriptPreDeploy:  "echo predeploy",
+				ScriptPreDestroy: "echo predestroy",
+			},
+		},
+		Targets: map[string]*Target{
+			"production": {
+				Experimental: &TargetExperimental{
+					Scripts: map[ScriptHook]Command{
+						ScriptPreDestroy: "./teardown.sh",
+						ScriptPostRun:    "./notify.sh",
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, root.initializeDynamicValue())
+	require.NoError(t, root.MergeTargetOverrides("production"))
+	assert.Equal(t, map[ScriptHook]Command{
+		ScriptPreDeploy:  "echo predeploy",
+		ScriptPreDestroy: "./teardown.sh",
+		ScriptPostRun:    "./notify.sh",
+	}, root.Experimental.Scripts)
+}
+
 func TestInitializeComplexVariablesViaFlagIsNotAllowed(t *testing.T) {
 	root := &Root{
 		Variables: map[string]*variable.Variable{
@@ -62,6 +62,11 @@ type Target struct {
 	//       lookup: "resource_name"
 	Variables map[string]*variable.TargetVariable `json:"variables,omitempty"`
 
+	// Path to a .env-style file with variable values for this target, relative
+	// to the configuration file that defines the target. Values passed with
+	// --var or --var-file take precedence over values from this file.
+	VarFile string `json:"var_file,omitempty"`
+
 	Git Git `json:"git,omitempty"`
 
 	RunAs *jobs.JobRunAs `json:"run_as,omitempty"`
@@ -69,6 +74,8 @@ type Target struct {
 	Sync *Sync `json:"sync,omitempty"`
 
 	Permissions []resources.Permission `json:"permissions,omitempty"`
+
+	Experimental *TargetExperimental `json:"experimental,omitempty"`
 }
 
 const (
@@ -32,6 +32,7 @@ func (f *fastValidate) Apply(ctx context.Context, rb *bundle.Bundle) diag.Diagno
 
 		// Blocking mutators. Deployments will fail if these checks fail.
 		ValidateArtifactPath(),
+		Policies(),
 	)
 
 	return nil
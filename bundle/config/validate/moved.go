@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+)
+
+type moved struct{ bundle.RO }
+
+// Moved validates the moved blocks in the bundle configuration.
+//
+// Each block must rename a resource to another key in the same resource group.
+// The new key must be defined in the configuration and the old key must not be.
+// Whether the old key exists in the deployment state is checked by
+// statemgmt.CheckMoved once the state is pulled.
+func Moved() bundle.ReadOnlyMutator {
+	return &moved{}
+}
+
+func (m *moved) Name() string {
+	return "validate:moved"
+}
+
+// parseResourceKey returns the resource group of a key of the form resources.<group>.<name>.
+func parseResourceKey(key string) (string, bool) {
+	p, err := dyn.NewPathFromString(key)
+	if err != nil || len(p) != 3 || p[0].Key() != "resources" || p[1].Key() == "" || p[2].Key() == "" {
+		return "", false
+	}
+	return p[1].Key(), true
+}
+
+func (m *moved) Apply(_ context.Context, b *bundle.Bundle) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	seenFrom := make(map[string]bool)
+	seenTo := make(map[string]bool)
+	for i, mv := range b.Config.Moved {
+		path := fmt.Sprintf("moved[%d]", i)
+		report := func(format string, args ...any) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:  diag.Error,
+				Summary:   fmt.Sprintf(format, args...),
+				Paths:     []dyn.Path{dyn.MustPathFromString(path)},
+				Locations: b.Config.GetLocations(path),
+			})
+		}
+
+		fromGroup, ok := parseResourceKey(mv.From)
+		if !ok {
+			report("invalid moved block: from %q must have the form resources.<group>.<name>", mv.From)
+			continue
+		}
+		toGroup, ok := parseResourceKey(mv.To)
+		if !ok {
+			report("invalid moved block: to %q must have the form resources.<group>.<name>", mv.To)
+			continue
+		}
+		if fromGroup != toGroup {
+			report("invalid moved block: cannot move %s to %s in a different resource group", mv.From, mv.To)
+			continue
+		}
+		if mv.From == mv.To {
+			report("invalid moved block: from and to are both %s", mv.From)
+			continue
+		}
+
+		if seenFrom[mv.From] {
+			report("invalid moved block: %s is moved more than once", mv.From)
+		}
+		if seenTo[mv.To] {
+			report("invalid moved block: more than one resource is moved to %s", mv.To)
+		}
+		seenFrom[mv.From] = true
+		seenTo[mv.To] = true
+
+		if _, err := dyn.Get(b.Config.Value(), mv.To); err != nil {
+			report("invalid moved block: %s is not defined in the bundle configuration", mv.To)
+		}
+		if _, err := dyn.Get(b.Config.Value(), mv.From); err == nil {
+			report("invalid moved block: %s is still defined in the bundle configuration", mv.From)
+		}
+	}
+
+	return diags
+}
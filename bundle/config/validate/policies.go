@@ -0,0 +1,433 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/convert"
+	"github.com/databricks/cli/libs/dyn/yamlloader"
+)
+
+// Policies evaluates the policy rules in the files and directories listed
+// in bundle.policies against the bundle configuration.
+func Policies() bundle.ReadOnlyMutator {
+	return &policies{}
+}
+
+// PolicyPaths evaluates the policy rules in the given files and directories
+// against the bundle configuration. It is used by "bundle validate --policy".
+func PolicyPaths(paths []string) bundle.ReadOnlyMutator {
+	return &policies{paths: paths}
+}
+
+type policies struct {
+	bundle.RO
+
+	// Paths to policy files or directories. If nil, bundle.policies is used.
+	paths []string
+}
+
+func (v *policies) Name() string {
+	return "validate:policies"
+}
+
+func (v *policies) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	paths := v.paths
+	if paths == nil {
+		var diags diag.Diagnostics
+		paths, diags = configPolicyPaths(b)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	var diags diag.Diagnostics
+	for _, path := range paths {
+		files, err := policyFiles(path)
+		if err != nil {
+			diags = diags.Extend(diag.FromErr(err))
+			continue
+		}
+
+		for _, file := range files {
+			rules, err := loadPolicyRules(file)
+			if err != nil {
+				diags = diags.Extend(diag.FromErr(err))
+				continue
+			}
+
+			for _, rule := range rules {
+				diags = diags.Extend(rule.evaluate(b.Config.Value(), b.Config.Bundle.Target))
+			}
+		}
+	}
+
+	return diags
+}
+
+// configPolicyPaths returns the paths in bundle.policies. Relative paths are
+// resolved against the directory of the configuration file that declares them.
+func configPolicyPaths(b *bundle.Bundle) ([]string, diag.Diagnostics) {
+	v := b.Config.Value().Get("bundle").Get("policies")
+	seq, ok := v.AsSequence()
+	if !ok {
+		return nil, nil
+	}
+
+	var paths []string
+	var diags diag.Diagnostics
+	for i, elem := range seq {
+		path, ok := elem.AsString()
+		if !ok {
+			p := dyn.NewPath(dyn.Key("bundle"), dyn.Key("policies"), dyn.Index(i))
+			diags = diags.Append(diag.Diagnostic{
+				Severity:  diag.Error,
+				Summary:   fmt.Sprintf("expected a path, found %s", elem.Kind()),
+				Locations: elem.Locations(),
+				Paths:     []dyn.Path{p},
+			})
+			continue
+		}
+
+		if !filepath.IsAbs(path) {
+			dir := b.BundleRootPath
+			if file := elem.Location().File; file != "" {
+				dir = filepath.Dir(file)
+			}
+			path = filepath.Join(dir, path)
+		}
+		paths = append(paths, path)
+	}
+	return paths, diags
+}
+
+// policyFiles returns the policy files at path. If path is a directory,
+// all .yml and .yaml files in the directory are returned in sorted order.
+func policyFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policies: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policies: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+		files = append(files, filepath.Join(path, entry.Name()))
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// policyFile is the format of a policy file:
+//
+//	rules:
+//	  - name: job-owner-tag
+//	    match: resources.jobs.*
+//	    assert:
+//	      - path: tags.owner
+//	        exists: true
+//	  - name: no-all-purpose-clusters
+//	    match: resources.clusters.*
+//	    targets: [prod]
+//	    deny: true
+//	    message: All-purpose clusters are not allowed in production
+//
+// The match pattern selects values in the configuration. Each selected value
+// must satisfy all assertions of the rule, or is denied if deny is set.
+type policyFile struct {
+	Rules []policyRule `json:"rules"`
+}
+
+type policyRule struct {
+	// Name of the rule, included in the reported violation.
+	Name string `json:"name"`
+
+	// Pattern that selects the values in the configuration to check, e.g. "resources.jobs.*".
+	Match string `json:"match"`
+
+	// Targets the rule applies to. The rule applies to all targets if empty.
+	Targets []string `json:"targets,omitempty"`
+
+	// If true, every value selected by the pattern is a violation.
+	Deny bool `json:"deny,omitempty"`
+
+	// Assertions every value selected by the pattern must satisfy.
+	Assert []policyAssertion `json:"assert,omitempty"`
+
+	// Message reported on violation. Defaults to a description of the failed assertion.
+	Message string `json:"message,omitempty"`
+
+	// Severity of violations: "error" (default) or "warning".
+	Severity string `json:"severity,omitempty"`
+
+	pattern  dyn.Pattern
+	severity diag.Severity
+	location dyn.Location
+}
+
+// policyAssertion is a predicate on the field at Path, relative to the selected value.
+// Except for exists, assertions on fields that are not set are satisfied.
+type policyAssertion struct {
+	Path string `json:"path,omitempty"`
+
+	Exists   *bool    `json:"exists,omitempty"`
+	Equals   any      `json:"equals,omitempty"`
+	In       []any    `json:"in,omitempty"`
+	NotIn    []any    `json:"not_in,omitempty"`
+	Matches  string   `json:"matches,omitempty"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	MaxItems *int     `json:"max_items,omitempty"`
+
+	path    dyn.Path
+	matches *regexp.Regexp
+}
+
+func loadPolicyRules(file string) ([]*policyRule, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policies: %w", err)
+	}
+	defer f.Close()
+
+	v, err := yamlloader.LoadYAML(file, f)
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize to convert integers to floats and report fields with the wrong type.
+	var pf policyFile
+	v, diags := convert.Normalize(pf, v)
+	if err := diags.Error(); err != nil {
+		return nil, err
+	}
+	err = convert.ToTyped(&pf, v)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*policyRule
+	for i := range pf.Rules {
+		rule := &pf.Rules[i]
+		rule.location = v.Get("rules").Index(i).Location()
+		err := rule.init()
+		if err != nil {
+			return nil, fmt.Errorf("%s: rule %q: %w", rule.location, rule.Name, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (r *policyRule) init() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+
+	var err error
+	r.pattern, err = dyn.NewPatternFromString(r.Match)
+	if err != nil {
+		return fmt.Errorf("invalid match pattern %q: %w", r.Match, err)
+	}
+
+	switch r.Severity {
+	case "", "error":
+		r.severity = diag.Error
+	case "warning":
+		r.severity = diag.Warning
+	default:
+		return fmt.Errorf("invalid severity %q, expected error or warning", r.Severity)
+	}
+
+	if !r.Deny && len(r.Assert) == 0 {
+		return errors.New("either deny or assert must be set")
+	}
+
+	for i := range r.Assert {
+		a := &r.Assert[i]
+		if a.Path != "" {
+			a.path, err = dyn.NewPathFromString(a.Path)
+			if err != nil {
+				return fmt.Errorf("invalid path %q: %w", a.Path, err)
+			}
+		}
+		if a.Matches != "" {
+			a.matches, err = regexp.Compile(a.Matches)
+			if err != nil {
+				return fmt.Errorf("invalid regular expression %q: %w", a.Matches, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *policyRule) evaluate(root dyn.Value, target string) diag.Diagnostics {
+	if len(r.Targets) > 0 && !slices.Contains(r.Targets, target) {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	_, _ = dyn.MapByPattern(root, r.pattern, func(p dyn.Path, v dyn.Value) (dyn.Value, error) {
+		if r.Deny {
+			diags = diags.Append(r.violation(p, v, p.String()+" is not allowed"))
+			return v, nil
+		}
+
+		for _, a := range r.Assert {
+			fp := slices.Concat(p, a.path)
+			fv, err := dyn.GetByPath(v, a.path)
+			if err != nil {
+				fv = dyn.InvalidValue
+			}
+			if msg, ok := a.check(fp.String(), fv); !ok {
+				if fv.IsValid() {
+					diags = diags.Append(r.violation(fp, fv, msg))
+				} else {
+					diags = diags.Append(r.violation(p, v, msg))
+				}
+			}
+		}
+		return v, nil
+	})
+	return diags
+}
+
+func (r *policyRule) violation(p dyn.Path, v dyn.Value, msg string) diag.Diagnostic {
+	if r.Message != "" {
+		msg = r.Message
+	}
+	return diag.Diagnostic{
+		Severity:  r.severity,
+		Summary:   fmt.Sprintf("policy %s: %s", r.Name, msg),
+		Detail:    "Policy defined at " + r.location.String(),
+		Locations: v.Locations(),
+		Paths:     []dyn.Path{p},
+	}
+}
+
+// check returns whether the value at path satisfies the assertion, and a description
+// of the assertion if it does not.
+func (a *policyAssertion) check(path string, v dyn.Value) (string, bool) {
+	isSet := v.IsValid() && v.Kind() != dyn.KindNil
+
+	if a.Exists != nil {
+		if *a.Exists && !isSet {
+			return path + " must be set", false
+		}
+		if !*a.Exists && isSet {
+			return path + " must not be set", false
+		}
+	}
+
+	if !isSet {
+		return "", true
+	}
+
+	if a.Equals != nil && !policyValueEqual(v, a.Equals) {
+		return fmt.Sprintf("%s must be %v", path, a.Equals), false
+	}
+
+	if a.In != nil && !slices.ContainsFunc(a.In, func(x any) bool { return policyValueEqual(v, x) }) {
+		return fmt.Sprintf("%s must be one of %s", path, formatPolicyValues(a.In)), false
+	}
+
+	if slices.ContainsFunc(a.NotIn, func(x any) bool { return policyValueEqual(v, x) }) {
+		return fmt.Sprintf("%s must not be one of %s", path, formatPolicyValues(a.NotIn)), false
+	}
+
+	if a.matches != nil {
+		s, ok := v.AsString()
+		if !ok || !a.matches.MatchString(s) {
+			return fmt.Sprintf("%s must match %q", path, a.Matches), false
+		}
+	}
+
+	if a.Min != nil || a.Max != nil {
+		n, ok := policyNumber(v.AsAny())
+		if !ok {
+			return path + " must be a number", false
+		}
+		if a.Min != nil && n < *a.Min {
+			return fmt.Sprintf("%s must be at least %v", path, *a.Min), false
+		}
+		if a.Max != nil && n > *a.Max {
+			return fmt.Sprintf("%s must be at most %v", path, *a.Max), false
+		}
+	}
+
+	if a.MaxItems != nil {
+		var n int
+		if seq, ok := v.AsSequence(); ok {
+			n = len(seq)
+		} else if m, ok := v.AsMap(); ok {
+			n = m.Len()
+		} else {
+			return path + " must be a list or a map", false
+		}
+		if n > *a.MaxItems {
+			return fmt.Sprintf("%s must have at most %d items", path, *a.MaxItems), false
+		}
+	}
+
+	return "", true
+}
+
+func policyNumber(x any) (float64, bool) {
+	switch n := x.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// policyValueEqual compares a configuration value with a value from a policy file.
+// Numbers are compared by value, strings and booleans by their string representation.
+func policyValueEqual(v dyn.Value, expected any) bool {
+	if n, ok := policyNumber(v.AsAny()); ok {
+		e, ok := policyNumber(expected)
+		return ok && n == e
+	}
+	switch v.Kind() {
+	case dyn.KindString, dyn.KindBool:
+		return fmt.Sprint(v.AsAny()) == fmt.Sprint(expected)
+	default:
+		return false
+	}
+}
+
+func formatPolicyValues(values []any) string {
+	var s []string
+	for _, v := range values {
+		s = append(s, fmt.Sprint(v))
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
@@ -0,0 +1,145 @@
+package validate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/internal/testutil"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicies = `
+rules:
+  - name: job-owner-tag
+    match: resources.jobs.*
+    assert:
+      - path: tags.owner
+        exists: true
+  - name: max-workers
+    match: resources.jobs.*.job_clusters[*].new_cluster
+    assert:
+      - path: autoscale.max_workers
+        max: 20
+  - name: no-all-purpose-clusters
+    match: resources.clusters.*
+    targets: [prod]
+    deny: true
+    message: All-purpose clusters are not allowed in production
+    severity: warning
+`
+
+func loadPolicyTestBundle(t *testing.T, target string) *bundle.Bundle {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "databricks.yml")
+	testutil.WriteFile(t, filepath.Join(dir, "policies", "rules.yml"), testPolicies)
+	testutil.WriteFile(t, path, `
+bundle:
+  name: test
+  policies:
+    - ./policies
+resources:
+  jobs:
+    tagged:
+      tags:
+        owner: team
+    untagged:
+      job_clusters:
+        - job_cluster_key: main
+          new_cluster:
+            autoscale:
+              max_workers: 40
+  clusters:
+    interactive:
+      cluster_name: interactive
+`)
+
+	root, diags := config.Load(path)
+	require.NoError(t, diags.Error())
+	root.Bundle.Target = target
+	return &bundle.Bundle{BundleRootPath: dir, Config: *root}
+}
+
+func TestPolicies(t *testing.T) {
+	b := loadPolicyTestBundle(t, "dev")
+	path := filepath.Join(b.BundleRootPath, "databricks.yml")
+	policyPath := filepath.Join(b.BundleRootPath, "policies", "rules.yml")
+
+	diags := Policies().Apply(t.Context(), b)
+	assert.Equal(t, diag.Diagnostics{
+		{
+			Severity:  diag.Error,
+			Summary:   "policy job-owner-tag: resources.jobs.untagged.tags.owner must be set",
+			Detail:    "Policy defined at " + policyPath + ":3:5",
+			Locations: []dyn.Location{{File: path, Line: 12, Column: 7}},
+			Paths:     []dyn.Path{dyn.MustPathFromString("resources.jobs.untagged")},
+		},
+		{
+			Severity:  diag.Error,
+			Summary:   "policy max-workers: resources.jobs.untagged.job_clusters[0].new_cluster.autoscale.max_workers must be at most 20",
+			Detail:    "Policy defined at " + policyPath + ":8:5",
+			Locations: []dyn.Location{{File: path, Line: 16, Column: 28}},
+			Paths:     []dyn.Path{dyn.MustPathFromString("resources.jobs.untagged.job_clusters[0].new_cluster.autoscale.max_workers")},
+		},
+	}, diags)
+}
+
+func TestPoliciesForTarget(t *testing.T) {
+	b := loadPolicyTestBundle(t, "prod")
+
+	diags := PolicyPaths([]string{filepath.Join(b.BundleRootPath, "policies")}).Apply(t.Context(), b)
+	require.Len(t, diags, 3)
+	assert.Equal(t, diag.Warning, diags[2].Severity)
+	assert.Equal(t, "policy no-all-purpose-clusters: All-purpose clusters are not allowed in production", diags[2].Summary)
+}
+
+func TestPolicyAssertions(t *testing.T) {
+	yes := true
+	no := false
+	low := 1.0
+	high := 10.0
+	maxItems := 1
+
+	for _, tc := range []struct {
+		name      string
+		assertion policyAssertion
+		value     dyn.Value
+		message   string
+	}{
+		{"exists", policyAssertion{Exists: &yes}, dyn.V("x"), ""},
+		{"exists violated", policyAssertion{Exists: &yes}, dyn.InvalidValue, "f must be set"},
+		{"not exists violated", policyAssertion{Exists: &no}, dyn.V("x"), "f must not be set"},
+		{"unset value", policyAssertion{Equals: "x"}, dyn.InvalidValue, ""},
+		{"equals", policyAssertion{Equals: int64(2)}, dyn.V(2), ""},
+		{"equals violated", policyAssertion{Equals: "a"}, dyn.V("b"), "f must be a"},
+		{"in", policyAssertion{In: []any{"a", "b"}}, dyn.V("b"), ""},
+		{"in violated", policyAssertion{In: []any{"a", "b"}}, dyn.V("c"), "f must be one of [a, b]"},
+		{"not in violated", policyAssertion{NotIn: []any{true}}, dyn.V(true), "f must not be one of [true]"},
+		{"min violated", policyAssertion{Min: &low}, dyn.V(0), "f must be at least 1"},
+		{"max", policyAssertion{Max: &high}, dyn.V(10.0), ""},
+		{"max not a number", policyAssertion{Max: &high}, dyn.V("10"), "f must be a number"},
+		{"max items violated", policyAssertion{MaxItems: &maxItems}, dyn.V([]dyn.Value{dyn.V(1), dyn.V(2)}), "f must have at most 1 items"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, ok := tc.assertion.check("f", tc.value)
+			assert.Equal(t, tc.message == "", ok)
+			assert.Equal(t, tc.message, msg)
+		})
+	}
+}
+
+func TestPolicyRulesInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yml")
+	testutil.WriteFile(t, path, `
+rules:
+  - name: bad
+    match: resources.jobs.*
+`)
+
+	_, err := loadPolicyRules(path)
+	assert.EqualError(t, err, path+`:3:5: rule "bad": either deny or assert must be set`)
+}
@@ -28,11 +28,27 @@ type Lookup struct {
 
 	Query string `json:"query,omitempty"`
 
+	Secret *SecretLookup `json:"secret,omitempty"`
+
 	ServicePrincipal string `json:"service_principal,omitempty"`
 
 	Warehouse string `json:"warehouse,omitempty"`
 }
 
+// SecretLookup identifies a secret in a Databricks secret scope.
+// The value of the secret is assigned to the variable.
+type SecretLookup struct {
+	Scope string `json:"scope"`
+
+	Key string `json:"key"`
+}
+
+// hasSecret returns true if the lookup refers to a secret.
+// The secret may be set to an empty struct by configuration normalization.
+func (l *Lookup) hasSecret() bool {
+	return l.Secret != nil && *l.Secret != (SecretLookup{})
+}
+
 type resolver interface {
 	// Resolve resolves the underlying entity's ID.
 	Resolve(ctx context.Context, w *databricks.WorkspaceClient) (string, error)
@@ -74,6 +90,9 @@ func (l *Lookup) constructResolver() (resolver, error) {
 	if l.Query != "" {
 		resolvers = append(resolvers, resolveQuery{name: l.Query})
 	}
+	if l.hasSecret() {
+		resolvers = append(resolvers, resolveSecret{scope: l.Secret.Scope, key: l.Secret.Key})
+	}
 	if l.ServicePrincipal != "" {
 		resolvers = append(resolvers, resolveServicePrincipal{name: l.ServicePrincipal})
 	}
@@ -0,0 +1,36 @@
+package variable
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+)
+
+type resolveSecret struct {
+	scope string
+	key   string
+}
+
+func (l resolveSecret) Resolve(ctx context.Context, w *databricks.WorkspaceClient) (string, error) {
+	secret, err := w.Secrets.GetSecret(ctx, workspace.GetSecretRequest{
+		Scope: l.scope,
+		Key:   l.key,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// The API returns the value of the secret base64 encoded.
+	value, err := base64.StdEncoding.DecodeString(secret.Value)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode value of secret %q in scope %q: %w", l.key, l.scope, err)
+	}
+	return string(value), nil
+}
+
+func (l resolveSecret) String() string {
+	return fmt.Sprintf("secret: %s/%s", l.scope, l.key)
+}
@@ -0,0 +1,50 @@
+package variable
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecret_ResolveSuccess(t *testing.T) {
+	m := mocks.NewMockWorkspaceClient(t)
+
+	api := m.GetMockSecretsAPI()
+	api.EXPECT().
+		GetSecret(mock.Anything, workspace.GetSecretRequest{Scope: "scope", Key: "key"}).
+		Return(&workspace.GetSecretResponse{
+			Key:   "key",
+			Value: base64.StdEncoding.EncodeToString([]byte("hunter2")),
+		}, nil)
+
+	ctx := t.Context()
+	l := resolveSecret{scope: "scope", key: "key"}
+	result, err := l.Resolve(ctx, m.WorkspaceClient)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", result)
+}
+
+func TestResolveSecret_ResolveNotFound(t *testing.T) {
+	m := mocks.NewMockWorkspaceClient(t)
+
+	api := m.GetMockSecretsAPI()
+	api.EXPECT().
+		GetSecret(mock.Anything, mock.Anything).
+		Return(nil, &apierr.APIError{StatusCode: 404, Message: "Secret does not exist"})
+
+	ctx := t.Context()
+	l := resolveSecret{scope: "scope", key: "key"}
+	_, err := l.Resolve(ctx, m.WorkspaceClient)
+	require.ErrorContains(t, err, "Secret does not exist")
+}
+
+func TestResolveSecret_String(t *testing.T) {
+	l := resolveSecret{scope: "scope", key: "key"}
+	assert.Equal(t, "secret: scope/key", l.String())
+}
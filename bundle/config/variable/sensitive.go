@@ -0,0 +1,79 @@
+package variable
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/libs/dyn"
+)
+
+// SensitiveValues returns the values of sensitive variables by variable name.
+// Only non-empty string values are returned; these are the only values that
+// can be interpolated into the configuration.
+func SensitiveValues(vars map[string]*Variable) map[string]string {
+	out := make(map[string]string)
+	for name, v := range vars {
+		if v == nil || !v.IsSensitive() {
+			continue
+		}
+		if s, ok := v.Value.(string); ok && s != "" {
+			out[name] = s
+		}
+	}
+	return out
+}
+
+// Reference returns the reference to the variable with the specified name,
+// e.g. "${var.foo}".
+func Reference(name string) string {
+	return "${var." + name + "}"
+}
+
+// NewRedactor returns a replacer that substitutes the specified sensitive
+// values with a reference to their variable. The escape function is applied to
+// both the values and the references, e.g. to match them in encoded JSON.
+//
+// Longer values are replaced first, so a value that contains another value is
+// replaced as a whole.
+func NewRedactor(values map[string]string, escape func(string) string) *strings.Replacer {
+	names := slices.SortedFunc(maps.Keys(values), func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(values[b]), len(values[a])), cmp.Compare(a, b))
+	})
+
+	var oldnew []string
+	for _, name := range names {
+		oldnew = append(oldnew, escape(values[name]), escape(Reference(name)))
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+// NewRestorer returns a replacer that reverses [NewRedactor].
+func NewRestorer(values map[string]string, escape func(string) string) *strings.Replacer {
+	var oldnew []string
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		oldnew = append(oldnew, escape(Reference(name)), escape(values[name]))
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+// Redact replaces the values of sensitive variables in all strings in v with
+// a reference to their variable.
+func Redact(v dyn.Value, values map[string]string) (dyn.Value, error) {
+	if len(values) == 0 {
+		return v, nil
+	}
+
+	r := NewRedactor(values, func(s string) string { return s })
+	return dyn.Walk(v, func(p dyn.Path, v dyn.Value) (dyn.Value, error) {
+		s, ok := v.AsString()
+		if !ok {
+			return v, nil
+		}
+		if redacted := r.Replace(s); redacted != s {
+			return dyn.NewValue(redacted, v.Locations()), nil
+		}
+		return v, nil
+	})
+}
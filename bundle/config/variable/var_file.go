@@ -0,0 +1,106 @@
+package variable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// envPrefix is the prefix of environment variables that set variable values.
+const envPrefix = "BUNDLE_VAR_"
+
+// ReadVarFile reads variable values from a .env-style file.
+// See [ParseVarFile] for the format of the file.
+func ReadVarFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read variable file: %w", err)
+	}
+	defer f.Close()
+
+	values, err := ParseVarFile(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+// ParseVarFile parses variable values from a .env-style file:
+//
+//	# Comment
+//	foo=bar
+//	export BUNDLE_VAR_baz="value with spaces"
+//
+// Empty lines and lines starting with # are ignored. A leading "export" and
+// the BUNDLE_VAR_ prefix are optional, so files written for the environment
+// can be used as is. Values may be enclosed in single quotes (taken literally)
+// or double quotes (Go escape sequences are interpreted). Unquoted values end
+// at an unquoted " #".
+func ParseVarFile(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected name=value", lineno)
+		}
+
+		name = strings.TrimPrefix(strings.TrimSpace(name), envPrefix)
+		if name == "" {
+			return nil, fmt.Errorf("line %d: variable name is empty", lineno)
+		}
+
+		value, err := parseVarFileValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineno, err)
+		}
+		values[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func parseVarFileValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		prefix, err := strconv.QuotedPrefix(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid double quoted value: %s", value)
+		}
+		if !isTrailingComment(value[len(prefix):]) {
+			return "", fmt.Errorf("unexpected characters after double quoted value: %s", value)
+		}
+		return strconv.Unquote(prefix)
+	case strings.HasPrefix(value, "'"):
+		end := strings.Index(value[1:], "'")
+		if end < 0 {
+			return "", fmt.Errorf("missing closing quote: %s", value)
+		}
+		if !isTrailingComment(value[end+2:]) {
+			return "", fmt.Errorf("unexpected characters after single quoted value: %s", value)
+		}
+		return value[1 : end+1], nil
+	default:
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		return strings.TrimSpace(value), nil
+	}
+}
+
+// isTrailingComment returns true if s is empty or a comment.
+func isTrailingComment(s string) bool {
+	s = strings.TrimSpace(s)
+	return s == "" || strings.HasPrefix(s, "#")
+}
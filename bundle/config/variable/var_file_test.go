@@ -0,0 +1,54 @@
+package variable
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVarFile(t *testing.T) {
+	values, err := ParseVarFile(strings.NewReader(`
+# Comment
+foo=bar
+export BUNDLE_VAR_baz = "value with \"quotes\"\n" # comment
+single='a "b"'
+literal='$HOME \n' # comment
+unquoted=a b #comment
+hash=a#b
+empty=
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"foo":      "bar",
+		"baz":      "value with \"quotes\"\n",
+		"single":   `a "b"`,
+		"literal":  `$HOME \n`,
+		"unquoted": "a b",
+		"hash":     "a#b",
+		"empty":    "",
+	}, values)
+}
+
+func TestParseVarFileErrors(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		err   string
+	}{
+		{"foo", "line 1: expected name=value"},
+		{"\n=bar", "line 2: variable name is empty"},
+		{`foo="bar`, `line 1: invalid double quoted value: "bar`},
+		{`foo="bar" baz`, `line 1: unexpected characters after double quoted value: "bar" baz`},
+		{`foo='bar`, `line 1: missing closing quote: 'bar`},
+	} {
+		_, err := ParseVarFile(strings.NewReader(tc.input))
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestReadVarFileNotFound(t *testing.T) {
+	_, err := ReadVarFile(filepath.Join(t.TempDir(), "missing.env"))
+	assert.ErrorContains(t, err, "failed to read variable file")
+}
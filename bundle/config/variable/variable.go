@@ -43,11 +43,14 @@ type Variable struct {
 	// resolved in the following priority order (from highest to lowest)
 	//
 	// 1. Command line flag `--var="foo=bar"`
-	// 2. Environment variable. eg: BUNDLE_VAR_foo=bar
-	// 3. Load defaults from .databricks/bundle/<target>/variable-overrides.json
-	// 4. Default value as defined in the applicable targets block
-	// 5. Default value defined in variable definition
-	// 6. Throw error, since if no default value is defined, then the variable
+	// 2. Variable file passed with `--var-file`
+	// 3. Variable file set with `var_file` in the applicable targets block
+	// 4. Environment variable. eg: BUNDLE_VAR_foo=bar
+	// 5. Lookup, including secrets
+	// 6. Load defaults from .databricks/bundle/<target>/variable-overrides.json
+	// 7. Default value as defined in the applicable targets block
+	// 8. Default value defined in variable definition
+	// 9. Throw error, since if no default value is defined, then the variable
 	//    is required
 	Value VariableValue `json:"value,omitempty" bundle:"readonly"`
 
@@ -91,6 +94,12 @@ func (v *Variable) IsComplexValued() bool {
 	}
 }
 
+// IsSensitive returns true if the value of the variable must not be printed
+// or stored in plans, because it is read from a secret scope.
+func (v *Variable) IsSensitive() bool {
+	return v.Lookup != nil && v.Lookup.hasSecret()
+}
+
 func (v *Variable) IsComplex() bool {
 	return v.Type == VariableTypeComplex
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/databricks/cli/bundle/env"
 	"github.com/databricks/cli/libs/auth"
 	"github.com/databricks/cli/libs/databrickscfg"
+	"github.com/databricks/cli/libs/httprecord"
 	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/config"
 	"github.com/databricks/databricks-sdk-go/marshal"
@@ -148,6 +149,9 @@ func (w *Workspace) Config(ctx context.Context) *config.Config {
 		WorkspaceID: w.WorkspaceID,
 	}
 
+	// Record or replay the requests of the bundle's client if asked to.
+	httprecord.Configure(ctx, cfg)
+
 	for k := range config.ConfigAttributes {
 		attr := &config.ConfigAttributes[k]
 		if !attr.IsZero(cfg) {
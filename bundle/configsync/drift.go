@@ -0,0 +1,135 @@
+package configsync
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/databricks/cli/bundle/direct/dstate"
+	"github.com/databricks/cli/libs/structs/structdiff"
+)
+
+// FieldDrift is a field whose value in the workspace differs from its last-deployed value.
+type FieldDrift struct {
+	Path string `json:"path"`
+
+	// Deployed is the value at the last deployment, Remote the current value in the
+	// workspace and Config the value in the current bundle configuration.
+	Deployed any `json:"deployed,omitempty"`
+	Remote   any `json:"remote,omitempty"`
+	Config   any `json:"config,omitempty"`
+
+	// ConfigChanged is set when the configuration of the field also changed since the
+	// last deployment. Deploying then overwrites the remote change with the new value.
+	ConfigChanged bool `json:"config_changed,omitempty"`
+}
+
+// ResourceDrift lists the changes made outside the bundle to a deployed resource.
+type ResourceDrift struct {
+	ResourceKey string `json:"resource_key"`
+
+	// Deleted is set when the resource no longer exists in the workspace.
+	Deleted bool `json:"deleted,omitempty"`
+
+	Fields []FieldDrift `json:"fields,omitempty"`
+}
+
+// DriftReport is the output of "bundle drift".
+type DriftReport struct {
+	Resources []ResourceDrift `json:"resources"`
+}
+
+// DetectDrift reports the resources in the deployment state whose remote state differs
+// from the last-deployed state. It relies on the per-field changes of a direct engine
+// plan, which compare the last-deployed, remote and configured value of each field.
+//
+// Changes suppressed by the plan (e.g. backend defaults or lifecycle.ignore_changes)
+// are not reported, except for remote changes that already match the configuration.
+func DetectDrift(plan *deployplan.Plan, state *dstate.DeploymentState) *DriftReport {
+	report := &DriftReport{Resources: []ResourceDrift{}}
+
+	for _, resourceKey := range slices.Sorted(maps.Keys(plan.Plan)) {
+		entry := plan.Plan[resourceKey]
+
+		// Resources that were never deployed cannot drift.
+		if state.GetResourceID(resourceKey) == "" {
+			continue
+		}
+
+		r := ResourceDrift{ResourceKey: resourceKey}
+		if entry.RemoteState == nil {
+			r.Deleted = entry.Action == deployplan.Create || entry.Gone
+			if r.Deleted {
+				report.Resources = append(report.Resources, r)
+			}
+			continue
+		}
+
+		for _, path := range slices.Sorted(maps.Keys(entry.Changes)) {
+			ch := entry.Changes[path]
+			if ch.Action == deployplan.Skip && ch.Reason != deployplan.ReasonRemoteAlreadySet {
+				continue
+			}
+			if structdiff.IsEqual(ch.Old, ch.Remote) {
+				continue
+			}
+			r.Fields = append(r.Fields, FieldDrift{
+				Path:          path,
+				Deployed:      ch.Old,
+				Remote:        ch.Remote,
+				Config:        ch.New,
+				ConfigChanged: !structdiff.IsEqual(ch.Old, ch.New),
+			})
+		}
+
+		if len(r.Fields) > 0 {
+			report.Resources = append(report.Resources, r)
+		}
+	}
+
+	return report
+}
+
+// FormatDriftText formats the drift report as human-readable text.
+func FormatDriftText(report *DriftReport) string {
+	var output strings.Builder
+
+	if len(report.Resources) == 0 {
+		output.WriteString("No drift detected.\n")
+		return output.String()
+	}
+
+	for _, r := range report.Resources {
+		key := strings.TrimPrefix(r.ResourceKey, "resources.")
+		if r.Deleted {
+			fmt.Fprintf(&output, "- %s: deleted outside the bundle\n", key)
+			continue
+		}
+
+		fmt.Fprintf(&output, "~ %s\n", key)
+		for _, f := range r.Fields {
+			fmt.Fprintf(&output, "    %s: %s -> %s", f.Path, formatDriftValue(f.Deployed), formatDriftValue(f.Remote))
+			if f.ConfigChanged {
+				fmt.Fprintf(&output, " (configuration changed to %s)", formatDriftValue(f.Config))
+			}
+			output.WriteString("\n")
+		}
+	}
+
+	fmt.Fprintf(&output, "\nDrift detected in %d resource(s).\n", len(report.Resources))
+	return output.String()
+}
+
+func formatDriftValue(v any) string {
+	if v == nil {
+		return "(unset)"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,64 @@
+package configsync
+
+import (
+	"testing"
+
+	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/databricks/cli/bundle/direct/dstate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectDrift(t *testing.T) {
+	var state dstate.DeploymentState
+	state.OpenWithData("state.json", dstate.Database{
+		State: map[string]dstate.ResourceEntry{
+			"resources.jobs.edited":    {ID: "1"},
+			"resources.jobs.unchanged": {ID: "2"},
+			"resources.jobs.deleted":   {ID: "3"},
+		},
+	})
+
+	plan := deployplan.NewPlanDirect()
+	plan.Plan["resources.jobs.edited"] = &deployplan.PlanEntry{
+		Action:      deployplan.Update,
+		RemoteState: map[string]any{},
+		Changes: deployplan.Changes{
+			"name":                {Action: deployplan.Update, Old: "a", New: "a", Remote: "b"},
+			"max_concurrent_runs": {Action: deployplan.Update, Old: 1, New: 3, Remote: 2},
+			"timeout_seconds":     {Action: deployplan.Update, Old: 10, New: 20, Remote: 10},
+			"description":         {Action: deployplan.Skip, Reason: deployplan.ReasonRemoteAlreadySet, Old: "x", New: "y", Remote: "y"},
+			"email_notifications": {Action: deployplan.Skip, Reason: deployplan.ReasonBackendDefault, Remote: map[string]any{}},
+		},
+	}
+	plan.Plan["resources.jobs.unchanged"] = &deployplan.PlanEntry{Action: deployplan.Skip, RemoteState: map[string]any{}}
+	plan.Plan["resources.jobs.deleted"] = &deployplan.PlanEntry{Action: deployplan.Create}
+	plan.Plan["resources.jobs.new"] = &deployplan.PlanEntry{Action: deployplan.Create}
+
+	report := DetectDrift(plan, &state)
+	assert.Equal(t, &DriftReport{
+		Resources: []ResourceDrift{
+			{ResourceKey: "resources.jobs.deleted", Deleted: true},
+			{
+				ResourceKey: "resources.jobs.edited",
+				Fields: []FieldDrift{
+					{Path: "description", Deployed: "x", Remote: "y", Config: "y", ConfigChanged: true},
+					{Path: "max_concurrent_runs", Deployed: 1, Remote: 2, Config: 3, ConfigChanged: true},
+					{Path: "name", Deployed: "a", Remote: "b", Config: "a"},
+				},
+			},
+		},
+	}, report)
+
+	assert.Equal(t, `- jobs.deleted: deleted outside the bundle
+~ jobs.edited
+    description: "x" -> "y" (configuration changed to "y")
+    max_concurrent_runs: 1 -> 2 (configuration changed to 3)
+    name: "a" -> "b"
+
+Drift detected in 2 resource(s).
+`, FormatDriftText(report))
+}
+
+func TestFormatDriftTextNoDrift(t *testing.T) {
+	assert.Equal(t, "No drift detected.\n", FormatDriftText(&DriftReport{}))
+}
@@ -0,0 +1,274 @@
+package deploy
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/variable"
+	"github.com/databricks/cli/internal/build"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/jsonloader"
+	"github.com/databricks/cli/libs/dyn/jsonsaver"
+	"github.com/databricks/cli/libs/filer"
+	"github.com/databricks/cli/libs/log"
+)
+
+const (
+	// DeploymentHistoryDir is the directory in the workspace state directory that
+	// holds snapshots of successful deployments, one file per state serial.
+	DeploymentHistoryDir = "deployments"
+
+	// DefaultKeepDeployments is the number of deployment snapshots kept if
+	// bundle.deployment.keep_deployments is not set.
+	DefaultKeepDeployments = 5
+)
+
+// DeploymentSnapshot records a successful deployment so that its resources can be
+// re-applied by "bundle deployment rollback".
+type DeploymentSnapshot struct {
+	// Serial and Lineage identify the resources state written by the deployment.
+	Serial  int    `json:"serial"`
+	Lineage string `json:"lineage"`
+
+	Timestamp  time.Time `json:"timestamp"`
+	CliVersion string    `json:"cli_version"`
+	GitCommit  string    `json:"git_commit,omitempty"`
+	GitBranch  string    `json:"git_branch,omitempty"`
+
+	// FilePath is the workspace path the files were uploaded to and Files the
+	// uploaded files. SnapshotPath is set for immutable folder deployments, whose
+	// files remain available at their content-addressed location.
+	FilePath     string   `json:"file_path"`
+	SnapshotPath string   `json:"snapshot_path,omitempty"`
+	Files        Filelist `json:"files,omitempty"`
+
+	// Resources is the resolved resources configuration. Values of sensitive
+	// variables are replaced by a reference to the variables listed in
+	// SensitiveVariables and restored from the configuration on rollback.
+	Resources          json.RawMessage `json:"resources"`
+	SensitiveVariables []string        `json:"sensitive_variables,omitempty"`
+}
+
+type saveDeploymentSnapshot struct {
+	filerFactory FilerFactory
+}
+
+// SaveDeploymentSnapshot returns a mutator that records the current deployment in the
+// workspace state directory and removes the oldest snapshots beyond
+// bundle.deployment.keep_deployments. It must run after the resources state is pushed.
+//
+// A deployment that succeeded is not failed because its snapshot could not be saved:
+// errors are logged as warnings.
+func SaveDeploymentSnapshot() bundle.Mutator {
+	return &saveDeploymentSnapshot{StateFiler}
+}
+
+func (m *saveDeploymentSnapshot) Name() string {
+	return "deploy:save-deployment-snapshot"
+}
+
+func (m *saveDeploymentSnapshot) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	err := m.save(ctx, b)
+	if err != nil {
+		log.Warnf(ctx, "Failed to save deployment snapshot for rollback: %v", err)
+	}
+	return nil
+}
+
+func (m *saveDeploymentSnapshot) save(ctx context.Context, b *bundle.Bundle) error {
+	snapshot, err := newDeploymentSnapshot(ctx, b)
+	if err != nil {
+		return err
+	}
+
+	f, err := m.filerFactory(ctx, b)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	name := path.Join(DeploymentHistoryDir, snapshotFileName(snapshot.Serial))
+	err = f.Write(ctx, name, bytes.NewReader(data), filer.CreateParentDirectories, filer.OverwriteIfExists)
+	if err != nil {
+		return err
+	}
+	log.Infof(ctx, "Saved deployment snapshot %s", name)
+
+	keep := cmp.Or(b.Config.Bundle.Deployment.KeepDeployments, DefaultKeepDeployments)
+	serials, err := listSnapshotSerials(ctx, f)
+	if err != nil {
+		return err
+	}
+	for len(serials) > keep {
+		name := path.Join(DeploymentHistoryDir, snapshotFileName(serials[0]))
+		if err := f.Delete(ctx, name); err != nil {
+			return err
+		}
+		serials = serials[1:]
+	}
+	return nil
+}
+
+func newDeploymentSnapshot(ctx context.Context, b *bundle.Bundle) (*DeploymentSnapshot, error) {
+	// The serial of the resources state identifies the deployment.
+	_, statePath := b.StateFilenameDirect(ctx)
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading resources state: %w", err)
+	}
+	var header struct {
+		Lineage string `json:"lineage"`
+		Serial  int    `json:"serial"`
+	}
+	err = json.Unmarshal(data, &header)
+	if err != nil {
+		return nil, fmt.Errorf("parsing resources state: %w", err)
+	}
+
+	state, err := load(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	values := variable.SensitiveValues(b.Config.Variables)
+	resources, err := variable.Redact(b.Config.Value().Get("resources"), values)
+	if err != nil {
+		return nil, err
+	}
+	resourcesJSON, err := jsonsaver.Marshal(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeploymentSnapshot{
+		Serial:             header.Serial,
+		Lineage:            header.Lineage,
+		Timestamp:          time.Now().UTC(),
+		CliVersion:         build.GetInfo().Version,
+		GitCommit:          b.Config.Bundle.Git.Commit,
+		GitBranch:          b.Config.Bundle.Git.ActualBranch,
+		FilePath:           b.Config.Workspace.FilePath,
+		SnapshotPath:       b.Config.Workspace.SnapshotPath,
+		Files:              state.Files,
+		Resources:          resourcesJSON,
+		SensitiveVariables: slices.Sorted(maps.Keys(values)),
+	}, nil
+}
+
+func snapshotFileName(serial int) string {
+	return strconv.Itoa(serial) + ".json"
+}
+
+// listSnapshotSerials returns the serials of the saved deployment snapshots in increasing order.
+func listSnapshotSerials(ctx context.Context, f filer.Filer) ([]int, error) {
+	entries, err := f.ReadDir(ctx, DeploymentHistoryDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var serials []int
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok {
+			continue
+		}
+		serial, err := strconv.Atoi(name)
+		if err != nil {
+			continue
+		}
+		serials = append(serials, serial)
+	}
+	slices.Sort(serials)
+	return serials, nil
+}
+
+// ListDeploymentSnapshots returns the saved deployment snapshots, most recent first.
+func ListDeploymentSnapshots(ctx context.Context, f filer.Filer) ([]*DeploymentSnapshot, error) {
+	serials, err := listSnapshotSerials(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []*DeploymentSnapshot
+	for _, serial := range slices.Backward(serials) {
+		s, err := ReadDeploymentSnapshot(ctx, f, serial)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, nil
+}
+
+// ReadDeploymentSnapshot reads the snapshot of the deployment with the given state serial.
+func ReadDeploymentSnapshot(ctx context.Context, f filer.Filer, serial int) (*DeploymentSnapshot, error) {
+	name := path.Join(DeploymentHistoryDir, snapshotFileName(serial))
+	r, err := f.Read(ctx, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no deployment snapshot with serial %d", serial)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var s DeploymentSnapshot
+	err = json.Unmarshal(data, &s)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", name, err)
+	}
+	return &s, nil
+}
+
+// Restore replaces the resources in the configuration of b with the resources of the snapshot.
+// Values of sensitive variables are taken from the current configuration.
+func (s *DeploymentSnapshot) Restore(b *bundle.Bundle) error {
+	values := variable.SensitiveValues(b.Config.Variables)
+	for _, name := range s.SensitiveVariables {
+		if _, ok := values[name]; !ok {
+			return fmt.Errorf("deployment %d uses the value of sensitive variable %q, which is not set in the current configuration", s.Serial, name)
+		}
+	}
+
+	data := variable.NewRestorer(values, escapeJSON).Replace(string(s.Resources))
+	resources, err := jsonloader.LoadJSON([]byte(data), "deployment "+strconv.Itoa(s.Serial))
+	if err != nil {
+		return err
+	}
+
+	return b.Config.Mutate(func(root dyn.Value) (dyn.Value, error) {
+		return dyn.Set(root, "resources", resources)
+	})
+}
+
+// escapeJSON returns s as it appears inside a JSON encoded string.
+func escapeJSON(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data[1 : len(data)-1])
+}
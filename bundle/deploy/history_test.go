@@ -0,0 +1,117 @@
+package deploy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/config/variable"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/filer"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBundleForSnapshot(t *testing.T, jobName string) *bundle.Bundle {
+	b := &bundle.Bundle{
+		BundleRootPath: t.TempDir(),
+		Config: config.Root{
+			Bundle: config.Bundle{
+				Target: "default",
+				Deployment: config.Deployment{
+					KeepDeployments: 2,
+				},
+			},
+			Workspace: config.Workspace{
+				StatePath: "/state",
+				FilePath:  "/files",
+			},
+			Variables: map[string]*variable.Variable{
+				"token": {
+					Value:  "s3cr\"et",
+					Lookup: &variable.Lookup{Secret: &variable.SecretLookup{Scope: "scope", Key: "key"}},
+				},
+			},
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"my_job": {
+						JobSettings: jobs.JobSettings{
+							Name: jobName,
+							Tags: map[string]string{"token": "s3cr\"et"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Populate the dynamic configuration value from the typed configuration.
+	require.NoError(t, b.Config.Mutate(func(v dyn.Value) (dyn.Value, error) { return v, nil }))
+	return b
+}
+
+func writeDirectState(t *testing.T, ctx context.Context, b *bundle.Bundle, serial int) {
+	_, statePath := b.StateFilenameDirect(ctx)
+	require.NoError(t, os.MkdirAll(filepath.Dir(statePath), 0o755))
+	data := `{"lineage": "abc", "serial": ` + strconv.Itoa(serial) + `, "state": {}}`
+	require.NoError(t, os.WriteFile(statePath, []byte(data), 0o644))
+}
+
+func TestSaveDeploymentSnapshot(t *testing.T) {
+	ctx := t.Context()
+	f, err := filer.NewLocalClient(t.TempDir())
+	require.NoError(t, err)
+	m := &saveDeploymentSnapshot{func(context.Context, *bundle.Bundle) (filer.Filer, error) {
+		return f, nil
+	}}
+
+	for serial := 1; serial <= 3; serial++ {
+		b := setupBundleForSnapshot(t, "job "+strconv.Itoa(serial))
+		writeDirectState(t, ctx, b, serial)
+		diags := bundle.Apply(ctx, b, m)
+		require.NoError(t, diags.Error())
+	}
+
+	// Only the last 2 deployments are kept.
+	snapshots, err := ListDeploymentSnapshots(ctx, f)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, 3, snapshots[0].Serial)
+	assert.Equal(t, 2, snapshots[1].Serial)
+	assert.Equal(t, "abc", snapshots[1].Lineage)
+	assert.Equal(t, "/files", snapshots[1].FilePath)
+	assert.Equal(t, []string{"token"}, snapshots[1].SensitiveVariables)
+
+	// Values of sensitive variables are not saved.
+	assert.NotContains(t, string(snapshots[1].Resources), "s3cr")
+	assert.Contains(t, string(snapshots[1].Resources), "${var.token}")
+
+	_, err = ReadDeploymentSnapshot(ctx, f, 1)
+	assert.ErrorContains(t, err, "no deployment snapshot with serial 1")
+
+	// Restoring the snapshot brings back its resources with the current values of sensitive variables.
+	b := setupBundleForSnapshot(t, "job 4")
+	require.NoError(t, snapshots[1].Restore(b))
+	job := b.Config.Resources.Jobs["my_job"]
+	assert.Equal(t, "job 2", job.Name)
+	assert.Equal(t, map[string]string{"token": "s3cr\"et"}, job.Tags)
+}
+
+func TestRestoreDeploymentSnapshotMissingSensitiveVariable(t *testing.T) {
+	s := &DeploymentSnapshot{
+		Serial:             7,
+		Resources:          []byte(`{"jobs": {}}`),
+		SensitiveVariables: []string{"token"},
+	}
+
+	b := setupBundleForSnapshot(t, "job")
+	b.Config.Variables = nil
+	err := s.Restore(b)
+	assert.ErrorContains(t, err, `deployment 7 uses the value of sensitive variable "token", which is not set in the current configuration`)
+}
@@ -12,10 +12,11 @@ import (
 type Goal string
 
 const (
-	GoalBind    = Goal("bind")
-	GoalUnbind  = Goal("unbind")
-	GoalDeploy  = Goal("deploy")
-	GoalDestroy = Goal("destroy")
+	GoalBind      = Goal("bind")
+	GoalUnbind    = Goal("unbind")
+	GoalDeploy    = Goal("deploy")
+	GoalDestroy   = Goal("destroy")
+	GoalStateEdit = Goal("state")
 )
 
 type release struct {
@@ -48,7 +49,7 @@ func (m *release) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics
 	switch m.goal {
 	case GoalDeploy:
 		return diag.FromErr(b.Locker.Unlock(ctx))
-	case GoalBind, GoalUnbind:
+	case GoalBind, GoalUnbind, GoalStateEdit:
 		return diag.FromErr(b.Locker.Unlock(ctx))
 	case GoalDestroy:
 		// Destroy may have proceeded without acquiring a lock (see lock.Acquire:
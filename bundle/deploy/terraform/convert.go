@@ -77,6 +77,11 @@ func BundleToTerraformWithDynValue(ctx context.Context, root dyn.Value, skipUnsu
 		return nil, err
 	}
 
+	tfroot.Moved, err = movedBlocks(root, tfroot.Resource)
+	if err != nil {
+		return nil, err
+	}
+
 	// We explicitly set "resource" to nil to omit it from a JSON encoding.
 	// This is required because the terraform CLI requires >= 1 resources defined
 	// if the "resource" property is used in a .tf.json file.
@@ -0,0 +1,86 @@
+package terraform
+
+import (
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/internal/tf/schema"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/convert"
+)
+
+// movedBlocks returns the Terraform moved blocks for the moved blocks in the
+// bundle configuration, so that Terraform renames resources in its state instead
+// of replacing them. Permissions and grants of a resource are moved along with it;
+// secret ACLs are not, as they are cheap to recreate.
+//
+// Moved blocks whose old key is not present in the state are rejected before
+// planning by statemgmt.CheckMoved.
+func movedBlocks(root dyn.Value, out *schema.Resources) ([]schema.Moved, error) {
+	v, err := dyn.Get(root, "moved")
+	if dyn.IsNoSuchKeyError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var moved []config.Moved
+	err = convert.ToTyped(&moved, v)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []schema.Moved
+	for _, m := range moved {
+		fromPath, err := dyn.NewPathFromString(m.From)
+		if err != nil {
+			return nil, err
+		}
+		toPath, err := dyn.NewPathFromString(m.To)
+		if err != nil {
+			return nil, err
+		}
+		if len(fromPath) != 3 || len(toPath) != 3 {
+			continue
+		}
+
+		group := toPath[1].Key()
+		tfType, ok := GroupToTerraformName[group]
+		if !ok {
+			continue
+		}
+
+		fromName, toName := fromPath[2].Key(), toPath[2].Key()
+		blocks = append(blocks, schema.Moved{
+			From: tfType + "." + fromName,
+			To:   tfType + "." + toName,
+		})
+
+		if out == nil {
+			continue
+		}
+		for _, pg := range prefixToGroup {
+			if pg.group != group {
+				continue
+			}
+			if _, ok := out.Permissions[pg.prefix+toName]; ok {
+				blocks = append(blocks, schema.Moved{
+					From: "databricks_permissions." + pg.prefix + fromName,
+					To:   "databricks_permissions." + pg.prefix + toName,
+				})
+			}
+		}
+		for _, gp := range grantsPrefix {
+			if gp.group != group {
+				continue
+			}
+			if _, ok := out.Grants[gp.prefix+toName]; ok {
+				blocks = append(blocks, schema.Moved{
+					From: "databricks_grants." + gp.prefix + fromName,
+					To:   "databricks_grants." + gp.prefix + toName,
+				})
+			}
+		}
+	}
+
+	return blocks, nil
+}
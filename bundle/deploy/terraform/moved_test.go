@@ -0,0 +1,38 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/internal/tf/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundleToTerraformMoved(t *testing.T) {
+	config := config.Root{
+		Resources: config.Resources{
+			Jobs: map[string]*resources.Job{
+				"etl_daily": {
+					Permissions: []resources.JobPermission{
+						{Level: "CAN_VIEW", UserName: "jane@doe.com"},
+					},
+				},
+			},
+			Pipelines: map[string]*resources.Pipeline{
+				"ingest_v2": {},
+			},
+		},
+		Moved: []config.Moved{
+			{From: "resources.jobs.etl", To: "resources.jobs.etl_daily"},
+			{From: "resources.pipelines.ingest", To: "resources.pipelines.ingest_v2"},
+		},
+	}
+
+	out := produceTerraformConfiguration(t, config)
+	assert.Equal(t, []schema.Moved{
+		{From: "databricks_job.etl", To: "databricks_job.etl_daily"},
+		{From: "databricks_permissions.job_etl", To: "databricks_permissions.job_etl_daily"},
+		{From: "databricks_pipeline.ingest", To: "databricks_pipeline.ingest_v2"},
+	}, out.Moved)
+}
@@ -97,16 +97,14 @@ func populatePlan(ctx context.Context, plan *deployplan.Plan, changes []*tfjson.
 			continue
 		}
 
-		var key string
-		switch group {
-		case "permissions":
-			key = convertPermissionsResourceNameToKey(rc.Name)
-		case "grants":
-			key = convertGrantsResourceNameToKey(rc.Name)
-		case "secret_acls":
-			key = convertSecretAclNameToScopeKey(rc.Name)
-		default:
-			key = "resources." + group + "." + rc.Name
+		key := terraformNameToKey(group, rc.Name)
+
+		// A resource renamed with a moved block records its previous address.
+		var movedFrom string
+		if rc.PreviousAddress != "" && rc.PreviousAddress != rc.Address {
+			if _, name, ok := strings.Cut(rc.PreviousAddress, "."); ok {
+				movedFrom = terraformNameToKey(group, name)
+			}
 		}
 
 		if existing, ok := plan.Plan[key]; ok {
@@ -120,11 +118,26 @@ func populatePlan(ctx context.Context, plan *deployplan.Plan, changes []*tfjson.
 				existing.Action = deployplan.GetHigherAction(existing.Action, actionType)
 			}
 		} else {
-			plan.Plan[key] = &deployplan.PlanEntry{Action: actionType}
+			plan.Plan[key] = &deployplan.PlanEntry{Action: actionType, MovedFrom: movedFrom}
 		}
 	}
 }
 
+// terraformNameToKey converts the name of a terraform resource in the given group
+// to the key of the corresponding bundle resource.
+func terraformNameToKey(group, name string) string {
+	switch group {
+	case "permissions":
+		return convertPermissionsResourceNameToKey(name)
+	case "grants":
+		return convertGrantsResourceNameToKey(name)
+	case "secret_acls":
+		return convertSecretAclNameToScopeKey(name)
+	default:
+		return "resources." + group + "." + name
+	}
+}
+
 // ShowPlanFile reads a Terraform plan file located at planPath using the provided tfexec.Terraform handle
 // and converts it into a deployplan.Plan.
 func ShowPlanFile(ctx context.Context, tf *tfexec.Terraform, planPath string) (*deployplan.Plan, error) {
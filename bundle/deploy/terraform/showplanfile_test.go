@@ -162,3 +162,33 @@ func TestConvertSecretAclNameToScopeKey(t *testing.T) {
 	assert.Equal(t, "resources.secret_scopes.my_scope.permissions", convertSecretAclNameToScopeKey("secret_acl_my_scope_1"))
 	assert.Equal(t, "resources.secret_scopes.scope_123.permissions", convertSecretAclNameToScopeKey("secret_acl_scope_123_2"))
 }
+
+func TestPopulatePlanMoved(t *testing.T) {
+	changes := []*tfjson.ResourceChange{
+		{
+			Address:         "databricks_job.etl_daily",
+			PreviousAddress: "databricks_job.etl",
+			Type:            "databricks_job",
+			Name:            "etl_daily",
+			Change: &tfjson.Change{
+				Actions: tfjson.Actions{tfjson.ActionNoop},
+			},
+		},
+		{
+			Address:         "databricks_permissions.job_etl_daily",
+			PreviousAddress: "databricks_permissions.job_etl",
+			Type:            "databricks_permissions",
+			Name:            "job_etl_daily",
+			Change: &tfjson.Change{
+				Actions: tfjson.Actions{tfjson.ActionNoop},
+			},
+		},
+	}
+
+	plan := deployplan.NewPlanTerraform()
+	populatePlan(t.Context(), plan, changes)
+
+	assert.Equal(t, "resources.jobs.etl", plan.Plan["resources.jobs.etl_daily"].MovedFrom)
+	assert.Equal(t, "resources.jobs.etl.permissions", plan.Plan["resources.jobs.etl_daily.permissions"].MovedFrom)
+	assert.Equal(t, 1, plan.CountActions().Move)
+}
@@ -34,7 +34,7 @@ func (alertConverter) Convert(ctx context.Context, key string, vin dyn.Value, ou
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceAlertV2{}, nil)
 	if err != nil {
 		return err
 	}
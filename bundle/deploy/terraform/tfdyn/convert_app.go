@@ -53,7 +53,7 @@ func (appConverter) Convert(ctx context.Context, key string, vin dyn.Value, out
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceApp{}, nil)
 	if err != nil {
 		return err
 	}
@@ -35,7 +35,7 @@ func (clusterConverter) Convert(ctx context.Context, key string, vin dyn.Value,
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceCluster{}, nil)
 	if err != nil {
 		return err
 	}
@@ -70,7 +70,7 @@ func (dashboardConverter) Convert(ctx context.Context, key string, vin dyn.Value
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceDashboard{}, nil)
 	if err != nil {
 		return err
 	}
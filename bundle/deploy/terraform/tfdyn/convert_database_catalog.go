@@ -20,7 +20,7 @@ func (d databaseCatalogConverter) Convert(ctx context.Context, key string, vin d
 	}
 
 	var err error
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceDatabaseDatabaseCatalog{}, nil)
 	if err != nil {
 		return err
 	}
@@ -27,7 +27,7 @@ func (d databaseInstanceConverter) Convert(ctx context.Context, key string, vin
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceDatabaseInstance{}, nil)
 	if err != nil {
 		return err
 	}
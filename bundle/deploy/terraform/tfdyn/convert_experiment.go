@@ -28,7 +28,7 @@ func (experimentConverter) Convert(ctx context.Context, key string, vin dyn.Valu
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceMlflowExperiment{}, nil)
 	if err != nil {
 		return err
 	}
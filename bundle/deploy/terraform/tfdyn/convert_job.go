@@ -115,6 +115,21 @@ func patchApplyPolicyDefaultValues(_ dyn.Path, v dyn.Value) (dyn.Value, error) {
 	return v, nil
 }
 
+// jobRenamedKeys maps the job fields renamed by convertJobResource to their
+// Terraform name, for use in lifecycle.ignore_changes.
+var jobRenamedKeys = map[string]string{
+	"tasks":        "task",
+	"job_clusters": "job_cluster",
+	"parameters":   "parameter",
+	"environments": "environment",
+	"libraries":    "library",
+	"git_branch":   "branch",
+	"git_commit":   "commit",
+	"git_provider": "provider",
+	"git_tag":      "tag",
+	"git_url":      "url",
+}
+
 func convertJobResource(ctx context.Context, vin dyn.Value) (dyn.Value, error) {
 	// Normalize the input value to the underlying job schema.
 	// This removes superfluous keys and adapts the input to the expected schema.
@@ -243,7 +258,7 @@ func (jobConverter) Convert(ctx context.Context, key string, vin dyn.Value, out
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceJob{}, jobRenamedKeys)
 	if err != nil {
 		return err
 	}
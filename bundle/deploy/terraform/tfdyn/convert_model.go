@@ -28,7 +28,7 @@ func (modelConverter) Convert(ctx context.Context, key string, vin dyn.Value, ou
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceMlflowModel{}, nil)
 	if err != nil {
 		return err
 	}
@@ -28,7 +28,7 @@ func (modelServingEndpointConverter) Convert(ctx context.Context, key string, vi
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceModelServing{}, nil)
 	if err != nil {
 		return err
 	}
@@ -10,13 +10,17 @@ import (
 	"github.com/databricks/cli/libs/log"
 )
 
+// pipelineRenamedKeys maps pipeline fields to their Terraform name.
+// It is also used to translate lifecycle.ignore_changes.
+var pipelineRenamedKeys = map[string]string{
+	"libraries":     "library",
+	"clusters":      "cluster",
+	"notifications": "notification",
+}
+
 func convertPipelineResource(ctx context.Context, vin dyn.Value) (dyn.Value, error) {
 	// Modify top-level keys.
-	vout, err := renameKeys(vin, map[string]string{
-		"libraries":     "library",
-		"clusters":      "cluster",
-		"notifications": "notification",
-	})
+	vout, err := renameKeys(vin, pipelineRenamedKeys)
 	if err != nil {
 		return dyn.InvalidValue, err
 	}
@@ -45,7 +49,7 @@ func (pipelineConverter) Convert(ctx context.Context, key string, vin dyn.Value,
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourcePipeline{}, pipelineRenamedKeys)
 	if err != nil {
 		return err
 	}
@@ -48,7 +48,7 @@ func (c postgresBranchConverter) Convert(ctx context.Context, key string, vin dy
 		log.Debugf(ctx, "postgres branch normalization diagnostic: %s", diag.Summary)
 	}
 
-	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourcePostgresBranch{}, nil)
 	if err != nil {
 		return err
 	}
@@ -41,7 +41,7 @@ func (c postgresCatalogConverter) Convert(ctx context.Context, key string, vin d
 		log.Debugf(ctx, "postgres catalog normalization diagnostic: %s", diag.Summary)
 	}
 
-	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourcePostgresCatalog{}, nil)
 	if err != nil {
 		return err
 	}
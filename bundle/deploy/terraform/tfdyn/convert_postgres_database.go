@@ -48,7 +48,7 @@ func (c postgresDatabaseConverter) Convert(ctx context.Context, key string, vin
 		log.Debugf(ctx, "postgres database normalization diagnostic: %s", diag.Summary)
 	}
 
-	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourcePostgresDatabase{}, nil)
 	if err != nil {
 		return err
 	}
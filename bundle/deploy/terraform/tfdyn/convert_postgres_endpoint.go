@@ -48,7 +48,7 @@ func (c postgresEndpointConverter) Convert(ctx context.Context, key string, vin
 		log.Debugf(ctx, "postgres endpoint normalization diagnostic: %s", diag.Summary)
 	}
 
-	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourcePostgresEndpoint{}, nil)
 	if err != nil {
 		return err
 	}
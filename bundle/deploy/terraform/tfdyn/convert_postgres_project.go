@@ -49,7 +49,7 @@ func (c postgresProjectConverter) Convert(ctx context.Context, key string, vin d
 		log.Debugf(ctx, "postgres project normalization diagnostic: %s", diag.Summary)
 	}
 
-	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourcePostgresProject{}, nil)
 	if err != nil {
 		return err
 	}
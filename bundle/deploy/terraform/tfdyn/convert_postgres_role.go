@@ -48,7 +48,7 @@ func (c postgresRoleConverter) Convert(ctx context.Context, key string, vin dyn.
 		log.Debugf(ctx, "postgres role normalization diagnostic: %s", diag.Summary)
 	}
 
-	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourcePostgresRole{}, nil)
 	if err != nil {
 		return err
 	}
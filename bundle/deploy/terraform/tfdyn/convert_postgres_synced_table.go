@@ -41,7 +41,7 @@ func (c postgresSyncedTableConverter) Convert(ctx context.Context, key string, v
 		log.Debugf(ctx, "postgres synced table normalization diagnostic: %s", diag.Summary)
 	}
 
-	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourcePostgresSyncedTable{}, nil)
 	if err != nil {
 		return err
 	}
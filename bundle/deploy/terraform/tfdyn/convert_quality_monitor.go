@@ -26,7 +26,7 @@ func (qualityMonitorConverter) Convert(ctx context.Context, key string, vin dyn.
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceQualityMonitor{}, nil)
 	if err != nil {
 		return err
 	}
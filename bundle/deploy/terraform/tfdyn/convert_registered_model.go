@@ -28,7 +28,7 @@ func (registeredModelConverter) Convert(ctx context.Context, key string, vin dyn
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceRegisteredModel{}, nil)
 	if err != nil {
 		return err
 	}
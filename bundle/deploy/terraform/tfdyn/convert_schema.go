@@ -36,7 +36,7 @@ func (schemaConverter) Convert(ctx context.Context, key string, vin dyn.Value, o
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceSchema{}, nil)
 	if err != nil {
 		return err
 	}
@@ -67,7 +67,7 @@ func (s secretScopeConverter) Convert(ctx context.Context, key string, vin dyn.V
 		log.Debugf(ctx, "secret scope normalization diagnostic: %s", diag.Summary)
 	}
 
-	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err := convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceSecretScope{}, nil)
 	if err != nil {
 		return err
 	}
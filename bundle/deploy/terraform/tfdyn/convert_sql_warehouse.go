@@ -28,7 +28,7 @@ func (sqlWarehouseConverter) Convert(ctx context.Context, key string, vin dyn.Va
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceSqlEndpoint{}, nil)
 	if err != nil {
 		return err
 	}
@@ -20,7 +20,7 @@ func (s syncedDatabaseTableConverter) Convert(ctx context.Context, key string, v
 	}
 
 	var err error
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceDatabaseSyncedDatabaseTable{}, nil)
 	if err != nil {
 		return err
 	}
@@ -34,7 +34,7 @@ func (volumeConverter) Convert(ctx context.Context, key string, vin dyn.Value, o
 		return err
 	}
 
-	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"))
+	vout, err = convertLifecycle(ctx, vout, vin.Get("lifecycle"), schema.ResourceVolume{}, nil)
 	if err != nil {
 		return err
 	}
@@ -2,11 +2,22 @@ package tfdyn
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/structs/structaccess"
+	"github.com/databricks/cli/libs/structs/structpath"
 )
 
-func convertLifecycle(ctx context.Context, vout, vLifecycle dyn.Value) (dyn.Value, error) {
+// convertLifecycle sets the lifecycle block of a Terraform resource.
+//
+// The resource argument is the Terraform schema type of the resource (e.g. schema.ResourceJob{}).
+// It is used to translate the field paths in lifecycle.ignore_changes to Terraform attribute
+// references. Fields renamed by the converter are passed in renames (bundle name to Terraform name).
+func convertLifecycle(ctx context.Context, vout, vLifecycle dyn.Value, resource any, renames map[string]string) (dyn.Value, error) {
 	if !vLifecycle.IsValid() {
 		return vout, nil
 	}
@@ -23,6 +34,21 @@ func convertLifecycle(ctx context.Context, vout, vLifecycle dyn.Value) (dyn.Valu
 		return vout, nil
 	}
 
+	vLifecycle, err = dyn.Map(vLifecycle, "ignore_changes", dyn.Foreach(func(p dyn.Path, v dyn.Value) (dyn.Value, error) {
+		field, ok := v.AsString()
+		if !ok {
+			return dyn.InvalidValue, fmt.Errorf("lifecycle.%s: expected a string, found %s", p, v.Kind())
+		}
+		ref, err := toTerraformReference(reflect.TypeOf(resource), field, renames)
+		if err != nil {
+			return dyn.InvalidValue, fmt.Errorf("invalid lifecycle.ignore_changes entry %q: %w", field, err)
+		}
+		return dyn.NewValue(ref, v.Locations()), nil
+	}))
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
 	vout, err = dyn.Set(vout, "lifecycle", vLifecycle)
 	if err != nil {
 		return dyn.InvalidValue, err
@@ -30,3 +56,60 @@ func convertLifecycle(ctx context.Context, vout, vLifecycle dyn.Value) (dyn.Valu
 
 	return vout, nil
 }
+
+// toTerraformReference translates a bundle field path to a Terraform attribute reference.
+// Terraform models nested objects as blocks, which are lists with a single element,
+// so "schedule.pause_status" becomes "schedule[0].pause_status".
+func toTerraformReference(typ reflect.Type, field string, renames map[string]string) (string, error) {
+	path, err := structpath.ParsePath(field)
+	if err != nil {
+		return "", err
+	}
+
+	var ref strings.Builder
+	for _, node := range path.AsSlice() {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+
+		if index, ok := node.Index(); ok {
+			if typ.Kind() != reflect.Slice {
+				return "", fmt.Errorf("cannot index %s", typ)
+			}
+			ref.WriteString("[" + strconv.Itoa(index) + "]")
+			typ = typ.Elem()
+			continue
+		}
+
+		key, ok := node.StringKey()
+		if !ok {
+			return "", fmt.Errorf("%s is not supported by the terraform deployment engine", node)
+		}
+
+		switch typ.Kind() {
+		case reflect.Map:
+			ref.WriteString("[" + strconv.Quote(key) + "]")
+			typ = typ.Elem()
+		case reflect.Struct:
+			if name, ok := renames[key]; ok {
+				key = name
+			}
+			sf, _, ok := structaccess.FindStructFieldByKeyType(typ, key)
+			if !ok {
+				return "", fmt.Errorf("field %q not found in the terraform schema", key)
+			}
+			if ref.Len() > 0 {
+				ref.WriteString(".")
+			}
+			ref.WriteString(key)
+			typ = sf.Type
+			if typ.Kind() == reflect.Pointer && typ.Elem().Kind() == reflect.Struct {
+				ref.WriteString("[0]")
+			}
+		default:
+			return "", fmt.Errorf("cannot access %q in %s", key, typ)
+		}
+	}
+
+	return ref.String(), nil
+}
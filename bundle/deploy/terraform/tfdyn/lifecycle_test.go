@@ -0,0 +1,75 @@
+package tfdyn
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/internal/tf/schema"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/convert"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToTerraformReference(t *testing.T) {
+	for _, tc := range []struct {
+		resource any
+		field    string
+		expected string
+	}{
+		{schema.ResourceJob{}, "name", "name"},
+		{schema.ResourceJob{}, "schedule.pause_status", "schedule[0].pause_status"},
+		{schema.ResourceJob{}, "tasks[1].new_cluster.num_workers", "task[1].new_cluster[0].num_workers"},
+		{schema.ResourceJob{}, "tags.team", `tags["team"]`},
+		{schema.ResourceJob{}, "git_source.git_branch", "git_source[0].branch"},
+		{schema.ResourceSqlEndpoint{}, "tags.custom_tags", "tags[0].custom_tags"},
+		{schema.ResourceModelServing{}, "config.served_entities[0].workload_size", "config[0].served_entities[0].workload_size"},
+	} {
+		ref, err := toTerraformReference(reflect.TypeOf(tc.resource), tc.field, jobRenamedKeys)
+		require.NoError(t, err, tc.field)
+		assert.Equal(t, tc.expected, ref, tc.field)
+	}
+}
+
+func TestToTerraformReferenceErrors(t *testing.T) {
+	for _, tc := range []struct {
+		field string
+		err   string
+	}{
+		{"unknown", `field "unknown" not found in the terraform schema`},
+		{"name[0]", "cannot index string"},
+		{"tasks[task_key='main']", "tasks[task_key='main'] is not supported by the terraform deployment engine"},
+	} {
+		_, err := toTerraformReference(reflect.TypeOf(schema.ResourceJob{}), tc.field, jobRenamedKeys)
+		assert.EqualError(t, err, tc.err, tc.field)
+	}
+}
+
+func TestConvertLifecycle(t *testing.T) {
+	src := resources.Job{
+		BaseResource: resources.BaseResource{
+			Lifecycle: resources.Lifecycle{
+				IgnoreChanges:       []string{"schedule.pause_status"},
+				CreateBeforeDestroy: true,
+			},
+		},
+		JobSettings: jobs.JobSettings{
+			Name: "my job",
+		},
+	}
+
+	vin, err := convert.FromTyped(src, dyn.NilValue)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	out := schema.NewResources()
+	err = jobConverter{}.Convert(ctx, "my_job", vin, out)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"ignore_changes":        []any{"schedule[0].pause_status"},
+		"create_before_destroy": true,
+	}, out.Job["my_job"].(map[string]any)["lifecycle"])
+}
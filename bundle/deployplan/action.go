@@ -12,6 +12,8 @@ type Action struct {
 	// Gone mirrors PlanEntry.Gone: the delete is a state-only cleanup because the
 	// resource no longer exists remotely.
 	Gone bool
+	// MovedFrom mirrors PlanEntry.MovedFrom: the previous key of a renamed resource.
+	MovedFrom string
 }
 
 func (a Action) String() string {
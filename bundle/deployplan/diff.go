@@ -0,0 +1,168 @@
+package deployplan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/libs/structs/structdiff"
+)
+
+// PlanDiffVersion is the version of the [PlanDiff] format. It is incremented on
+// incompatible changes so that consumers (e.g. PR bots) can detect them.
+const PlanDiffVersion = 1
+
+// Possible values for FieldDiff.Source
+const (
+	// SourceConfig: the field changed in the bundle configuration since the last deployment.
+	SourceConfig = "config"
+	// SourceRemote: the field was changed outside the bundle (remote drift); deploying reverts it.
+	SourceRemote = "remote"
+)
+
+// PlanDiff is a stable, field-level representation of a plan intended for review.
+// Unlike [Plan], which is an internal format used to apply changes, its structure
+// only changes together with [PlanDiffVersion].
+//
+// Values are taken from a plan whose sensitive fields and variables are already
+// redacted, so the diff can be published as is.
+type PlanDiff struct {
+	DiffVersion int            `json:"diff_version"`
+	CLIVersion  string         `json:"cli_version,omitempty"`
+	Resources   []ResourceDiff `json:"resources"`
+
+	// Estimate is set by "bundle plan --estimate".
+	Estimate *ComputeEstimate `json:"estimate,omitempty"`
+}
+
+type ResourceDiff struct {
+	ResourceKey string      `json:"resource_key"`
+	Action      ActionType  `json:"action"`
+	MovedFrom   string      `json:"moved_from,omitempty"`
+	Fields      []FieldDiff `json:"fields,omitempty"`
+}
+
+type FieldDiff struct {
+	Path   string     `json:"path"`
+	Action ActionType `json:"action"`
+	Source string     `json:"source"`
+	Reason string     `json:"reason,omitempty"`
+
+	// Old is the value at the last deployment, Remote the current value in the
+	// workspace and New the value after applying the plan.
+	Old    any `json:"old,omitempty"`
+	New    any `json:"new,omitempty"`
+	Remote any `json:"remote,omitempty"`
+
+	// ForcesRecreate is set when changing this field requires recreating the resource.
+	ForcesRecreate bool `json:"forces_recreate,omitempty"`
+}
+
+// Diff returns the field-level diff of the plan. Unchanged resources and
+// suppressed field changes (action skip) are not included.
+func (p *Plan) Diff() *PlanDiff {
+	d := &PlanDiff{
+		DiffVersion: PlanDiffVersion,
+		CLIVersion:  p.CLIVersion,
+		Resources:   []ResourceDiff{},
+	}
+
+	for _, action := range p.GetActions() {
+		if action.ActionType == Skip && action.MovedFrom == "" {
+			continue
+		}
+
+		r := ResourceDiff{
+			ResourceKey: action.ResourceKey,
+			Action:      action.ActionType,
+			MovedFrom:   action.MovedFrom,
+		}
+
+		entry := p.Plan[action.ResourceKey]
+		for _, path := range slices.Sorted(maps.Keys(entry.Changes)) {
+			ch := entry.Changes[path]
+			if ch.Action == Skip {
+				continue
+			}
+			source := SourceConfig
+			if structdiff.IsEqual(ch.Old, ch.New) {
+				source = SourceRemote
+			}
+			r.Fields = append(r.Fields, FieldDiff{
+				Path:           path,
+				Action:         ch.Action,
+				Source:         source,
+				Reason:         ch.Reason,
+				Old:            ch.Old,
+				New:            ch.New,
+				Remote:         ch.Remote,
+				ForcesRecreate: ch.Action == Recreate,
+			})
+		}
+
+		d.Resources = append(d.Resources, r)
+	}
+
+	return d
+}
+
+var diffSymbols = map[ActionType]string{
+	Skip:         " ",
+	Resize:       "~",
+	Update:       "~",
+	UpdateWithID: "~",
+	Create:       "+",
+	Recreate:     "-/+",
+	Delete:       "-",
+}
+
+// WriteText renders the diff in a human readable format similar to "terraform plan".
+func (d *PlanDiff) WriteText(w io.Writer) error {
+	for _, r := range d.Resources {
+		key := strings.TrimPrefix(r.ResourceKey, "resources.")
+		header := fmt.Sprintf("%s %s (%s)", diffSymbols[r.Action], key, r.Action)
+		if r.MovedFrom != "" {
+			header += ", moved from " + strings.TrimPrefix(r.MovedFrom, "resources.")
+		}
+		if _, err := fmt.Fprintln(w, header); err != nil {
+			return err
+		}
+
+		for _, f := range r.Fields {
+			before := f.Old
+			if f.Source == SourceRemote {
+				before = f.Remote
+			}
+			line := fmt.Sprintf("    %s: %s -> %s", f.Path, formatDiffValue(before), formatDiffValue(f.New))
+
+			var notes []string
+			if f.Source == SourceRemote {
+				notes = append(notes, "remote drift")
+			}
+			if f.ForcesRecreate {
+				notes = append(notes, "forces recreate")
+			}
+			if len(notes) > 0 {
+				line += " (" + strings.Join(notes, ", ") + ")"
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatDiffValue(v any) string {
+	if v == nil {
+		return "(unset)"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
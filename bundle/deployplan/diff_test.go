@@ -0,0 +1,68 @@
+package deployplan_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanDiff(t *testing.T) {
+	plan := deployplan.NewPlanDirect()
+	plan.CLIVersion = "0.0.0-dev"
+	plan.Plan["resources.jobs.etl"] = &deployplan.PlanEntry{
+		Action: deployplan.Update,
+		Changes: deployplan.Changes{
+			"name":            {Action: deployplan.Update, Old: "etl", New: "etl v2", Remote: "etl"},
+			"timeout_seconds": {Action: deployplan.Update, Old: 60, New: 60, Remote: 120},
+			"description":     {Action: deployplan.Skip, Reason: deployplan.ReasonBackendDefault, Remote: "x"},
+		},
+	}
+	plan.Plan["resources.pipelines.dlt"] = &deployplan.PlanEntry{
+		Action: deployplan.Recreate,
+		Changes: deployplan.Changes{
+			"storage": {Action: deployplan.Recreate, Old: "/a", New: "/b", Remote: "/a"},
+		},
+	}
+	plan.Plan["resources.schemas.new"] = &deployplan.PlanEntry{Action: deployplan.Create}
+	plan.Plan["resources.schemas.same"] = &deployplan.PlanEntry{Action: deployplan.Skip}
+	plan.Plan["resources.schemas.renamed"] = &deployplan.PlanEntry{Action: deployplan.Skip, MovedFrom: "resources.schemas.old"}
+
+	diff := plan.Diff()
+	assert.Equal(t, &deployplan.PlanDiff{
+		DiffVersion: deployplan.PlanDiffVersion,
+		CLIVersion:  "0.0.0-dev",
+		Resources: []deployplan.ResourceDiff{
+			{
+				ResourceKey: "resources.jobs.etl",
+				Action:      deployplan.Update,
+				Fields: []deployplan.FieldDiff{
+					{Path: "name", Action: deployplan.Update, Source: deployplan.SourceConfig, Old: "etl", New: "etl v2", Remote: "etl"},
+					{Path: "timeout_seconds", Action: deployplan.Update, Source: deployplan.SourceRemote, Old: 60, New: 60, Remote: 120},
+				},
+			},
+			{
+				ResourceKey: "resources.pipelines.dlt",
+				Action:      deployplan.Recreate,
+				Fields: []deployplan.FieldDiff{
+					{Path: "storage", Action: deployplan.Recreate, Source: deployplan.SourceConfig, Old: "/a", New: "/b", Remote: "/a", ForcesRecreate: true},
+				},
+			},
+			{ResourceKey: "resources.schemas.new", Action: deployplan.Create},
+			{ResourceKey: "resources.schemas.renamed", Action: deployplan.Skip, MovedFrom: "resources.schemas.old"},
+		},
+	}, diff)
+
+	var buf bytes.Buffer
+	require.NoError(t, diff.WriteText(&buf))
+	assert.Equal(t, `~ jobs.etl (update)
+    name: "etl" -> "etl v2"
+    timeout_seconds: 120 -> 60 (remote drift)
+-/+ pipelines.dlt (recreate)
+    storage: "/a" -> "/b" (forces recreate)
++ schemas.new (create)
+  schemas.renamed (skip), moved from schemas.old
+`, buf.String())
+}
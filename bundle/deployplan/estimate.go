@@ -0,0 +1,276 @@
+package deployplan
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ComputeShape describes the compute used by a resource as far as it can be derived
+// from its configuration. It is not a price estimate: it only captures the dimensions
+// that drive cost (node types, worker counts, warehouse size and serverless vs classic).
+type ComputeShape struct {
+	// Name identifies the compute within its resource: the job cluster key, the task key
+	// for task clusters or the pipeline cluster label. It is empty for clusters and warehouses.
+	Name string `json:"name,omitempty"`
+
+	Serverless     bool   `json:"serverless,omitempty"`
+	NodeType       string `json:"node_type,omitempty"`
+	DriverNodeType string `json:"driver_node_type,omitempty"`
+	NumWorkers     int    `json:"num_workers,omitempty"`
+	Autoscale      bool   `json:"autoscale,omitempty"`
+	MinWorkers     int    `json:"min_workers,omitempty"`
+	MaxWorkers     int    `json:"max_workers,omitempty"`
+
+	// Warehouse fields, only set for SQL warehouses.
+	WarehouseSize string `json:"warehouse_size,omitempty"`
+	MinClusters   int    `json:"min_clusters,omitempty"`
+	MaxClusters   int    `json:"max_clusters,omitempty"`
+}
+
+// PeakWorkers returns the number of workers the compute can scale up to.
+func (s ComputeShape) PeakWorkers() int {
+	if s.Autoscale {
+		return s.MaxWorkers
+	}
+	return s.NumWorkers
+}
+
+func (s ComputeShape) String() string {
+	var parts []string
+	if s.Serverless {
+		parts = append(parts, "serverless")
+	}
+	if s.WarehouseSize != "" {
+		parts = append(parts, s.WarehouseSize)
+	}
+	if s.MinClusters != 0 || s.MaxClusters != 0 {
+		parts = append(parts, fmt.Sprintf("%d-%d clusters", s.MinClusters, s.MaxClusters))
+	}
+	if s.NodeType != "" {
+		parts = append(parts, s.NodeType)
+	}
+	if s.DriverNodeType != "" && s.DriverNodeType != s.NodeType {
+		parts = append(parts, "driver "+s.DriverNodeType)
+	}
+	if s.Autoscale {
+		parts = append(parts, fmt.Sprintf("%d-%d workers (autoscale)", s.MinWorkers, s.MaxWorkers))
+	} else if s.NodeType != "" || s.NumWorkers != 0 {
+		parts = append(parts, fmt.Sprintf("%d workers", s.NumWorkers))
+	}
+	if len(parts) == 0 {
+		return "classic"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ResourceEstimate is the compute footprint of a resource before and after applying the plan.
+// Before is derived from the remote state and After from the new state of the plan entry.
+type ResourceEstimate struct {
+	ResourceKey string         `json:"resource_key"`
+	Action      ActionType     `json:"action"`
+	Before      []ComputeShape `json:"before,omitempty"`
+	After       []ComputeShape `json:"after,omitempty"`
+}
+
+// ComputeEstimate summarizes the compute footprint changes of a plan.
+type ComputeEstimate struct {
+	Resources []ResourceEstimate `json:"resources,omitempty"`
+
+	// PeakWorkersBefore and PeakWorkersAfter are the number of workers all classic
+	// compute of the planned resources can scale up to, before and after the plan.
+	PeakWorkersBefore int `json:"peak_workers_before"`
+	PeakWorkersAfter  int `json:"peak_workers_after"`
+}
+
+// Estimate computes the compute footprint changes of the plan. Resources without
+// compute or whose compute does not change are not included.
+//
+// The estimate needs the new and remote state of the plan entries, so it is empty
+// for plans produced by the terraform deployment engine.
+func (p *Plan) Estimate() (*ComputeEstimate, error) {
+	est := &ComputeEstimate{}
+	for _, action := range p.GetActions() {
+		if action.ActionType == Skip || action.IsChildResource() {
+			continue
+		}
+
+		shapesFunc, ok := computeShapes[resourceType(action.ResourceKey)]
+		if !ok {
+			continue
+		}
+
+		entry := p.Plan[action.ResourceKey]
+		r := ResourceEstimate{ResourceKey: action.ResourceKey, Action: action.ActionType}
+
+		if action.ActionType != Create && entry.RemoteState != nil {
+			remote, err := toStateMap(entry.RemoteState)
+			if err != nil {
+				return nil, fmt.Errorf("%s: reading remote state: %w", action.ResourceKey, err)
+			}
+			r.Before = shapesFunc(remote)
+		}
+
+		if action.ActionType != Delete && entry.NewState != nil && len(entry.NewState.Value) > 0 {
+			var state map[string]any
+			err := json.Unmarshal(entry.NewState.Value, &state)
+			if err != nil {
+				return nil, fmt.Errorf("%s: reading new state: %w", action.ResourceKey, err)
+			}
+			r.After = shapesFunc(state)
+		}
+
+		if slices.Equal(r.Before, r.After) {
+			continue
+		}
+
+		for _, s := range r.Before {
+			est.PeakWorkersBefore += s.PeakWorkers()
+		}
+		for _, s := range r.After {
+			est.PeakWorkersAfter += s.PeakWorkers()
+		}
+		est.Resources = append(est.Resources, r)
+	}
+	return est, nil
+}
+
+// resourceType returns the resource type of a key like "resources.jobs.foo".
+func resourceType(key string) string {
+	parts := strings.SplitN(key, ".", 3)
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+func toStateMap(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	err = json.Unmarshal(data, &m)
+	return m, err
+}
+
+var computeShapes = map[string]func(state map[string]any) []ComputeShape{
+	"jobs":           jobShapes,
+	"pipelines":      pipelineShapes,
+	"clusters":       func(state map[string]any) []ComputeShape { return []ComputeShape{clusterShape("", state)} },
+	"sql_warehouses": func(state map[string]any) []ComputeShape { return []ComputeShape{warehouseShape(state)} },
+}
+
+// Task types that do not run on job compute.
+var computeFreeTasks = []string{
+	"condition_task",
+	"dashboard_task",
+	"for_each_task",
+	"power_bi_task",
+	"run_job_task",
+	"sql_task",
+}
+
+func jobShapes(state map[string]any) []ComputeShape {
+	var shapes []ComputeShape
+	for _, jc := range stateSlice(state, "job_clusters") {
+		shapes = append(shapes, clusterShape(stateString(jc, "job_cluster_key"), stateMap(jc, "new_cluster")))
+	}
+
+	serverless := false
+	for _, task := range stateSlice(state, "tasks") {
+		if cluster := stateMap(task, "new_cluster"); cluster != nil {
+			shapes = append(shapes, clusterShape(stateString(task, "task_key"), cluster))
+			continue
+		}
+		if stateString(task, "job_cluster_key") != "" || stateString(task, "existing_cluster_id") != "" {
+			continue
+		}
+		if !slices.ContainsFunc(computeFreeTasks, func(key string) bool { return task[key] != nil }) {
+			serverless = true
+		}
+	}
+	if serverless {
+		shapes = append(shapes, ComputeShape{Name: "serverless", Serverless: true})
+	}
+	return shapes
+}
+
+func pipelineShapes(state map[string]any) []ComputeShape {
+	if stateBool(state, "serverless") {
+		return []ComputeShape{{Serverless: true}}
+	}
+
+	clusters := stateSlice(state, "clusters")
+	if len(clusters) == 0 {
+		// Classic pipelines without cluster settings use a default cluster.
+		return []ComputeShape{{Name: "default"}}
+	}
+
+	var shapes []ComputeShape
+	for _, cluster := range clusters {
+		label := stateString(cluster, "label")
+		if label == "" {
+			label = "default"
+		}
+		shapes = append(shapes, clusterShape(label, cluster))
+	}
+	return shapes
+}
+
+func clusterShape(name string, cluster map[string]any) ComputeShape {
+	s := ComputeShape{
+		Name:           name,
+		NodeType:       stateString(cluster, "node_type_id"),
+		DriverNodeType: stateString(cluster, "driver_node_type_id"),
+		NumWorkers:     stateInt(cluster, "num_workers"),
+	}
+	if autoscale := stateMap(cluster, "autoscale"); autoscale != nil {
+		s.Autoscale = true
+		s.NumWorkers = 0
+		s.MinWorkers = stateInt(autoscale, "min_workers")
+		s.MaxWorkers = stateInt(autoscale, "max_workers")
+	}
+	return s
+}
+
+func warehouseShape(state map[string]any) ComputeShape {
+	return ComputeShape{
+		Serverless:    stateBool(state, "enable_serverless_compute"),
+		WarehouseSize: stateString(state, "cluster_size"),
+		MinClusters:   stateInt(state, "min_num_clusters"),
+		MaxClusters:   stateInt(state, "max_num_clusters"),
+	}
+}
+
+func stateMap(m map[string]any, key string) map[string]any {
+	v, _ := m[key].(map[string]any)
+	return v
+}
+
+func stateSlice(m map[string]any, key string) []map[string]any {
+	items, _ := m[key].([]any)
+	var out []map[string]any
+	for _, item := range items {
+		if v, ok := item.(map[string]any); ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func stateString(m map[string]any, key string) string {
+	v, _ := m[key].(string)
+	return v
+}
+
+func stateInt(m map[string]any, key string) int {
+	v, _ := m[key].(float64)
+	return int(v)
+}
+
+func stateBool(m map[string]any, key string) bool {
+	v, _ := m[key].(bool)
+	return v
+}
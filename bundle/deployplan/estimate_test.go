@@ -0,0 +1,105 @@
+package deployplan_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/databricks/cli/libs/structs/structvar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newState(t *testing.T, v any) *structvar.StructVarJSON {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return &structvar.StructVarJSON{Value: data}
+}
+
+func TestPlanEstimate(t *testing.T) {
+	cluster := func(nodeType string, minWorkers, maxWorkers int) map[string]any {
+		return map[string]any{
+			"node_type_id": nodeType,
+			"autoscale":    map[string]any{"min_workers": minWorkers, "max_workers": maxWorkers},
+		}
+	}
+
+	plan := deployplan.NewPlanDirect()
+	plan.Plan["resources.jobs.etl"] = &deployplan.PlanEntry{
+		Action: deployplan.Update,
+		RemoteState: map[string]any{
+			"job_clusters": []any{map[string]any{"job_cluster_key": "main", "new_cluster": cluster("i3.xlarge", 2, 8)}},
+		},
+		NewState: newState(t, map[string]any{
+			"job_clusters": []any{map[string]any{"job_cluster_key": "main", "new_cluster": cluster("i3.2xlarge", 2, 16)}},
+			"tasks": []any{
+				map[string]any{"task_key": "a", "job_cluster_key": "main"},
+				map[string]any{"task_key": "b", "notebook_task": map[string]any{"notebook_path": "/nb"}},
+				map[string]any{"task_key": "c", "condition_task": map[string]any{"op": "EQUAL_TO"}},
+			},
+		}),
+	}
+	plan.Plan["resources.jobs.renamed"] = &deployplan.PlanEntry{
+		Action:      deployplan.Update,
+		RemoteState: map[string]any{"name": "old", "tasks": []any{map[string]any{"task_key": "a", "existing_cluster_id": "123"}}},
+		NewState:    newState(t, map[string]any{"name": "new", "tasks": []any{map[string]any{"task_key": "a", "existing_cluster_id": "123"}}}),
+	}
+	plan.Plan["resources.sql_warehouses.wh"] = &deployplan.PlanEntry{
+		Action: deployplan.Create,
+		NewState: newState(t, map[string]any{
+			"cluster_size":              "Small",
+			"min_num_clusters":          1,
+			"max_num_clusters":          2,
+			"enable_serverless_compute": true,
+		}),
+	}
+	plan.Plan["resources.clusters.interactive"] = &deployplan.PlanEntry{
+		Action:      deployplan.Delete,
+		RemoteState: map[string]any{"node_type_id": "i3.xlarge", "num_workers": 4},
+	}
+	plan.Plan["resources.pipelines.dlt"] = &deployplan.PlanEntry{Action: deployplan.Skip}
+	plan.Plan["resources.schemas.s"] = &deployplan.PlanEntry{Action: deployplan.Create, NewState: newState(t, map[string]any{"name": "s"})}
+
+	est, err := plan.Estimate()
+	require.NoError(t, err)
+	assert.Equal(t, &deployplan.ComputeEstimate{
+		Resources: []deployplan.ResourceEstimate{
+			{
+				ResourceKey: "resources.clusters.interactive",
+				Action:      deployplan.Delete,
+				Before:      []deployplan.ComputeShape{{NodeType: "i3.xlarge", NumWorkers: 4}},
+			},
+			{
+				ResourceKey: "resources.jobs.etl",
+				Action:      deployplan.Update,
+				Before:      []deployplan.ComputeShape{{Name: "main", NodeType: "i3.xlarge", Autoscale: true, MinWorkers: 2, MaxWorkers: 8}},
+				After: []deployplan.ComputeShape{
+					{Name: "main", NodeType: "i3.2xlarge", Autoscale: true, MinWorkers: 2, MaxWorkers: 16},
+					{Name: "serverless", Serverless: true},
+				},
+			},
+			{
+				ResourceKey: "resources.sql_warehouses.wh",
+				Action:      deployplan.Create,
+				After:       []deployplan.ComputeShape{{Serverless: true, WarehouseSize: "Small", MinClusters: 1, MaxClusters: 2}},
+			},
+		},
+		PeakWorkersBefore: 12,
+		PeakWorkersAfter:  16,
+	}, est)
+}
+
+func TestComputeShapeString(t *testing.T) {
+	for _, tc := range []struct {
+		shape    deployplan.ComputeShape
+		expected string
+	}{
+		{deployplan.ComputeShape{NodeType: "i3.xlarge", NumWorkers: 2}, "i3.xlarge, 2 workers"},
+		{deployplan.ComputeShape{NodeType: "i3.xlarge", DriverNodeType: "i3.2xlarge", Autoscale: true, MinWorkers: 1, MaxWorkers: 4}, "i3.xlarge, driver i3.2xlarge, 1-4 workers (autoscale)"},
+		{deployplan.ComputeShape{Serverless: true, WarehouseSize: "Small", MinClusters: 1, MaxClusters: 2}, "serverless, Small, 1-2 clusters"},
+		{deployplan.ComputeShape{Serverless: true}, "serverless"},
+		{deployplan.ComputeShape{Name: "default"}, "classic"},
+	} {
+		assert.Equal(t, tc.expected, tc.shape.String())
+	}
+}
@@ -38,13 +38,20 @@ type ActionCounts struct {
 	Change    int
 	Delete    int
 	Unchanged int
+
+	// Move is the number of resources renamed with a moved block. A moved
+	// resource is also counted by the action planned for it.
+	Move int
 }
 
 // CountActions tallies the plan's actions by category. Order is irrelevant to a
 // tally, so it iterates the plan map directly rather than the sorted GetActions.
 func (p *Plan) CountActions() ActionCounts {
 	var c ActionCounts
-	for _, entry := range p.Plan {
+	for key, entry := range p.Plan {
+		if entry.MovedFrom != "" && !(Action{ResourceKey: key}).IsChildResource() {
+			c.Move++
+		}
 		switch entry.Action {
 		case Create:
 			c.Create++
@@ -116,10 +123,19 @@ type PlanEntry struct {
 	// Gone is set on Delete entries when planning confirmed the resource no longer
 	// exists remotely. Applying such an entry only removes it from the state, without
 	// calling the delete API, and approval prompts do not list it as a deletion.
-	Gone        bool                     `json:"gone,omitempty"`
-	NewState    *structvar.StructVarJSON `json:"new_state,omitempty"`
-	RemoteState any                      `json:"remote_state,omitempty"`
-	Changes     Changes                  `json:"changes,omitempty"`
+	Gone bool `json:"gone,omitempty"`
+	// MovedFrom is the previous key of a resource renamed with a moved block.
+	// Applying the entry first moves the state of the resource to its new key.
+	MovedFrom string `json:"moved_from,omitempty"`
+	// IgnoreChanges lists the field patterns from lifecycle.ignore_changes.
+	// Changes to these fields are classified as Skip.
+	IgnoreChanges []string `json:"ignore_changes,omitempty"`
+	// CreateBeforeDestroy is set from lifecycle.create_before_destroy. Applying a
+	// Recreate entry then creates the replacement before deleting the old resource.
+	CreateBeforeDestroy bool                     `json:"create_before_destroy,omitempty"`
+	NewState            *structvar.StructVarJSON `json:"new_state,omitempty"`
+	RemoteState         any                      `json:"remote_state,omitempty"`
+	Changes             Changes                  `json:"changes,omitempty"`
 }
 
 type DependsOnEntry struct {
@@ -147,6 +163,8 @@ const (
 	// ReasonMissingInRemote: field is not present in RemoteType (write-only / input-only).
 	// Remote always appears nil, so treat the absence as a no-op when there is no local change.
 	ReasonMissingInRemote = "missing_in_remote"
+	// ReasonIgnoreChanges: field matches an entry in the resource's lifecycle.ignore_changes.
+	ReasonIgnoreChanges = "ignore_changes"
 
 	// Special reason that results in removing this change from the plan
 	ReasonDrop = "!drop"
@@ -201,6 +219,7 @@ func (p *Plan) GetActions() []Action {
 			ResourceKey: key,
 			ActionType:  entry.Action,
 			Gone:        entry.Gone,
+			MovedFrom:   entry.MovedFrom,
 		})
 	}
 
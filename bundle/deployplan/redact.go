@@ -0,0 +1,166 @@
+package deployplan
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/databricks/cli/bundle/config/variable"
+)
+
+// replaceLeaves replaces every string in v that is a key of replacements with
+// the corresponding value. Map keys and other scalars are left as is.
+// It reports whether anything was replaced.
+func replaceLeaves(v any, replacements map[string]string) (any, bool) {
+	switch v := v.(type) {
+	case string:
+		if s, ok := replacements[v]; ok {
+			return s, true
+		}
+	case map[string]any:
+		changed := false
+		for k, elem := range v {
+			if out, ok := replaceLeaves(elem, replacements); ok {
+				v[k] = out
+				changed = true
+			}
+		}
+		return v, changed
+	case []any:
+		changed := false
+		for i, elem := range v {
+			if out, ok := replaceLeaves(elem, replacements); ok {
+				v[i] = out
+				changed = true
+			}
+		}
+		return v, changed
+	}
+	return v, false
+}
+
+// replaceInJSON applies [replaceLeaves] to the decoded JSON value in data.
+// It returns data as is if nothing was replaced.
+func replaceInJSON(data []byte, replacements map[string]string) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	// Decode numbers as json.Number so that they are encoded unchanged.
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	err := dec.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+
+	v, changed := replaceLeaves(v, replacements)
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(v)
+}
+
+// replaceInValue applies [replaceLeaves] to the JSON representation of v.
+// If anything was replaced, the result is decoded into a new value of the
+// same type as v.
+func replaceInValue(v any, replacements map[string]string) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	replaced, err := replaceInJSON(data, replacements)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(replaced, data) {
+		return v, nil
+	}
+
+	out := reflect.New(reflect.TypeOf(v))
+	err = json.Unmarshal(replaced, out.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return out.Elem().Interface(), nil
+}
+
+// RedactValues replaces the values of sensitive variables (by variable name)
+// in the plan with a reference to their variable, so that the plan can be
+// printed and stored without them. [Plan.RestoreValues] reverses this before
+// a stored plan is applied.
+//
+// Only strings that are equal to the value of a sensitive variable are
+// replaced, so that keys, numbers and strings that merely contain a value
+// are left unchanged.
+func (p *Plan) RedactValues(values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	// If variables share a value, the first name in sorted order is used.
+	replacements := make(map[string]string, len(values))
+	for _, name := range slices.Backward(slices.Sorted(maps.Keys(values))) {
+		replacements[values[name]] = variable.Reference(name)
+	}
+
+	for _, entry := range p.Plan {
+		var err error
+		if entry.NewState != nil {
+			entry.NewState.Value, err = replaceInJSON(entry.NewState.Value, replacements)
+			if err != nil {
+				return err
+			}
+		}
+
+		entry.RemoteState, err = replaceInValue(entry.RemoteState, replacements)
+		if err != nil {
+			return err
+		}
+
+		for _, ch := range entry.Changes {
+			if ch.Old, err = replaceInValue(ch.Old, replacements); err != nil {
+				return err
+			}
+			if ch.New, err = replaceInValue(ch.New, replacements); err != nil {
+				return err
+			}
+			if ch.Remote, err = replaceInValue(ch.Remote, replacements); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RestoreValues replaces the references to sensitive variables in the new
+// state of a plan redacted by [Plan.RedactValues] with their values.
+func (p *Plan) RestoreValues(values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	replacements := make(map[string]string, len(values))
+	for name, value := range values {
+		replacements[variable.Reference(name)] = value
+	}
+
+	for _, entry := range p.Plan {
+		if entry.NewState == nil {
+			continue
+		}
+		var err error
+		entry.NewState.Value, err = replaceInJSON(entry.NewState.Value, replacements)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
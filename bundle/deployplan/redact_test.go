@@ -0,0 +1,68 @@
+package deployplan
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/databricks/cli/libs/structs/structvar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRemote struct {
+	Token string `json:"token"`
+}
+
+func TestPlanRedactAndRestoreValues(t *testing.T) {
+	values := map[string]string{"token": `s3cr"t`}
+	plan := &Plan{
+		Plan: map[string]*PlanEntry{
+			"resources.jobs.foo": {
+				NewState:    &structvar.StructVarJSON{Value: json.RawMessage(`{"token":"s3cr\"t","name":"foo"}`)},
+				RemoteState: &testRemote{Token: `s3cr"t`},
+				Changes: Changes{
+					"token": {Old: "old", New: `s3cr"t`},
+				},
+			},
+		},
+	}
+
+	err := plan.RedactValues(values)
+	require.NoError(t, err)
+
+	entry := plan.Plan["resources.jobs.foo"]
+	assert.JSONEq(t, `{"token":"${var.token}","name":"foo"}`, string(entry.NewState.Value))
+	assert.Equal(t, &testRemote{Token: "${var.token}"}, entry.RemoteState)
+	assert.Equal(t, "old", entry.Changes["token"].Old)
+	assert.Equal(t, "${var.token}", entry.Changes["token"].New)
+
+	err = plan.RestoreValues(values)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"token":"s3cr\"t","name":"foo"}`, string(entry.NewState.Value))
+}
+
+func TestPlanRedactValuesOnlyReplacesEqualStrings(t *testing.T) {
+	values := map[string]string{"short": "1", "token": "abc"}
+	plan := &Plan{
+		Plan: map[string]*PlanEntry{
+			"resources.jobs.foo": {
+				NewState: &structvar.StructVarJSON{Value: json.RawMessage(`{"abc":"abcd","max_retries":1,"tags":["abc","1"],"timeout":10}`)},
+				Changes: Changes{
+					"max_retries": {Old: 1, New: 10},
+				},
+			},
+		},
+	}
+
+	err := plan.RedactValues(values)
+	require.NoError(t, err)
+
+	entry := plan.Plan["resources.jobs.foo"]
+	assert.JSONEq(t, `{"abc":"abcd","max_retries":1,"tags":["${var.token}","${var.short}"],"timeout":10}`, string(entry.NewState.Value))
+	assert.Equal(t, 1, entry.Changes["max_retries"].Old)
+	assert.Equal(t, 10, entry.Changes["max_retries"].New)
+
+	err = plan.RestoreValues(values)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"abc":"abcd","max_retries":1,"tags":["abc","1"],"timeout":10}`, string(entry.NewState.Value))
+}
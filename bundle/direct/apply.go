@@ -46,6 +46,9 @@ func (d *DeploymentUnit) Deploy(ctx context.Context, db *dstate.DeploymentState,
 
 	switch actionType {
 	case deployplan.Recreate:
+		if planEntry != nil && planEntry.CreateBeforeDestroy {
+			return d.CreateBeforeDestroy(ctx, db, oldID, newState)
+		}
 		return d.Recreate(ctx, db, oldID, newState)
 	case deployplan.Update:
 		return d.Update(ctx, db, oldID, newState, planEntry)
@@ -142,6 +145,41 @@ func (d *DeploymentUnit) Recreate(ctx context.Context, db *dstate.DeploymentStat
 	return d.Create(ctx, db, newState)
 }
 
+// CreateBeforeDestroy replaces the resource like Recreate, but creates the replacement
+// before deleting the old resource, as requested by lifecycle.create_before_destroy.
+// The replacement must not conflict with the old resource (e.g. use a different name).
+func (d *DeploymentUnit) CreateBeforeDestroy(ctx context.Context, db *dstate.DeploymentState, oldID string, newState any) error {
+	oldState, err := d.loadPersistedState(db)
+	if err != nil {
+		return err
+	}
+
+	// On failure the state still points to the old resource, so the next plan retries the replacement.
+	err = d.Create(ctx, db, newState)
+	if err != nil {
+		return fmt.Errorf("creating replacement for id=%s: %w", oldID, err)
+	}
+
+	// From here on the state points to the replacement. If deleting the old resource
+	// fails, it is no longer tracked by the bundle and has to be deleted manually.
+	err = retryOnTransientErr(ctx, func() error { return d.Adapter.DoDelete(ctx, oldID, oldState) })
+	if err != nil && !apierr.IsMissing(err) && !isManagedByParent(err) {
+		if !d.deleteConfirmedGone(ctx, oldID) {
+			return fmt.Errorf("deleting old id=%s after creating its replacement: %w", oldID, err)
+		}
+		log.Warnf(ctx, "Treating %s id=%s as already deleted despite delete error: %s", d.ResourceKey, oldID, err)
+	}
+
+	_, err = waitCapped(ctx, d.MaxWait, "deletion of "+d.ResourceKey, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, d.Adapter.WaitAfterDelete(ctx, oldID)
+	})
+	if err != nil {
+		return fmt.Errorf("waiting after deleting old id=%s: %w", oldID, err)
+	}
+
+	return nil
+}
+
 func (d *DeploymentUnit) Update(ctx context.Context, db *dstate.DeploymentState, id string, newState any, planEntry *deployplan.PlanEntry) error {
 	if !d.Adapter.HasDoUpdate() {
 		return fmt.Errorf("internal error: DoUpdate not implemented for resource %s", d.ResourceKey)
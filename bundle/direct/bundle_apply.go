@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/deployplan"
 	"github.com/databricks/cli/bundle/terraform_dabs_map"
+	"github.com/databricks/cli/libs/dagrun"
 	"github.com/databricks/cli/libs/log"
 	"github.com/databricks/cli/libs/logdiag"
 	"github.com/databricks/cli/libs/structs/structaccess"
@@ -37,21 +40,33 @@ func (b *DeploymentBundle) Apply(ctx context.Context, client *databricks.Workspa
 	b.StateDB.AssertOpenedForWrite()
 	b.RemoteStateCache.Clear()
 
+	err = b.applyMovedState(plan)
+	if err != nil {
+		logdiag.LogError(ctx, err)
+		return
+	}
+
 	g, err := makeGraph(plan)
 	if err != nil {
 		logdiag.LogError(ctx, err)
 		return
 	}
 
+	// Set once a resource fails if StopOnError is set; resources are not started after that.
+	var stopped atomic.Bool
+	b.Outcomes.reset()
+
 	g.Run(defaultParallelism, func(resourceKey string, failedDependency *string) bool {
 		entry, err := plan.WriteLockEntry(resourceKey)
 		if err != nil {
 			logdiag.LogError(ctx, fmt.Errorf("%s: internal error: %w", resourceKey, err))
+			b.Outcomes.record(Outcome{ResourceKey: resourceKey, Status: OutcomeFailed})
 			return false
 		}
 
 		if entry == nil {
 			logdiag.LogError(ctx, fmt.Errorf("%s: internal error: node not in graph", resourceKey))
+			b.Outcomes.record(Outcome{ResourceKey: resourceKey, Status: OutcomeFailed})
 			return false
 		}
 
@@ -62,106 +77,136 @@ func (b *DeploymentBundle) Apply(ctx context.Context, client *databricks.Workspa
 
 		if action == deployplan.Undefined {
 			logdiag.LogError(ctx, fmt.Errorf("cannot deploy %s: unknown action %q", resourceKey, action))
+			b.Outcomes.record(Outcome{ResourceKey: resourceKey, Action: action, Status: OutcomeFailed})
 			return false
 		}
 
 		// If a dependency failed, report and skip execution for this node by returning false
-		if failedDependency != nil {
+		if failedDependency != nil && b.Outcomes.status(*failedDependency) == OutcomeFailed {
 			if action != deployplan.Skip {
 				logdiag.LogError(ctx, fmt.Errorf("%s: dependency failed: %s", errorPrefix, *failedDependency))
+				b.Outcomes.record(Outcome{ResourceKey: resourceKey, Action: action, Status: OutcomeSkipped, FailedDependency: *failedDependency})
 			}
 			return false
 		}
 
-		adapter, err := b.getAdapterForKey(resourceKey)
-		if adapter == nil {
-			logdiag.LogError(ctx, fmt.Errorf("%s: internal error: cannot get adapter: %w", errorPrefix, err))
+		// Any other failed dependency was itself not started because the deployment stopped
+		// after a failure, which is not reported as an error.
+		if failedDependency != nil || stopped.Load() {
+			if action != deployplan.Skip {
+				b.Outcomes.record(Outcome{ResourceKey: resourceKey, Action: action, Status: OutcomeSkipped})
+			}
 			return false
 		}
 
-		// Deletes are capped even with dependents: state is dropped before the wait, so a
-		// cut-short delete leaves the resource untracked while it tears down, and a dependency
-		// deleted after it may be rejected for still having a child. Accepted deliberately.
-		// Recreate's internal delete-wait is never routed through the cap at all, because it
-		// releases the name for the create that follows.
-		unitWait := maxWait
-		if action != deployplan.Delete && hasBlockingDependents(g, resourceKey) {
-			unitWait = maxWaitUnset
-			if maxWait != maxWaitUnset {
-				log.Debugf(ctx, "Not capping wait for %s: other resources depend on it", resourceKey)
+		if !b.applyEntry(ctx, g, resourceKey, entry, errorPrefix, maxWait) {
+			b.Outcomes.record(Outcome{ResourceKey: resourceKey, Action: action, Status: OutcomeFailed})
+			if b.StopOnError {
+				stopped.Store(true)
 			}
+			return false
 		}
 
-		d := &DeploymentUnit{
-			ResourceKey: resourceKey,
-			Adapter:     adapter,
-			DependsOn:   entry.DependsOn,
-			MaxWait:     unitWait,
+		if action != deployplan.Skip {
+			b.Outcomes.record(Outcome{ResourceKey: resourceKey, Action: action, Status: OutcomeApplied})
 		}
+		return true
+	})
+}
 
-		if action == deployplan.Delete {
-			if entry.Gone {
-				// Planning confirmed the resource is already deleted remotely; only
-				// remove it from the state, without calling the delete API.
-				err = b.StateDB.DeleteState(resourceKey)
-			} else {
-				err = d.Destroy(ctx, &b.StateDB)
-			}
-			if err != nil {
-				logdiag.LogError(ctx, fmt.Errorf("%s: %w", errorPrefix, err))
-				return false
-			}
-			return true
+// applyEntry applies the planned action of a single resource. Errors are logged and
+// reported by returning false.
+func (b *DeploymentBundle) applyEntry(ctx context.Context, g *dagrun.Graph, resourceKey string, entry *deployplan.PlanEntry, errorPrefix string, maxWait time.Duration) bool {
+	action := entry.Action
+
+	adapter, err := b.getAdapterForKey(resourceKey)
+	if adapter == nil {
+		logdiag.LogError(ctx, fmt.Errorf("%s: internal error: cannot get adapter: %w", errorPrefix, err))
+		return false
+	}
+
+	// Deletes are capped even with dependents: state is dropped before the wait, so a
+	// cut-short delete leaves the resource untracked while it tears down, and a dependency
+	// deleted after it may be rejected for still having a child. Accepted deliberately.
+	// Recreate's internal delete-wait is never routed through the cap at all, because it
+	// releases the name for the create that follows.
+	unitWait := maxWait
+	if action != deployplan.Delete && hasBlockingDependents(g, resourceKey) {
+		unitWait = maxWaitUnset
+		if maxWait != maxWaitUnset {
+			log.Debugf(ctx, "Not capping wait for %s: other resources depend on it", resourceKey)
 		}
+	}
 
-		// We don't keep NewState around for 'skip' nodes
+	d := &DeploymentUnit{
+		ResourceKey: resourceKey,
+		Adapter:     adapter,
+		DependsOn:   entry.DependsOn,
+		MaxWait:     unitWait,
+	}
 
-		if action != deployplan.Skip {
-			if !b.resolveReferences(ctx, resourceKey, entry, errorPrefix, false) {
-				return false
-			}
+	if action == deployplan.Delete {
+		if entry.Gone {
+			// Planning confirmed the resource is already deleted remotely; only
+			// remove it from the state, without calling the delete API.
+			err = b.StateDB.DeleteState(resourceKey)
+		} else {
+			err = d.Destroy(ctx, &b.StateDB)
+		}
+		if err != nil {
+			logdiag.LogError(ctx, fmt.Errorf("%s: %w", errorPrefix, err))
+			return false
+		}
+		return true
+	}
 
-			// Get the cached StructVar to check for unresolved refs and get value
-			sv, ok := b.StateCache.Load(resourceKey)
-			if !ok {
-				logdiag.LogError(ctx, fmt.Errorf("%s: internal error: missing cached StructVar", errorPrefix))
-				return false
-			}
+	// We don't keep NewState around for 'skip' nodes
 
-			if len(sv.Refs) > 0 {
-				logdiag.LogError(ctx, fmt.Errorf("%s: unresolved references: %s", errorPrefix, jsonDump(sv.Refs)))
-				return false
-			}
+	if action != deployplan.Skip {
+		if !b.resolveReferences(ctx, resourceKey, entry, errorPrefix, false) {
+			return false
+		}
 
-			// TODO: redo calcDiff to downgrade planned action if possible (?)
-			err = d.Deploy(ctx, &b.StateDB, sv.Value, action, entry)
-			if err != nil {
-				logdiag.LogError(ctx, fmt.Errorf("%s: %w", errorPrefix, err))
-				return false
-			}
+		// Get the cached StructVar to check for unresolved refs and get value
+		sv, ok := b.StateCache.Load(resourceKey)
+		if !ok {
+			logdiag.LogError(ctx, fmt.Errorf("%s: internal error: missing cached StructVar", errorPrefix))
+			return false
 		}
 
-		// TODO: Note, we only really need remote state if there are remote references.
-		//       The graph includes edges for both local and remote references. The local references are
-		//       already resolved and should not play a role here.
-		needRemoteState := len(g.Adj[resourceKey]) > 0
-		if needRemoteState {
-			id := b.StateDB.GetResourceID(d.ResourceKey)
-			if id == "" {
-				logdiag.LogError(ctx, fmt.Errorf("%s: internal error: missing entry in state after deploy", errorPrefix))
-				return false
-			}
+		if len(sv.Refs) > 0 {
+			logdiag.LogError(ctx, fmt.Errorf("%s: unresolved references: %s", errorPrefix, jsonDump(sv.Refs)))
+			return false
+		}
 
-			err = d.refreshRemoteState(ctx, id)
-			if err != nil {
-				logdiag.LogError(ctx, fmt.Errorf("%s: failed to read remote state: %w", errorPrefix, err))
-				return false
-			}
-			b.RemoteStateCache.Store(resourceKey, d.RemoteState)
+		// TODO: redo calcDiff to downgrade planned action if possible (?)
+		err = d.Deploy(ctx, &b.StateDB, sv.Value, action, entry)
+		if err != nil {
+			logdiag.LogError(ctx, fmt.Errorf("%s: %w", errorPrefix, err))
+			return false
 		}
+	}
 
-		return true
-	})
+	// TODO: Note, we only really need remote state if there are remote references.
+	//       The graph includes edges for both local and remote references. The local references are
+	//       already resolved and should not play a role here.
+	needRemoteState := len(g.Adj[resourceKey]) > 0
+	if needRemoteState {
+		id := b.StateDB.GetResourceID(d.ResourceKey)
+		if id == "" {
+			logdiag.LogError(ctx, fmt.Errorf("%s: internal error: missing entry in state after deploy", errorPrefix))
+			return false
+		}
+
+		err = d.refreshRemoteState(ctx, id)
+		if err != nil {
+			logdiag.LogError(ctx, fmt.Errorf("%s: failed to read remote state: %w", errorPrefix, err))
+			return false
+		}
+		b.RemoteStateCache.Store(resourceKey, d.RemoteState)
+	}
+
+	return true
 }
 
 func (b *DeploymentBundle) LookupReferencePostDeploy(ctx context.Context, path *structpath.PathNode) (any, error) {
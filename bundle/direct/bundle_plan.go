@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/variable"
 	"github.com/databricks/cli/bundle/deployplan"
 	"github.com/databricks/cli/bundle/direct/dresources"
 	"github.com/databricks/cli/bundle/direct/dstate"
@@ -123,11 +124,25 @@ func (b *DeploymentBundle) CalculatePlan(ctx context.Context, client *databricks
 		return nil, err
 	}
 
+	var moved map[string]string
+	if configRoot != nil {
+		moved, err = b.applyMoved(ctx, configRoot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	plan, err := b.makePlan(ctx, configRoot, &b.StateDB.Data)
 	if err != nil {
 		return nil, fmt.Errorf("reading config: %w", err)
 	}
 
+	for key, from := range moved {
+		if entry := plan.Plan[key]; entry != nil {
+			entry.MovedFrom = from
+		}
+	}
+
 	b.Plan = plan
 
 	g, err := makeGraph(plan)
@@ -281,7 +296,13 @@ func (b *DeploymentBundle) CalculatePlan(ctx context.Context, client *databricks
 			return false
 		}
 
-		err = addPerFieldActions(ctx, adapter, entry.Changes, remoteState)
+		ignoreChanges, err := parseIgnoreChanges(adapter, entry.IgnoreChanges)
+		if err != nil {
+			logdiag.LogError(ctx, fmt.Errorf("%s: %w", errorPrefix, err))
+			return false
+		}
+
+		err = addPerFieldActions(ctx, adapter, entry.Changes, remoteState, ignoreChanges)
 		if err != nil {
 			logdiag.LogError(ctx, fmt.Errorf("%s: classifying changes: %w", errorPrefix, err))
 			return false
@@ -305,6 +326,20 @@ func (b *DeploymentBundle) CalculatePlan(ctx context.Context, client *databricks
 			return false
 		}
 
+		// A recreated resource is configured from scratch, so ignored fields only matter for updates.
+		if len(ignoreChanges) > 0 && action != deployplan.Create && action != deployplan.Recreate {
+			err = keepIgnoredFields(adapter, entry.Changes, sv.Value)
+			if err != nil {
+				logdiag.LogError(ctx, fmt.Errorf("%s: %w", errorPrefix, err))
+				return false
+			}
+			err = sv.SyncToJSON(entry.NewState)
+			if err != nil {
+				logdiag.LogError(ctx, fmt.Errorf("%s: cannot save state: %w", errorPrefix, err))
+				return false
+			}
+		}
+
 		entry.Action = action
 		return true
 	})
@@ -329,6 +364,14 @@ func (b *DeploymentBundle) CalculatePlan(ctx context.Context, client *databricks
 		}
 	}
 
+	// Values of sensitive variables (e.g. secret lookups) are never stored in the plan.
+	// They are restored from the configuration when a plan file is applied.
+	if configRoot != nil {
+		if err := plan.RedactValues(variable.SensitiveValues(configRoot.Variables)); err != nil {
+			return nil, fmt.Errorf("redacting plan: %w", err)
+		}
+	}
+
 	return plan, nil
 }
 
@@ -382,7 +425,7 @@ func prepareChanges(ctx context.Context, adapter *dresources.Adapter, localDiff,
 	return m, nil
 }
 
-func addPerFieldActions(ctx context.Context, adapter *dresources.Adapter, changes deployplan.Changes, remoteState any) error {
+func addPerFieldActions(ctx context.Context, adapter *dresources.Adapter, changes deployplan.Changes, remoteState any, ignoreChanges []*structpath.PatternNode) error {
 	cfg := adapter.ResourceConfig()
 	generatedCfg := adapter.GeneratedResourceConfig()
 
@@ -394,6 +437,14 @@ func addPerFieldActions(ctx context.Context, adapter *dresources.Adapter, change
 			return err
 		}
 
+		// lifecycle.ignore_changes takes precedence over all other rules, including
+		// recreate_on_changes and custom classification by the resource.
+		if matchesIgnoreChanges(path, ignoreChanges) {
+			ch.Action = deployplan.Skip
+			ch.Reason = deployplan.ReasonIgnoreChanges
+			continue
+		}
+
 		// RemoteAlreadySet only holds when ch.Remote is a real remote value we can compare
 		// against ch.New. Skip it for fields whose remote value is fabricated and thus
 		// meaningless: declared ignore_remote_changes (present in RemoteType but read back
@@ -975,6 +1026,11 @@ func (b *DeploymentBundle) makePlan(ctx context.Context, configRoot *config.Root
 			return nil, fmt.Errorf("%s: %w", prefix, err)
 		}
 
+		lifecycle, err := readLifecycle(configRoot.Value(), node, adapter.StateType())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", prefix, err)
+		}
+
 		inputStructVar, err := adapter.PrepareInputConfig(inputConfig, node)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", prefix, err)
@@ -1085,8 +1141,10 @@ func (b *DeploymentBundle) makePlan(ctx context.Context, configRoot *config.Root
 		}
 
 		e := deployplan.PlanEntry{
-			DependsOn: dependsOn,
-			NewState:  newStateJSON,
+			DependsOn:           dependsOn,
+			IgnoreChanges:       lifecycle.IgnoreChanges,
+			CreateBeforeDestroy: lifecycle.CreateBeforeDestroy,
+			NewState:            newStateJSON,
 		}
 
 		p.Plan[node] = &e
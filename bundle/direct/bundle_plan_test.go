@@ -11,6 +11,7 @@ import (
 	"github.com/databricks/cli/libs/dyn/yamlloader"
 	"github.com/databricks/cli/libs/structs/structpath"
 	"github.com/databricks/cli/libs/structs/structvar"
+	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/databricks-sdk-go/service/jobs"
 	"github.com/databricks/databricks-sdk-go/service/pipelines"
 	"github.com/stretchr/testify/assert"
@@ -256,7 +257,7 @@ func TestRemoteAlreadySetGuards(t *testing.T) {
 			adapter, ok := adapters[tt.resource]
 			require.True(t, ok)
 			changes := deployplan.Changes{tt.field: tt.ch}
-			err := addPerFieldActions(t.Context(), adapter, changes, nil)
+			err := addPerFieldActions(t.Context(), adapter, changes, nil, nil)
 			require.NoError(t, err)
 			assert.Equal(t, tt.expectedAction, tt.ch.Action)
 			if tt.expectedReason != "" {
@@ -323,7 +324,7 @@ func jobRunResultStateAction(t *testing.T, state *jobs.RunState) *deployplan.Cha
 		Remote: state.ResultState,
 	}}
 
-	require.NoError(t, addPerFieldActions(t.Context(), adapters["job_runs"], changes, remote))
+	require.NoError(t, addPerFieldActions(t.Context(), adapters["job_runs"], changes, remote, nil))
 	return changes["result_state"]
 }
 
@@ -401,3 +402,46 @@ func bundleWithSkippedJobRun(t *testing.T, remote *dresources.JobRunRemote) *Dep
 	b.RemoteStateCache.Store(jobRunKey, remote)
 	return b
 }
+
+func TestIgnoreChanges(t *testing.T) {
+	adapters, err := dresources.InitAll(nil)
+	require.NoError(t, err)
+	adapter := adapters["jobs"]
+
+	ignoreChanges, err := parseIgnoreChanges(adapter, []string{"schedule.pause_status", "tasks[*].new_cluster.num_workers"})
+	require.NoError(t, err)
+
+	changes := deployplan.Changes{
+		"schedule.pause_status":              {Old: "UNPAUSED", New: "UNPAUSED", Remote: "PAUSED"},
+		"tasks[0].new_cluster.num_workers":   {Old: 1, New: 2, Remote: 4},
+		"tasks[0].new_cluster.spark_version": {Old: "15.4.x", New: "16.4.x", Remote: "15.4.x"},
+	}
+
+	err = addPerFieldActions(t.Context(), adapter, changes, nil, ignoreChanges)
+	require.NoError(t, err)
+	assert.Equal(t, deployplan.Skip, changes["schedule.pause_status"].Action)
+	assert.Equal(t, deployplan.ReasonIgnoreChanges, changes["schedule.pause_status"].Reason)
+	assert.Equal(t, deployplan.Skip, changes["tasks[0].new_cluster.num_workers"].Action)
+	assert.Equal(t, deployplan.ReasonIgnoreChanges, changes["tasks[0].new_cluster.num_workers"].Reason)
+	assert.Equal(t, deployplan.Update, changes["tasks[0].new_cluster.spark_version"].Action)
+
+	newState := &jobs.JobSettings{
+		Schedule: &jobs.CronSchedule{PauseStatus: jobs.PauseStatusUnpaused},
+		Tasks: []jobs.Task{
+			{NewCluster: &compute.ClusterSpec{NumWorkers: 2, SparkVersion: "16.4.x"}},
+		},
+	}
+	err = keepIgnoredFields(adapter, changes, newState)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.PauseStatusPaused, newState.Schedule.PauseStatus)
+	assert.Equal(t, 4, newState.Tasks[0].NewCluster.NumWorkers)
+	assert.Equal(t, "16.4.x", newState.Tasks[0].NewCluster.SparkVersion)
+}
+
+func TestIgnoreChangesInvalidField(t *testing.T) {
+	adapters, err := dresources.InitAll(nil)
+	require.NoError(t, err)
+
+	_, err = parseIgnoreChanges(adapters["jobs"], []string{"schedule.unknown"})
+	assert.ErrorContains(t, err, `invalid lifecycle.ignore_changes entry "schedule.unknown"`)
+}
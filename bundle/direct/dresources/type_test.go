@@ -79,16 +79,24 @@ var knownMissingInStateType = map[string][]string{
 	},
 	"apps": {
 		"lifecycle.prevent_destroy",
+		"lifecycle.ignore_changes",
+		"lifecycle.create_before_destroy",
 	},
 	"clusters": {
 		"lifecycle.prevent_destroy",
+		"lifecycle.ignore_changes",
+		"lifecycle.create_before_destroy",
 	},
 	"sql_warehouses": {
 		"lifecycle.prevent_destroy",
+		"lifecycle.ignore_changes",
+		"lifecycle.create_before_destroy",
 	},
 	"job_runs": {
 		// State stores trigger fingerprints, not the config trigger list / prevent_destroy.
 		"lifecycle.prevent_destroy",
+		"lifecycle.ignore_changes",
+		"lifecycle.create_before_destroy",
 		"lifecycle.triggers[*]",
 	},
 	"dashboards": {
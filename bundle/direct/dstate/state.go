@@ -8,8 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/databricks/cli/bundle/config"
@@ -70,6 +73,9 @@ type DeploymentState struct {
 
 	// Maps resource key to ID. Unlike Data.State, this is up to date during writes (deploys).
 	stateIDs map[string]string
+
+	// Keys of resources with pending changes. Unlike Data.Pending, this is up to date during writes.
+	pending []string
 }
 
 type Header struct {
@@ -98,6 +104,11 @@ type Database struct {
 	// This is not updated during write/deploy, those writes go to WAL instead.
 	// The State is then reconstructed from WAL.
 	State map[string]ResourceEntry `json:"state"`
+
+	// Pending lists the keys of resources whose planned changes were not applied
+	// by the deployments that wrote this state, because they failed, were skipped
+	// after a failure or the deployment was interrupted.
+	Pending []string `json:"pending,omitempty"`
 }
 
 type ResourceEntry struct {
@@ -109,6 +120,9 @@ type ResourceEntry struct {
 type WALEntry struct {
 	Key   string         `json:"k"`
 	Value *ResourceEntry `json:"v,omitempty"` // nil means delete
+
+	// Pending, if set, replaces Database.Pending; Key and Value are not used.
+	Pending *[]string `json:"p,omitempty"`
 }
 
 func NewDatabase(lineage string, serial int) Database {
@@ -166,6 +180,108 @@ func (db *DeploymentState) DeleteState(key string) error {
 	return err
 }
 
+// Move renames the resource from to to, keeping its ID and recorded state.
+// Dependencies of other resources on it are renamed as well.
+//
+// The state in memory is renamed right away, so that the resource can be looked
+// up by its new key while planning. In write mode the rename is also recorded in
+// the WAL, so that Finalize persists it. Move may be called in write mode for a
+// resource that was already renamed in read mode, to record that rename.
+func (db *DeploymentState) Move(from, to string) error {
+	db.AssertOpenedForReadOrWrite()
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if entry, ok := db.Data.State[from]; ok {
+		if _, exists := db.Data.State[to]; exists {
+			return fmt.Errorf("cannot move %s to %s: both are present in the state", from, to)
+		}
+		delete(db.Data.State, from)
+		delete(db.stateIDs, from)
+		db.Data.State[to] = entry
+		db.stateIDs[to] = entry.ID
+	}
+
+	if _, ok := db.Data.State[to]; !ok {
+		return fmt.Errorf("cannot move %s to %s: %s is not present in the state", from, to, from)
+	}
+
+	var dependents []string
+	for key, entry := range db.Data.State {
+		changed := false
+		for i, dep := range entry.DependsOn {
+			if dep.Node == from {
+				entry.DependsOn[i].Node = to
+				changed = true
+			}
+			if dep.Node == to {
+				changed = true
+			}
+		}
+		if changed {
+			dependents = append(dependents, key)
+		}
+	}
+
+	if db.walFile == nil {
+		return nil
+	}
+
+	err := appendJSONLine(db.walFile, WALEntry{Key: from})
+	if err != nil {
+		return err
+	}
+	slices.Sort(dependents)
+	for _, key := range append([]string{to}, dependents...) {
+		entry := db.Data.State[key]
+		err = appendJSONLine(db.walFile, WALEntry{Key: key, Value: &entry})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameKey returns the key with the resource key from replaced by to.
+// Sub-resources such as "resources.jobs.foo.permissions" follow their parent.
+func renameKey(key, from, to string) (string, bool) {
+	if key == from {
+		return to, true
+	}
+	if rest, ok := strings.CutPrefix(key, from+"."); ok {
+		return to + "." + rest, true
+	}
+	return key, false
+}
+
+// MoveResource renames the resource from to to along with its sub-resources,
+// such as permissions and grants, using [DeploymentState.Move] for each of them.
+// It returns the previous key of every renamed entry, keyed by its new key.
+func (db *DeploymentState) MoveResource(from, to string) (map[string]string, error) {
+	db.AssertOpenedForReadOrWrite()
+	db.mu.Lock()
+	keys := slices.Sorted(maps.Keys(db.Data.State))
+	db.mu.Unlock()
+
+	moved := make(map[string]string)
+	for _, key := range keys {
+		newKey, ok := renameKey(key, from, to)
+		if !ok {
+			continue
+		}
+		err := db.Move(key, newKey)
+		if err != nil {
+			return nil, err
+		}
+		moved[newKey] = key
+	}
+
+	if len(moved) == 0 {
+		return nil, fmt.Errorf("cannot move %s to %s: %s is not present in the state", from, to, from)
+	}
+	return moved, nil
+}
+
 func (db *DeploymentState) GetResourceEntry(key string) (ResourceEntry, bool) {
 	// Note, if opened for write, you get the state that you had at the beginning of deploy, not most recent one
 	db.AssertOpenedForReadOrWrite()
@@ -180,6 +296,37 @@ func (db *DeploymentState) GetResourceEntry(key string) (ResourceEntry, bool) {
 	return result, ok
 }
 
+// Pending returns the keys of resources with pending changes, as last recorded with SetPending.
+func (db *DeploymentState) Pending() []string {
+	db.AssertOpenedForReadOrWrite()
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return slices.Clone(db.pending)
+}
+
+// SetPending records the keys of resources with pending changes. The keys are
+// written to the WAL, so that they are persisted by Finalize, or recovered from
+// the WAL if the deployment is interrupted. Nothing is written if they did not change.
+func (db *DeploymentState) SetPending(keys []string) error {
+	db.AssertOpenedForWrite()
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	// Non-nil, so that an empty list is written as [] rather than null.
+	keys = append([]string{}, keys...)
+	slices.Sort(keys)
+	if slices.Equal(keys, db.pending) {
+		return nil
+	}
+
+	err := appendJSONLine(db.walFile, WALEntry{Pending: &keys})
+	if err == nil {
+		db.pending = keys
+	}
+	return err
+}
+
 // GetResourceID returns the ID of the resource for the given key, or an empty string if not found.
 func (db *DeploymentState) GetResourceID(key string) string {
 	db.AssertOpenedForReadOrWrite()
@@ -256,6 +403,7 @@ func (db *DeploymentState) Open(ctx context.Context, path string, withRecovery W
 	for key, entry := range db.Data.State {
 		db.stateIDs[key] = entry.ID
 	}
+	db.pending = db.Data.Pending
 
 	walPath := db.Path + walSuffix
 	_, err = os.Stat(walPath)
@@ -315,6 +463,7 @@ func (db *DeploymentState) OpenWithData(path string, data Database) {
 	for key, entry := range data.State {
 		db.stateIDs[key] = entry.ID
 	}
+	db.pending = data.Pending
 }
 
 func (db *DeploymentState) replayWAL(ctx context.Context) error {
@@ -391,6 +540,11 @@ func (db *DeploymentState) mergeWalIntoState(ctx context.Context) (bool, error)
 				corruptedLines = append(corruptedLines, append([]byte(nil), line...))
 				continue
 			}
+			if entry.Pending != nil {
+				db.Data.Pending = *entry.Pending
+				db.pending = *entry.Pending
+				continue
+			}
 			if db.Data.State == nil {
 				db.Data.State = make(map[string]ResourceEntry)
 			}
@@ -466,6 +620,7 @@ func (db *DeploymentState) Finalize(ctx context.Context) (resourcestate.Exported
 	db.Path = ""
 	db.Data = Database{}
 	db.stateIDs = nil
+	db.pending = nil
 
 	return state, err
 }
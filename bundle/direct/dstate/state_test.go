@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/databricks/cli/bundle/deployplan"
 	"github.com/databricks/cli/internal/build"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -261,3 +262,111 @@ func TestGetOrInitLineageReadableBeforeWriteAndPersisted(t *testing.T) {
 	assert.Equal(t, lineage, reopened.Data.Lineage)
 	mustFinalize(t, &reopened)
 }
+
+func TestMoveInReadModeThenWriteMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	var db DeploymentState
+	require.NoError(t, db.Open(t.Context(), path, WithRecovery(true), WithWrite(true)))
+	require.NoError(t, db.SaveState("resources.jobs.foo", "123", map[string]string{}, nil))
+	require.NoError(t, db.SaveState("resources.pipelines.bar", "456", map[string]string{}, []deployplan.DependsOnEntry{
+		{Node: "resources.jobs.foo", Label: "${resources.jobs.foo.id}"},
+	}))
+	mustFinalize(t, &db)
+
+	// Planning renames the resource in memory only.
+	require.NoError(t, db.Open(t.Context(), path, WithRecovery(true), WithWrite(false)))
+	require.NoError(t, db.Move("resources.jobs.foo", "resources.jobs.baz"))
+	assert.Equal(t, "123", db.GetResourceID("resources.jobs.baz"))
+	assert.Empty(t, db.GetResourceID("resources.jobs.foo"))
+	assert.Equal(t, "resources.jobs.baz", db.Data.State["resources.pipelines.bar"].DependsOn[0].Node)
+
+	// Applying records the rename in the WAL.
+	require.NoError(t, db.UpgradeToWrite())
+	require.NoError(t, db.Move("resources.jobs.foo", "resources.jobs.baz"))
+	mustFinalize(t, &db)
+
+	var db2 DeploymentState
+	require.NoError(t, db2.Open(t.Context(), path, WithRecovery(false), WithWrite(false)))
+	assert.Equal(t, 2, db2.Data.Serial)
+	assert.Equal(t, "123", db2.GetResourceID("resources.jobs.baz"))
+	assert.NotContains(t, db2.Data.State, "resources.jobs.foo")
+	assert.Equal(t, "resources.jobs.baz", db2.Data.State["resources.pipelines.bar"].DependsOn[0].Node)
+	mustFinalize(t, &db2)
+}
+
+func TestMoveMissingResource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	var db DeploymentState
+	require.NoError(t, db.Open(t.Context(), path, WithRecovery(true), WithWrite(false)))
+	err := db.Move("resources.jobs.foo", "resources.jobs.baz")
+	assert.EqualError(t, err, "cannot move resources.jobs.foo to resources.jobs.baz: resources.jobs.foo is not present in the state")
+	mustFinalize(t, &db)
+}
+
+func TestMoveResourceWithSubResources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	var db DeploymentState
+	require.NoError(t, db.Open(t.Context(), path, WithRecovery(true), WithWrite(true)))
+	require.NoError(t, db.SaveState("resources.jobs.foo", "123", map[string]string{}, nil))
+	require.NoError(t, db.SaveState("resources.jobs.foo.permissions", "/jobs/123", map[string]string{}, nil))
+	require.NoError(t, db.SaveState("resources.jobs.foobar", "456", map[string]string{}, nil))
+	mustFinalize(t, &db)
+
+	require.NoError(t, db.Open(t.Context(), path, WithRecovery(true), WithWrite(true)))
+	moved, err := db.MoveResource("resources.jobs.foo", "resources.jobs.baz")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"resources.jobs.baz":             "resources.jobs.foo",
+		"resources.jobs.baz.permissions": "resources.jobs.foo.permissions",
+	}, moved)
+
+	_, err = db.MoveResource("resources.jobs.missing", "resources.jobs.other")
+	assert.EqualError(t, err, "cannot move resources.jobs.missing to resources.jobs.other: resources.jobs.missing is not present in the state")
+	mustFinalize(t, &db)
+
+	var db2 DeploymentState
+	require.NoError(t, db2.Open(t.Context(), path, WithRecovery(false), WithWrite(false)))
+	assert.Equal(t, "123", db2.GetResourceID("resources.jobs.baz"))
+	assert.Equal(t, "/jobs/123", db2.GetResourceID("resources.jobs.baz.permissions"))
+	assert.Equal(t, "456", db2.GetResourceID("resources.jobs.foobar"))
+	assert.NotContains(t, db2.Data.State, "resources.jobs.foo")
+	mustFinalize(t, &db2)
+}
+
+func TestSetPendingIsPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	var db DeploymentState
+	require.NoError(t, db.Open(t.Context(), path, WithRecovery(true), WithWrite(true)))
+	require.NoError(t, db.SetPending([]string{"resources.jobs.b", "resources.jobs.a"}))
+	assert.Equal(t, []string{"resources.jobs.a", "resources.jobs.b"}, db.Pending())
+	mustFinalize(t, &db)
+
+	var db2 DeploymentState
+	require.NoError(t, db2.Open(t.Context(), path, WithRecovery(false), WithWrite(true)))
+	assert.Equal(t, 1, db2.Data.Serial)
+	assert.Equal(t, []string{"resources.jobs.a", "resources.jobs.b"}, db2.Pending())
+	require.NoError(t, db2.SetPending(nil))
+	mustFinalize(t, &db2)
+
+	var db3 DeploymentState
+	require.NoError(t, db3.Open(t.Context(), path, WithRecovery(false), WithWrite(false)))
+	assert.Equal(t, 2, db3.Data.Serial)
+	assert.Empty(t, db3.Pending())
+	mustFinalize(t, &db3)
+}
+
+func TestSetPendingUnchangedDoesNotWriteWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	var db DeploymentState
+	require.NoError(t, db.Open(t.Context(), path, WithRecovery(true), WithWrite(true)))
+	require.NoError(t, db.SetPending(nil))
+	mustFinalize(t, &db)
+
+	_, err := os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
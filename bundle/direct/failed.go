@@ -0,0 +1,75 @@
+package direct
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/databricks/cli/bundle/direct/dstate"
+)
+
+// FailedResources returns the resources that failed, were skipped or were not
+// completed by earlier deployments, as selectors in the "type.name" form accepted
+// by plan.FilterToSelected. They are read from the deployment state, so they are
+// available wherever the state is pulled, including after an interrupted deployment
+// whose WAL was recovered.
+func FailedResources(db *dstate.DeploymentState) []string {
+	var selectors []string
+	for _, key := range db.Pending() {
+		selectors = append(selectors, strings.TrimPrefix(key, "resources."))
+	}
+	return selectors
+}
+
+// RecordPendingResources marks every resource with a planned action as pending in
+// the deployment state before the plan is applied. If the deployment is interrupted,
+// the resources it completed are recovered from the WAL and plan as unchanged on retry.
+func RecordPendingResources(db *dstate.DeploymentState, plan *deployplan.Plan) error {
+	keys := pendingSet(db)
+	for key, entry := range plan.Plan {
+		if entry.Action != deployplan.Skip {
+			keys[parentKey(key)] = true
+		}
+	}
+	return db.SetPending(slices.Collect(maps.Keys(keys)))
+}
+
+// RecordFailedResources updates the pending resources in the deployment state with the
+// outcome of applying plan: resources in the plan are no longer pending unless their
+// action was not applied. Resources of earlier deployments that were not in the plan
+// remain pending.
+func RecordFailedResources(db *dstate.DeploymentState, plan *deployplan.Plan, outcomes []Outcome) error {
+	keys := pendingSet(db)
+
+	applied := make(map[string]bool, len(outcomes))
+	for _, o := range outcomes {
+		applied[o.ResourceKey] = o.Status == OutcomeApplied
+	}
+
+	for key := range plan.Plan {
+		delete(keys, parentKey(key))
+	}
+	for key, entry := range plan.Plan {
+		if entry.Action != deployplan.Skip && !applied[key] {
+			keys[parentKey(key)] = true
+		}
+	}
+	return db.SetPending(slices.Collect(maps.Keys(keys)))
+}
+
+func pendingSet(db *dstate.DeploymentState) map[string]bool {
+	keys := make(map[string]bool)
+	for _, key := range db.Pending() {
+		keys[key] = true
+	}
+	return keys
+}
+
+// parentKey converts a plan key to the key of its resource. Permissions and grants
+// are applied with their parent resource, so they select the parent.
+func parentKey(key string) string {
+	key = strings.TrimSuffix(key, ".permissions")
+	key = strings.TrimSuffix(key, ".grants")
+	return key
+}
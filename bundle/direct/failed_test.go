@@ -0,0 +1,89 @@
+package direct
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/databricks/cli/bundle/direct/dstate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reopen finalizes db and opens the persisted state for write again, as the next
+// deployment would after pulling it.
+func reopen(t *testing.T, db *dstate.DeploymentState, path string) {
+	t.Helper()
+	_, err := db.Finalize(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, db.Open(t.Context(), path, dstate.WithRecovery(true), dstate.WithWrite(true)))
+}
+
+func TestRecordFailedResources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resources.json")
+	var db dstate.DeploymentState
+	require.NoError(t, db.Open(t.Context(), path, dstate.WithRecovery(true), dstate.WithWrite(true)))
+
+	plan := deployplan.NewPlanDirect()
+	plan.Plan["resources.jobs.independent"] = &deployplan.PlanEntry{Action: deployplan.Create}
+	plan.Plan["resources.jobs.foo"] = &deployplan.PlanEntry{Action: deployplan.Update}
+	plan.Plan["resources.jobs.foo.permissions"] = &deployplan.PlanEntry{Action: deployplan.Create}
+	plan.Plan["resources.jobs.bar"] = &deployplan.PlanEntry{Action: deployplan.Create}
+	plan.Plan["resources.jobs.unchanged"] = &deployplan.PlanEntry{Action: deployplan.Skip}
+
+	// Before applying, every resource with an action is pending.
+	require.NoError(t, RecordPendingResources(&db, plan))
+	assert.Equal(t, []string{"jobs.bar", "jobs.foo", "jobs.independent"}, FailedResources(&db))
+
+	// The parent resource is recorded if only its permissions failed.
+	require.NoError(t, RecordFailedResources(&db, plan, []Outcome{
+		{ResourceKey: "resources.jobs.independent", Status: OutcomeApplied},
+		{ResourceKey: "resources.jobs.foo", Status: OutcomeApplied},
+		{ResourceKey: "resources.jobs.foo.permissions", Status: OutcomeFailed},
+		{ResourceKey: "resources.jobs.bar", Status: OutcomeSkipped},
+	}))
+	reopen(t, &db, path)
+	assert.Equal(t, []string{"jobs.bar", "jobs.foo"}, FailedResources(&db))
+
+	// Retrying a subset keeps the resources that were not retried.
+	retry := deployplan.NewPlanDirect()
+	retry.Plan["resources.jobs.bar"] = &deployplan.PlanEntry{Action: deployplan.Create}
+	require.NoError(t, RecordPendingResources(&db, retry))
+	require.NoError(t, RecordFailedResources(&db, retry, []Outcome{
+		{ResourceKey: "resources.jobs.bar", Status: OutcomeApplied},
+	}))
+	reopen(t, &db, path)
+	assert.Equal(t, []string{"jobs.foo"}, FailedResources(&db))
+
+	// Nothing is left to retry once the remaining resource is applied.
+	retry = deployplan.NewPlanDirect()
+	retry.Plan["resources.jobs.foo"] = &deployplan.PlanEntry{Action: deployplan.Skip}
+	retry.Plan["resources.jobs.foo.permissions"] = &deployplan.PlanEntry{Action: deployplan.Update}
+	require.NoError(t, RecordPendingResources(&db, retry))
+	require.NoError(t, RecordFailedResources(&db, retry, []Outcome{
+		{ResourceKey: "resources.jobs.foo.permissions", Status: OutcomeApplied},
+	}))
+	reopen(t, &db, path)
+	assert.Empty(t, FailedResources(&db))
+	assert.Empty(t, db.Data.Pending)
+	_, err := db.Finalize(t.Context())
+	require.NoError(t, err)
+}
+
+func TestRecordFailedResourcesInterrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resources.json")
+	var db dstate.DeploymentState
+	require.NoError(t, db.Open(t.Context(), path, dstate.WithRecovery(true), dstate.WithWrite(true)))
+
+	plan := deployplan.NewPlanDirect()
+	plan.Plan["resources.jobs.foo"] = &deployplan.PlanEntry{Action: deployplan.Create}
+	require.NoError(t, RecordPendingResources(&db, plan))
+
+	// The deployment is interrupted before the outcome is recorded. The next
+	// deployment recovers the pending resources from the WAL.
+	var next dstate.DeploymentState
+	require.NoError(t, next.Open(t.Context(), path, dstate.WithRecovery(true), dstate.WithWrite(false)))
+	assert.Equal(t, []string{"jobs.foo"}, FailedResources(&next))
+	_, err := next.Finalize(t.Context())
+	require.NoError(t, err)
+}
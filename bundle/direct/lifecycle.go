@@ -0,0 +1,109 @@
+package direct
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/databricks/cli/bundle/direct/dresources"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/convert"
+	"github.com/databricks/cli/libs/structs/structaccess"
+	"github.com/databricks/cli/libs/structs/structpath"
+)
+
+// readLifecycle reads the lifecycle block of the resource at node from the configuration.
+// Nodes without a lifecycle block (e.g. permissions and grants) return a zero value.
+func readLifecycle(root dyn.Value, node string, stateType reflect.Type) (resources.Lifecycle, error) {
+	var lifecycle resources.Lifecycle
+
+	path, err := dyn.NewPathFromString(node)
+	if err != nil {
+		return lifecycle, err
+	}
+
+	v, err := dyn.GetByPath(root, path.Append(dyn.Key("lifecycle")))
+	if dyn.IsNoSuchKeyError(err) {
+		return lifecycle, nil
+	}
+	if err != nil {
+		return lifecycle, err
+	}
+
+	err = convert.ToTyped(&lifecycle, v)
+	if err != nil {
+		return lifecycle, err
+	}
+
+	// Patterns are validated against the state type so that typos are reported at plan
+	// time instead of silently never matching.
+	for _, field := range lifecycle.IgnoreChanges {
+		if _, err := parseIgnoreChange(stateType, field); err != nil {
+			return lifecycle, fmt.Errorf("invalid lifecycle.ignore_changes entry %q: %w", field, err)
+		}
+	}
+
+	return lifecycle, nil
+}
+
+func parseIgnoreChange(stateType reflect.Type, field string) (*structpath.PatternNode, error) {
+	pattern, err := structpath.ParsePattern(field)
+	if err != nil {
+		return nil, err
+	}
+	err = structaccess.ValidatePattern(stateType, pattern)
+	if err != nil {
+		return nil, err
+	}
+	return pattern, nil
+}
+
+// parseIgnoreChanges parses the lifecycle.ignore_changes entries stored in a plan entry.
+func parseIgnoreChanges(adapter *dresources.Adapter, fields []string) ([]*structpath.PatternNode, error) {
+	var patterns []*structpath.PatternNode
+	for _, field := range fields {
+		pattern, err := parseIgnoreChange(adapter.StateType(), field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lifecycle.ignore_changes entry %q: %w", field, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+func matchesIgnoreChanges(path *structpath.PathNode, patterns []*structpath.PatternNode) bool {
+	for _, pattern := range patterns {
+		if path.HasPatternPrefix(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// keepIgnoredFields sets the fields skipped because of lifecycle.ignore_changes in newState
+// to their remote value, so that updating the resource for other reasons does not revert
+// changes made outside the bundle. Fields not returned by the API keep their last deployed value.
+func keepIgnoredFields(adapter *dresources.Adapter, changes deployplan.Changes, newState any) error {
+	for pathString, ch := range changes {
+		if ch.Reason != deployplan.ReasonIgnoreChanges {
+			continue
+		}
+
+		path, err := structpath.ParsePath(pathString)
+		if err != nil {
+			return err
+		}
+
+		value := ch.Remote
+		if isFieldMissingInRemote(adapter, path) {
+			value = ch.Old
+		}
+
+		err = structaccess.Set(newState, path, value)
+		if err != nil {
+			return fmt.Errorf("keeping ignored field %s: %w", pathString, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package direct
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/databricks/cli/libs/log"
+)
+
+// applyMoved renames the resources listed in moved blocks in the state, so that
+// they are planned under their new key instead of being deleted and recreated.
+// Sub-resources (permissions and grants) are renamed along with their resource.
+//
+// The state is only renamed in memory; applying the plan records the renames.
+// It returns the previous key of every renamed state entry, keyed by its new key.
+func (b *DeploymentBundle) applyMoved(ctx context.Context, configRoot *config.Root) (map[string]string, error) {
+	result := make(map[string]string)
+	state := b.StateDB.Data.State
+
+	for _, m := range configRoot.Moved {
+		_, hasFrom := state[m.From]
+		_, hasTo := state[m.To]
+
+		switch {
+		case hasFrom && hasTo:
+			return nil, fmt.Errorf("cannot move %s to %s: both are present in the deployment state", m.From, m.To)
+		case !hasFrom && hasTo:
+			log.Debugf(ctx, "Resource %s was already moved to %s", m.From, m.To)
+			continue
+		case !hasFrom && len(state) == 0:
+			// Nothing was deployed yet, so there is nothing to move.
+			continue
+		case !hasFrom:
+			return nil, fmt.Errorf("cannot move %s to %s: %s is not present in the deployment state", m.From, m.To, m.From)
+		}
+
+		moved, err := b.StateDB.MoveResource(m.From, m.To)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(result, moved)
+	}
+
+	return result, nil
+}
+
+// applyMovedState records the renames of resources planned with a moved block
+// in the state. The state must be open for write.
+func (b *DeploymentBundle) applyMovedState(plan *deployplan.Plan) error {
+	for _, key := range slices.Sorted(maps.Keys(plan.Plan)) {
+		from := plan.Plan[key].MovedFrom
+		if from == "" {
+			continue
+		}
+		err := b.StateDB.Move(from, key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
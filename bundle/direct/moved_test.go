@@ -0,0 +1,79 @@
+package direct
+
+import (
+	"testing"
+
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/databricks/cli/bundle/direct/dstate"
+	"github.com/databricks/cli/libs/logdiag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyMoved(t *testing.T) {
+	ctx := logdiag.InitContext(t.Context())
+	path := writeTestState(t)
+
+	var b DeploymentBundle
+	require.NoError(t, b.StateDB.Open(ctx, path, dstate.WithRecovery(true), dstate.WithWrite(false)))
+
+	moved, err := b.applyMoved(ctx, &config.Root{
+		Moved: []config.Moved{
+			{From: "resources.jobs.foo", To: "resources.jobs.baz"},
+			// Already moved: the new key is in the state.
+			{From: "resources.jobs.old", To: "resources.jobs.foobar"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"resources.jobs.baz":             "resources.jobs.foo",
+		"resources.jobs.baz.permissions": "resources.jobs.foo.permissions",
+	}, moved)
+	assert.Equal(t, "1", b.StateDB.GetResourceID("resources.jobs.baz"))
+	assert.False(t, logdiag.HasError(ctx))
+
+	// Applying the plan persists the renames.
+	plan := deployplan.NewPlanDirect()
+	for key, from := range moved {
+		plan.Plan[key] = &deployplan.PlanEntry{Action: deployplan.Skip, MovedFrom: from}
+	}
+	require.NoError(t, b.StateDB.UpgradeToWrite())
+	require.NoError(t, b.applyMovedState(plan))
+	_, err = b.StateDB.Finalize(ctx)
+	require.NoError(t, err)
+
+	state, err := b.ReadState(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, "1", state["resources.jobs.baz"].ID)
+	assert.Equal(t, "/jobs/1", state["resources.jobs.baz.permissions"].ID)
+	assert.NotContains(t, state, "resources.jobs.foo")
+	assert.NotContains(t, state, "resources.jobs.foo.permissions")
+	assert.Equal(t, "resources.jobs.baz", state["resources.pipelines.bar"].DependsOn[0].Node)
+}
+
+func TestApplyMovedConflict(t *testing.T) {
+	ctx := logdiag.InitContext(t.Context())
+	path := writeTestState(t)
+
+	var b DeploymentBundle
+	require.NoError(t, b.StateDB.Open(ctx, path, dstate.WithRecovery(true), dstate.WithWrite(false)))
+
+	_, err := b.applyMoved(ctx, &config.Root{
+		Moved: []config.Moved{{From: "resources.jobs.foo", To: "resources.jobs.foobar"}},
+	})
+	assert.EqualError(t, err, "cannot move resources.jobs.foo to resources.jobs.foobar: both are present in the deployment state")
+}
+
+func TestApplyMovedMissing(t *testing.T) {
+	ctx := logdiag.InitContext(t.Context())
+	path := writeTestState(t)
+
+	var b DeploymentBundle
+	require.NoError(t, b.StateDB.Open(ctx, path, dstate.WithRecovery(true), dstate.WithWrite(false)))
+
+	_, err := b.applyMoved(ctx, &config.Root{
+		Moved: []config.Moved{{From: "resources.jobs.old", To: "resources.jobs.new"}},
+	})
+	assert.EqualError(t, err, "cannot move resources.jobs.old to resources.jobs.new: resources.jobs.old is not present in the deployment state")
+}
@@ -0,0 +1,86 @@
+package direct
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/databricks/cli/bundle/deployplan"
+)
+
+type OutcomeStatus string
+
+const (
+	// OutcomeApplied is a resource whose planned action succeeded.
+	OutcomeApplied OutcomeStatus = "applied"
+
+	// OutcomeFailed is a resource whose planned action returned an error.
+	OutcomeFailed OutcomeStatus = "failed"
+
+	// OutcomeSkipped is a resource that was not attempted, either because a resource it
+	// depends on failed or because the deployment stopped after a failure.
+	OutcomeSkipped OutcomeStatus = "skipped"
+)
+
+// Outcome is the result of applying a single plan entry.
+type Outcome struct {
+	ResourceKey string
+	Action      deployplan.ActionType
+	Status      OutcomeStatus
+
+	// FailedDependency is the failed resource a skipped resource depends on. It is empty
+	// for resources skipped because the deployment stopped after a failure.
+	FailedDependency string
+}
+
+// Outcomes collects the outcome of every resource that Apply attempted or skipped.
+// Resources with nothing to do are only recorded if they fail.
+type Outcomes struct {
+	mu     sync.Mutex
+	byKey  map[string]Outcome
+	failed bool
+}
+
+func (o *Outcomes) reset() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.byKey = make(map[string]Outcome)
+	o.failed = false
+}
+
+func (o *Outcomes) record(outcome Outcome) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.byKey == nil {
+		o.byKey = make(map[string]Outcome)
+	}
+	o.byKey[outcome.ResourceKey] = outcome
+	if outcome.Status == OutcomeFailed {
+		o.failed = true
+	}
+}
+
+func (o *Outcomes) status(resourceKey string) OutcomeStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.byKey[resourceKey].Status
+}
+
+// HasFailures reports whether any resource failed.
+func (o *Outcomes) HasFailures() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.failed
+}
+
+// List returns the recorded outcomes ordered by resource key.
+func (o *Outcomes) List() []Outcome {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	result := make([]Outcome, 0, len(o.byKey))
+	for _, key := range slices.Sorted(maps.Keys(o.byKey)) {
+		result = append(result, o.byKey[key])
+	}
+	return result
+}
@@ -50,6 +50,14 @@ type DeploymentBundle struct {
 	Plan             *deployplan.Plan
 	RemoteStateCache sync.Map
 	StateCache       structvar.Cache
+
+	// StopOnError makes Apply stop starting resources after one fails. Resources that are
+	// already in progress are completed. If unset, resources that do not depend on the
+	// failed resource are still applied.
+	StopOnError bool
+
+	// Outcomes holds the result of the last Apply for each resource.
+	Outcomes Outcomes
 }
 
 // SetRemoteState updates the remote state with type validation and marks as fresh.
@@ -0,0 +1,109 @@
+package direct
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle/direct/dstate"
+	"github.com/databricks/cli/libs/log"
+)
+
+// resourceGroup returns the resource group of a key of the form resources.<group>.<name>.
+// It returns false for sub-resource keys such as "resources.jobs.foo.permissions".
+func resourceGroup(key string) (string, bool) {
+	parts := strings.Split(key, ".")
+	if len(parts) != 3 || parts[0] != "resources" || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// ReadState returns the resources recorded in the direct engine state.
+func (b *DeploymentBundle) ReadState(ctx context.Context, statePath string) (map[string]dstate.ResourceEntry, error) {
+	err := b.StateDB.Open(ctx, statePath, dstate.WithRecovery(true), dstate.WithWrite(false))
+	if err != nil {
+		return nil, err
+	}
+
+	state := b.StateDB.Data.State
+	_, err = b.StateDB.Finalize(ctx)
+	return state, err
+}
+
+// MoveState renames a resource in the direct engine state without touching the
+// workspace resource, so that the next deployment updates it under its new key
+// instead of deleting and recreating it. Sub-resources of the resource (such as
+// permissions and grants) are moved along with it, and dependencies of other
+// resources on it are updated.
+func (b *DeploymentBundle) MoveState(ctx context.Context, statePath, from, to string) error {
+	fromGroup, ok := resourceGroup(from)
+	if !ok {
+		return fmt.Errorf("cannot move %s: expected a key of the form resources.<group>.<name>", from)
+	}
+	toGroup, ok := resourceGroup(to)
+	if !ok {
+		return fmt.Errorf("cannot move to %s: expected a key of the form resources.<group>.<name>", to)
+	}
+	if fromGroup != toGroup {
+		return fmt.Errorf("cannot move %s to %s: resources cannot be moved to a different resource group", from, to)
+	}
+
+	err := b.StateDB.Open(ctx, statePath, dstate.WithRecovery(true), dstate.WithWrite(true))
+	if err != nil {
+		return err
+	}
+
+	state := b.StateDB.Data.State
+	if _, ok := state[from]; !ok {
+		_, _ = b.StateDB.Finalize(ctx)
+		return fmt.Errorf("resource %s not found in the deployment state", from)
+	}
+	if _, ok := state[to]; ok {
+		_, _ = b.StateDB.Finalize(ctx)
+		return fmt.Errorf("resource %s already exists in the deployment state", to)
+	}
+
+	moved, err := b.StateDB.MoveResource(from, to)
+	if err != nil {
+		return err
+	}
+	for _, newKey := range slices.Sorted(maps.Keys(moved)) {
+		log.Infof(ctx, "Moved %s to %s", moved[newKey], newKey)
+	}
+
+	_, err = b.StateDB.Finalize(ctx)
+	return err
+}
+
+// RemoveState removes a resource and its sub-resources from the direct engine
+// state without deleting the workspace resource. Unlike [DeploymentBundle.Unbind],
+// it fails if the resource is not present in the state.
+func (b *DeploymentBundle) RemoveState(ctx context.Context, statePath, key string) error {
+	err := b.StateDB.Open(ctx, statePath, dstate.WithRecovery(true), dstate.WithWrite(true))
+	if err != nil {
+		return err
+	}
+
+	state := b.StateDB.Data.State
+	if _, ok := state[key]; !ok {
+		_, _ = b.StateDB.Finalize(ctx)
+		return fmt.Errorf("resource %s not found in the deployment state", key)
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(state)) {
+		if k != key && !strings.HasPrefix(k, key+".") {
+			continue
+		}
+		err = b.StateDB.DeleteState(k)
+		if err != nil {
+			return err
+		}
+		log.Infof(ctx, "Removed %s", k)
+	}
+
+	_, err = b.StateDB.Finalize(ctx)
+	return err
+}
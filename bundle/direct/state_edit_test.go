@@ -0,0 +1,99 @@
+package direct
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/databricks/cli/bundle/direct/dstate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestState(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "resources.json")
+
+	var db dstate.DeploymentState
+	require.NoError(t, db.Open(t.Context(), path, dstate.WithRecovery(true), dstate.WithWrite(true)))
+	require.NoError(t, db.SaveState("resources.jobs.foo", "1", map[string]string{"name": "foo"}, nil))
+	require.NoError(t, db.SaveState("resources.jobs.foo.permissions", "/jobs/1", map[string]string{}, nil))
+	require.NoError(t, db.SaveState("resources.jobs.foobar", "2", map[string]string{"name": "foobar"}, nil))
+	require.NoError(t, db.SaveState("resources.pipelines.bar", "3", map[string]string{}, []deployplan.DependsOnEntry{
+		{Node: "resources.jobs.foo", Label: "${resources.jobs.foo.id}"},
+	}))
+	_, err := db.Finalize(t.Context())
+	require.NoError(t, err)
+	return path
+}
+
+func TestMoveState(t *testing.T) {
+	path := writeTestState(t)
+
+	var b DeploymentBundle
+	require.NoError(t, b.MoveState(t.Context(), path, "resources.jobs.foo", "resources.jobs.baz"))
+
+	state, err := b.ReadState(t.Context(), path)
+	require.NoError(t, err)
+
+	assert.NotContains(t, state, "resources.jobs.foo")
+	assert.NotContains(t, state, "resources.jobs.foo.permissions")
+	assert.Equal(t, "1", state["resources.jobs.baz"].ID)
+	assert.JSONEq(t, `{"name": "foo"}`, string(state["resources.jobs.baz"].State))
+	assert.Equal(t, "/jobs/1", state["resources.jobs.baz.permissions"].ID)
+	assert.Equal(t, "2", state["resources.jobs.foobar"].ID)
+	assert.Equal(t, []deployplan.DependsOnEntry{
+		{Node: "resources.jobs.baz", Label: "${resources.jobs.foo.id}"},
+	}, state["resources.pipelines.bar"].DependsOn)
+
+	var db dstate.DeploymentState
+	require.NoError(t, db.Open(t.Context(), path, dstate.WithRecovery(false), dstate.WithWrite(false)))
+	assert.Equal(t, 2, db.Data.Serial)
+	_, err = db.Finalize(t.Context())
+	require.NoError(t, err)
+}
+
+func TestMoveStateErrors(t *testing.T) {
+	path := writeTestState(t)
+
+	var b DeploymentBundle
+	err := b.MoveState(t.Context(), path, "resources.jobs.missing", "resources.jobs.baz")
+	assert.EqualError(t, err, "resource resources.jobs.missing not found in the deployment state")
+
+	err = b.MoveState(t.Context(), path, "resources.jobs.foo", "resources.jobs.foobar")
+	assert.EqualError(t, err, "resource resources.jobs.foobar already exists in the deployment state")
+
+	err = b.MoveState(t.Context(), path, "resources.jobs.foo", "resources.pipelines.foo")
+	assert.EqualError(t, err, "cannot move resources.jobs.foo to resources.pipelines.foo: resources cannot be moved to a different resource group")
+
+	err = b.MoveState(t.Context(), path, "resources.jobs.foo.permissions", "resources.jobs.baz.permissions")
+	assert.EqualError(t, err, "cannot move resources.jobs.foo.permissions: expected a key of the form resources.<group>.<name>")
+
+	err = b.MoveState(t.Context(), path, "resources.jobs.foo", "resources.jobs.baz.permissions")
+	assert.EqualError(t, err, "cannot move to resources.jobs.baz.permissions: expected a key of the form resources.<group>.<name>")
+
+	// Failed moves leave the state untouched.
+	var db dstate.DeploymentState
+	require.NoError(t, db.Open(t.Context(), path, dstate.WithRecovery(false), dstate.WithWrite(false)))
+	assert.Equal(t, 1, db.Data.Serial)
+	assert.Len(t, db.Data.State, 4)
+	_, err = db.Finalize(t.Context())
+	require.NoError(t, err)
+}
+
+func TestRemoveState(t *testing.T) {
+	path := writeTestState(t)
+
+	var b DeploymentBundle
+	require.NoError(t, b.RemoveState(t.Context(), path, "resources.jobs.foo"))
+
+	state, err := b.ReadState(t.Context(), path)
+	require.NoError(t, err)
+	assert.NotContains(t, state, "resources.jobs.foo")
+	assert.NotContains(t, state, "resources.jobs.foo.permissions")
+	assert.Contains(t, state, "resources.jobs.foobar")
+	assert.Contains(t, state, "resources.pipelines.bar")
+
+	err = b.RemoveState(t.Context(), path, "resources.jobs.foo")
+	assert.EqualError(t, err, "resource resources.jobs.foo not found in the deployment state")
+}
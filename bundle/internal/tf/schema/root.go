@@ -11,12 +11,18 @@ func NewProviders() *Providers {
 	}
 }
 
+type Moved struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
 type Root struct {
 	Terraform map[string]any `json:"terraform"`
 
 	Provider *Providers   `json:"provider,omitempty"`
 	Data     *DataSources `json:"data,omitempty"`
 	Resource *Resources   `json:"resource,omitempty"`
+	Moved    []Moved      `json:"moved,omitempty"`
 }
 
 const (
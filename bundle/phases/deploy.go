@@ -19,6 +19,7 @@ import (
 	"github.com/databricks/cli/bundle/deploy/snapshot"
 	"github.com/databricks/cli/bundle/deploy/terraform"
 	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/databricks/cli/bundle/direct"
 	"github.com/databricks/cli/bundle/libraries"
 	"github.com/databricks/cli/bundle/metrics"
 	"github.com/databricks/cli/bundle/permissions"
@@ -90,7 +91,23 @@ func deployCore(ctx context.Context, b *bundle.Bundle, plan *deployplan.Plan, st
 	// should report the engine that ran.
 	b.Metrics.StateEngine = stateEngine.ThisOrDefault()
 	if stateEngine.IsDirect() {
+		// Resources that are not applied are recorded in the state for "bundle deploy --retry-failed".
+		// They are recorded before applying, so an interrupted deployment can be retried too.
+		if err := direct.RecordPendingResources(&b.DeploymentBundle.StateDB, plan); err != nil {
+			log.Warnf(ctx, "Failed to record pending resources: %v", err)
+		}
+
+		b.DeploymentBundle.StopOnError = b.StopOnError
 		b.DeploymentBundle.Apply(ctx, b.WorkspaceClient(ctx), plan)
+
+		outcomes := b.DeploymentBundle.Outcomes.List()
+		if err := direct.RecordFailedResources(&b.DeploymentBundle.StateDB, plan, outcomes); err != nil {
+			log.Warnf(ctx, "Failed to record failed resources: %v", err)
+		}
+		if b.DeploymentBundle.Outcomes.HasFailures() {
+			logApplyOutcomes(ctx, b, outcomes)
+		}
+
 		state, err = b.DeploymentBundle.StateDB.Finalize(ctx)
 		// Capture the finalized state for deploy telemetry. It carries each
 		// resource's state-size in bytes (from the WAL replay Finalize just
@@ -117,6 +134,11 @@ func deployCore(ctx context.Context, b *bundle.Bundle, plan *deployplan.Plan, st
 		statemgmt.UploadStateForYamlSync(stateEngine),
 	)
 
+	// Snapshots of direct engine deployments are kept for "bundle deployment rollback".
+	if stateEngine.IsDirect() && !logdiag.HasError(ctx) {
+		bundle.ApplyContext(ctx, b, deploy.SaveDeploymentSnapshot())
+	}
+
 	// Once the deploy is complete, dry-run the migration to the direct engine
 	// and record the outcome in telemetry. If the user has opted in to the
 	// direct engine (via bundle.engine or DATABRICKS_BUNDLE_ENGINE) and the
@@ -139,6 +161,43 @@ func logFileSummary(ctx context.Context, b *bundle.Bundle) {
 	cmdio.LogString(ctx, fmt.Sprintf("Files: %d uploaded, %d deleted", b.FileCounts.Uploaded, b.FileCounts.Deleted))
 }
 
+// logApplyOutcomes lists the resources that were applied, failed and skipped by a direct
+// engine deployment in which some resource failed. The errors themselves are reported as
+// they happen; this summary shows what the deployment left behind.
+func logApplyOutcomes(ctx context.Context, b *bundle.Bundle, outcomes []direct.Outcome) {
+	if b.Quiet >= bundle.QuietAll {
+		return
+	}
+
+	cmdio.LogString(ctx, "Deployment summary:")
+	for _, group := range []struct {
+		status direct.OutcomeStatus
+		label  string
+	}{
+		{direct.OutcomeApplied, "Applied:"},
+		{direct.OutcomeFailed, "Failed:"},
+		{direct.OutcomeSkipped, "Skipped:"},
+	} {
+		for _, o := range outcomes {
+			if o.Status != group.status {
+				continue
+			}
+			line := fmt.Sprintf("  %-9s%s", group.label, strings.TrimPrefix(o.ResourceKey, "resources."))
+			if o.Action != deployplan.Skip && o.Action != deployplan.Undefined {
+				line += " (" + o.Action.StringShort() + ")"
+			}
+			if o.Status == direct.OutcomeSkipped {
+				if o.FailedDependency != "" {
+					line += ", depends on " + strings.TrimPrefix(o.FailedDependency, "resources.")
+				} else {
+					line += ", not started after a failure"
+				}
+			}
+			cmdio.LogString(ctx, line)
+		}
+	}
+}
+
 // logDeploySummary prints the per-resource actions that were applied followed by the
 // resource summary line. -q drops the per-resource lines, -qq drops the summary too.
 // The past-tense verb is the short action name plus "d" (create→Created,
@@ -152,6 +211,9 @@ func logDeploySummary(ctx context.Context, b *bundle.Bundle, plan *deployplan.Pl
 
 	if b.Quiet < bundle.QuietSummary {
 		for _, action := range plan.GetActions() {
+			if action.MovedFrom != "" && !action.IsChildResource() {
+				cmdio.LogString(ctx, "Moved "+strings.TrimPrefix(action.MovedFrom, "resources.")+" to "+strings.TrimPrefix(action.ResourceKey, "resources."))
+			}
 			if action.ActionType == deployplan.Skip || action.ActionType == deployplan.Undefined {
 				continue
 			}
@@ -191,6 +253,24 @@ func uploadLibraries(ctx context.Context, b *bundle.Bundle, libs map[string][]li
 func Deploy(ctx context.Context, b *bundle.Bundle, outputHandler sync.OutputHandler, stateEngine engine.EngineType, requestedEngine engine.EngineSetting, libs map[string][]libraries.LocationToUpdate, plan *deployplan.Plan) {
 	log.Info(ctx, "Phase: deploy")
 
+	if !stateEngine.IsDirect() && (b.StopOnError || b.RetryFailed) {
+		logdiag.LogError(ctx, errors.New("--stop-on-error and --retry-failed are only supported with the direct engine. See https://docs.databricks.com/aws/en/dev-tools/bundles/direct"))
+		return
+	}
+
+	if b.RetryFailed {
+		failed := direct.FailedResources(&b.DeploymentBundle.StateDB)
+		if len(failed) == 0 {
+			logdiag.LogError(ctx, errors.New("no failed resources to retry: the last deployment completed all resources"))
+			return
+		}
+		log.Infof(ctx, "Retrying failed resources: %s", strings.Join(failed, ", "))
+		// The selectors are plan keys without the "resources." prefix, like the ones
+		// ResolveSelect produces. They are not resolved against the configuration,
+		// because failed deletes refer to resources that are no longer configured.
+		b.Select = failed
+	}
+
 	// Core mutators that CRUD resources and modify deployment state. These
 	// mutators need informed consent if they are potentially destructive.
 	bundle.ApplySeqContext(ctx, b,
@@ -208,6 +288,15 @@ func Deploy(ctx context.Context, b *bundle.Bundle, outputHandler sync.OutputHand
 		bundle.ApplyContext(ctx, b, lock.Release(lock.GoalDeploy))
 	}()
 
+	// Fail before changing anything if another deployment wrote the state
+	// between pulling it and acquiring the lock.
+	if stateEngine.IsDirect() {
+		if err := statemgmt.CheckRemoteState(ctx, b); err != nil {
+			logdiag.LogError(ctx, err)
+			return
+		}
+	}
+
 	immutable := b.IsImmutableFolder()
 	if immutable && !stateEngine.IsDirect() {
 		logdiag.LogError(ctx, errors.New("experimental.immutable_folder is only supported with the direct deployment engine"))
@@ -316,6 +405,10 @@ func Deploy(ctx context.Context, b *bundle.Bundle, outputHandler sync.OutputHand
 		return
 	}
 
+	if stateEngine.IsDirect() && b.DeploymentBundle.Outcomes.HasFailures() && b.Quiet < bundle.QuietAll {
+		cmdio.LogString(ctx, `To retry the resources that were not deployed, run "databricks bundle deploy --retry-failed".`)
+	}
+
 	if logdiag.HasError(ctx) {
 		return
 	}
@@ -8,12 +8,15 @@ import (
 	"slices"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/config/engine"
 	"github.com/databricks/cli/bundle/config/mutator"
 	"github.com/databricks/cli/bundle/deploy/files"
 	"github.com/databricks/cli/bundle/deploy/lock"
 	"github.com/databricks/cli/bundle/deploy/terraform"
 	"github.com/databricks/cli/bundle/deployplan"
+	"github.com/databricks/cli/bundle/scripts"
+	"github.com/databricks/cli/bundle/statemgmt"
 	"github.com/databricks/cli/libs/cmdio"
 	"github.com/databricks/cli/libs/diag"
 	"github.com/databricks/cli/libs/log"
@@ -160,6 +163,14 @@ func destroyCore(ctx context.Context, b *bundle.Bundle, plan *deployplan.Plan, e
 
 	bundle.ApplyContext(ctx, b, files.Delete())
 
+	// The remote state is stored outside of the deployment root if
+	// bundle.deployment.state_path is set, so it is not deleted along with it.
+	if engine.IsDirect() && !logdiag.HasError(ctx) {
+		if err := statemgmt.DeleteRemoteState(ctx, b); err != nil {
+			logdiag.LogError(ctx, fmt.Errorf("deleting deployment state: %w", err))
+		}
+	}
+
 	if !logdiag.HasError(ctx) && b.Quiet < bundle.QuietAll {
 		// Count top-level resources only, matching the approval list above (which
 		// skips children); this also keeps the count stable across engines. Gone
@@ -200,6 +211,13 @@ func Destroy(ctx context.Context, b *bundle.Bundle, engine engine.EngineType) {
 		bundle.ApplyContext(ctx, b, lock.Release(lock.GoalDestroy))
 	}()
 
+	if engine.IsDirect() {
+		if err := statemgmt.CheckRemoteState(ctx, b); err != nil {
+			logdiag.LogError(ctx, err)
+			return
+		}
+	}
+
 	if !engine.IsDirect() {
 		bundle.ApplySeqContext(ctx, b,
 			// We need to resolve artifact variable (how we do it in build phase)
@@ -246,6 +264,13 @@ func Destroy(ctx context.Context, b *bundle.Bundle, engine engine.EngineType) {
 	}
 
 	if hasApproval {
+		// Run predestroy only once the destroy is approved, since it may
+		// tear down resources outside of the bundle.
+		bundle.ApplyContext(ctx, b, scripts.Execute(config.ScriptPreDestroy))
+		if logdiag.HasError(ctx) {
+			return
+		}
+
 		if engine.IsDirect() {
 			// Upgrade from read (opened by process.go) to write mode
 			if err := b.DeploymentBundle.StateDB.UpgradeToWrite(); err != nil {
@@ -254,6 +279,11 @@ func Destroy(ctx context.Context, b *bundle.Bundle, engine engine.EngineType) {
 			}
 		}
 		destroyCore(ctx, b, plan, engine)
+		if logdiag.HasError(ctx) {
+			return
+		}
+
+		bundle.ApplyContext(ctx, b, scripts.Execute(config.ScriptPostDestroy))
 	} else {
 		cmdio.LogString(ctx, "Destroy cancelled!")
 	}
@@ -28,9 +28,11 @@ func PreDeployChecks(ctx context.Context, b *bundle.Bundle, isPlan bool, engine
 		mutator.ValidateGitDetails(),
 		mutator.ValidateDirectOnlyResources(engine),
 		mutator.ValidateLifecycleStarted(engine),
+		mutator.ValidateLifecycleIgnoreChanges(engine),
 		mutator.ValidateCascadeOnDestroy(engine),
 		mutator.ValidateJobRunTriggers(),
 		statemgmt.CheckRunningResource(engine),
+		statemgmt.CheckMoved(engine),
 	)
 }
 
@@ -0,0 +1,66 @@
+package phases
+
+import (
+	"context"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/engine"
+	"github.com/databricks/cli/bundle/deploy/lock"
+	"github.com/databricks/cli/bundle/statemgmt"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/cli/libs/logdiag"
+)
+
+// Rollback applies the resources of a previous deployment through the regular plan and
+// approval flow. The resources in the configuration of b must already be replaced with
+// those of the deployment (see deploy.DeploymentSnapshot.Restore).
+//
+// Only resources are rolled back: files and artifacts are not uploaded again.
+func Rollback(ctx context.Context, b *bundle.Bundle, stateEngine engine.EngineType) {
+	log.Info(ctx, "Phase: rollback")
+
+	bundle.ApplyContext(ctx, b, lock.Acquire(lock.GoalDeploy))
+	if logdiag.HasError(ctx) {
+		// lock is not acquired here
+		return
+	}
+
+	// lock is acquired here
+	defer func() {
+		bundle.ApplyContext(ctx, b, lock.Release(lock.GoalDeploy))
+	}()
+
+	if err := statemgmt.CheckRemoteState(ctx, b); err != nil {
+		logdiag.LogError(ctx, err)
+		return
+	}
+
+	plan := RunPlan(ctx, b, stateEngine)
+	if logdiag.HasError(ctx) {
+		return
+	}
+
+	// Upgrade from read (opened by process.go) to write mode
+	if err := b.DeploymentBundle.StateDB.UpgradeToWrite(); err != nil {
+		logdiag.LogError(ctx, err)
+		return
+	}
+
+	haveApproval, err := approvalForDeploy(ctx, b, plan)
+	if err != nil {
+		logdiag.LogError(ctx, err)
+		return
+	}
+	if !haveApproval {
+		cmdio.LogString(ctx, "Rollback cancelled!")
+		return
+	}
+
+	deployCore(ctx, b, plan, stateEngine, engine.EngineSetting{})
+	if logdiag.HasError(ctx) {
+		return
+	}
+
+	logDeploySummary(ctx, b, plan)
+}
@@ -0,0 +1,71 @@
+package phases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/engine"
+	"github.com/databricks/cli/bundle/deploy/lock"
+	"github.com/databricks/cli/bundle/statemgmt"
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/cli/libs/logdiag"
+)
+
+var errStateEditTerraform = errors.New("editing the deployment state is only supported by the direct deployment engine; use 'databricks bundle deployment bind' and 'unbind' instead")
+
+// StateMove renames a resource in the deployment state from one key to another.
+func StateMove(ctx context.Context, b *bundle.Bundle, from, to string, engine engine.EngineType) {
+	log.Info(ctx, "Phase: state mv")
+
+	editState(ctx, b, engine, func(statePath string) error {
+		return b.DeploymentBundle.MoveState(ctx, statePath, from, to)
+	})
+}
+
+// StateRemove removes a resource from the deployment state without deleting it in the workspace.
+func StateRemove(ctx context.Context, b *bundle.Bundle, key string, engine engine.EngineType) {
+	log.Info(ctx, "Phase: state rm")
+
+	editState(ctx, b, engine, func(statePath string) error {
+		return b.DeploymentBundle.RemoveState(ctx, statePath, key)
+	})
+}
+
+// editState applies edit to the local direct engine state under the deployment
+// lock and pushes the result to the remote state location.
+func editState(ctx context.Context, b *bundle.Bundle, engineType engine.EngineType, edit func(statePath string) error) {
+	if !engineType.IsDirect() {
+		logdiag.LogError(ctx, errStateEditTerraform)
+		return
+	}
+
+	bundle.ApplyContext(ctx, b, lock.Acquire(lock.GoalStateEdit))
+	if logdiag.HasError(ctx) {
+		return
+	}
+
+	defer func() {
+		bundle.ApplyContext(ctx, b, lock.Release(lock.GoalStateEdit))
+	}()
+
+	// Pull the state only once the lock is held, so that the edit cannot be
+	// based on a state that a concurrent deployment is about to replace.
+	_, stateDesc := statemgmt.PullResourcesState(ctx, b, statemgmt.AlwaysPull(true), engine.EngineSetting{Type: engineType})
+	if logdiag.HasError(ctx) {
+		return
+	}
+	if !stateDesc.Engine.IsDirect() {
+		logdiag.LogError(ctx, errStateEditTerraform)
+		return
+	}
+
+	_, statePath := b.StateFilenameDirect(ctx)
+	err := edit(statePath)
+	if err != nil {
+		logdiag.LogError(ctx, err)
+		return
+	}
+
+	statemgmt.PushResourcesState(ctx, b, stateDesc.Engine)
+}
@@ -0,0 +1,65 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/run/output"
+	"github.com/databricks/databricks-sdk-go/service/dashboards"
+)
+
+// dashboardRunner refreshes a dashboard by publishing its latest draft. The
+// published dashboard is recomputed against its warehouse on publication.
+type dashboardRunner struct {
+	key
+	nopArgsHandler
+
+	bundle    *bundle.Bundle
+	dashboard *resources.Dashboard
+}
+
+func (r *dashboardRunner) Name() string {
+	if r.dashboard == nil {
+		return ""
+	}
+	return r.dashboard.DisplayName
+}
+
+func (r *dashboardRunner) Run(ctx context.Context, opts *Options) (output.RunOutput, error) {
+	dashboard := r.dashboard
+	if dashboard == nil {
+		return nil, errors.New("dashboard is not defined")
+	}
+	if dashboard.ID == "" {
+		return nil, fmt.Errorf("dashboard %s has not been deployed", r.Key())
+	}
+
+	w := r.bundle.WorkspaceClient(ctx)
+	logResourceProgress(ctx, r.Key(), "PUBLISHING", "")
+
+	// embed_credentials is false by default, so it must always be sent.
+	published, err := w.Lakeview.Publish(ctx, dashboards.PublishRequest{
+		DashboardId:      dashboard.ID,
+		EmbedCredentials: dashboard.EmbedCredentials,
+		WarehouseId:      dashboard.WarehouseId,
+		ForceSendFields:  []string{"EmbedCredentials"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logResourceProgress(ctx, r.Key(), "PUBLISHED", published.RevisionCreateTime)
+	return &output.DashboardOutput{DashboardId: dashboard.ID, URL: dashboard.URL}, nil
+}
+
+// Cancel is a no-op because publishing a dashboard is synchronous.
+func (r *dashboardRunner) Cancel(ctx context.Context) error {
+	return nil
+}
+
+func (r *dashboardRunner) Restart(ctx context.Context, opts *Options) (output.RunOutput, error) {
+	return r.Run(ctx, opts)
+}
@@ -154,6 +154,7 @@ func (r *jobRunner) Run(ctx context.Context, opts *Options) (output.RunOutput, e
 	if err != nil {
 		return nil, fmt.Errorf("cannot start job: %w", err)
 	}
+	opts.RunID = strconv.FormatInt(waiter.RunId, 10)
 
 	if opts.NoWait {
 		details, err := w.Jobs.GetRun(ctx, jobs.GetRunRequest{
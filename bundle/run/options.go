@@ -9,6 +9,13 @@ type Options struct {
 	Job      JobOptions
 	Pipeline PipelineOptions
 	NoWait   bool
+
+	// ServingEndpoint is set from positional arguments only.
+	ServingEndpoint ServingEndpointOptions
+
+	// RunID is set by the runner to the ID of the run it started, if the
+	// resource has one (e.g. the job run ID or pipeline update ID).
+	RunID string
 }
 
 func (o *Options) Define(cmd *cobra.Command) {
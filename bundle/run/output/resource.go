@@ -0,0 +1,58 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go/service/serving"
+)
+
+type DashboardOutput struct {
+	DashboardId string `json:"dashboard_id"`
+	URL         string `json:"url,omitempty"`
+}
+
+func (out *DashboardOutput) String() (string, error) {
+	if out.URL == "" {
+		return fmt.Sprintf("Dashboard ID: %s\n", out.DashboardId), nil
+	}
+	return fmt.Sprintf("Dashboard URL: %s\n", out.URL), nil
+}
+
+type QualityMonitorOutput struct {
+	RefreshId int64  `json:"refresh_id"`
+	State     string `json:"state,omitempty"`
+}
+
+func (out *QualityMonitorOutput) String() (string, error) {
+	return fmt.Sprintf("Refresh ID: %d\n", out.RefreshId), nil
+}
+
+type VectorSearchIndexOutput struct {
+	PipelineId      string `json:"pipeline_id"`
+	UpdateId        string `json:"update_id,omitempty"`
+	IndexedRowCount int64  `json:"indexed_row_count,omitempty"`
+}
+
+func (out *VectorSearchIndexOutput) String() (string, error) {
+	if out.UpdateId == "" {
+		return fmt.Sprintf("Pipeline ID: %s\n", out.PipelineId), nil
+	}
+	return fmt.Sprintf("Update ID: %s\nIndexed rows: %d\n", out.UpdateId, out.IndexedRowCount), nil
+}
+
+type ServingEndpointOutput struct {
+	State    string                         `json:"state"`
+	Response *serving.QueryEndpointResponse `json:"response,omitempty"`
+}
+
+func (out *ServingEndpointOutput) String() (string, error) {
+	if out.Response == nil {
+		return fmt.Sprintf("Endpoint state: %s\n", out.State), nil
+	}
+	b, err := json.MarshalIndent(out.Response, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
@@ -105,9 +105,7 @@ func (r *pipelineRunner) Run(ctx context.Context, opts *Options) (output.RunOutp
 	}
 
 	updateID := res.UpdateId
-
-	// setup progress logger and tracker to query events
-	updateTracker := progress.NewUpdateTracker(pipelineID, updateID, w)
+	opts.RunID = updateID
 
 	// Log the pipeline update URL as soon as it is available.
 	cmdio.Log(ctx, progress.NewPipelineUpdateUrlEvent(w.Config.Host, updateID, pipelineID))
@@ -118,13 +116,27 @@ func (r *pipelineRunner) Run(ctx context.Context, opts *Options) (output.RunOutp
 		}, nil
 	}
 
+	err = r.waitForUpdate(ctx, pipelineID, updateID)
+	if err != nil {
+		return nil, err
+	}
+	return &output.PipelineOutput{
+		UpdateId: updateID,
+	}, nil
+}
+
+// waitForUpdate polls a pipeline update for completion and logs its progress.
+func (r *pipelineRunner) waitForUpdate(ctx context.Context, pipelineID, updateID string) error {
+	w := r.bundle.WorkspaceClient(ctx)
+	updateTracker := progress.NewUpdateTracker(pipelineID, updateID, w)
+
 	// Poll update for completion and post status.
 	// Note: there is no "StartUpdateAndWait" wrapper for this API.
 	var prevState *pipelines.UpdateInfoState
 	for {
 		events, err := updateTracker.Events(ctx)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		for _, event := range events {
 			cmdio.Log(ctx, &event)
@@ -133,7 +145,7 @@ func (r *pipelineRunner) Run(ctx context.Context, opts *Options) (output.RunOutp
 
 		update, err := w.Pipelines.GetUpdateByPipelineIdAndUpdateId(ctx, pipelineID, updateID)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		// Log only if the current state is different from the previous state.
@@ -145,21 +157,19 @@ func (r *pipelineRunner) Run(ctx context.Context, opts *Options) (output.RunOutp
 
 		if state == pipelines.UpdateInfoStateCanceled {
 			log.Infof(ctx, "Update was cancelled!")
-			return nil, errors.New("update cancelled")
+			return errors.New("update cancelled")
 		}
 		if state == pipelines.UpdateInfoStateFailed {
 			log.Infof(ctx, "Update has failed!")
 			err := r.logErrorEvent(ctx, pipelineID, updateID)
 			if err != nil {
-				return nil, err
+				return err
 			}
-			return nil, errors.New("update failed")
+			return errors.New("update failed")
 		}
 		if state == pipelines.UpdateInfoStateCompleted {
 			log.Infof(ctx, "Update has completed successfully!")
-			return &output.PipelineOutput{
-				UpdateId: updateID,
-			}, nil
+			return nil
 		}
 
 		time.Sleep(time.Second)
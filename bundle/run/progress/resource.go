@@ -0,0 +1,37 @@
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ResourceProgressEvent is a state transition of a run of a resource that is
+// neither a job nor a pipeline, e.g. a quality monitor refresh or a vector
+// search index sync.
+type ResourceProgressEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Resource  string    `json:"resource"`
+	State     string    `json:"state"`
+	Message   string    `json:"message,omitempty"`
+}
+
+func NewResourceProgressEvent(resource, state, message string) *ResourceProgressEvent {
+	return &ResourceProgressEvent{
+		Timestamp: time.Now(),
+		Resource:  resource,
+		State:     state,
+		Message:   message,
+	}
+}
+
+func (event *ResourceProgressEvent) String() string {
+	result := strings.Builder{}
+	result.WriteString(event.Timestamp.Format("2006-01-02 15:04:05") + " ")
+	result.WriteString(fmt.Sprintf(`"%s"`, event.Resource) + " ")
+	result.WriteString(event.State)
+	if event.Message != "" {
+		result.WriteString(" " + event.Message)
+	}
+	return result.String()
+}
@@ -0,0 +1,21 @@
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceProgressEventString(t *testing.T) {
+	event := &ResourceProgressEvent{
+		Timestamp: time.Date(0, 0, 0, 0, 0, 0, 0, &time.Location{}),
+		Resource:  "quality_monitors.my_monitor",
+		State:     "RUNNING",
+		Message:   "message",
+	}
+	assert.Equal(t, "-0001-11-30 00:00:00 \"quality_monitors.my_monitor\" RUNNING message", event.String())
+
+	event.Message = ""
+	assert.Equal(t, "-0001-11-30 00:00:00 \"quality_monitors.my_monitor\" RUNNING", event.String())
+}
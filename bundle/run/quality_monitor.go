@@ -0,0 +1,143 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/run/output"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+// qualityMonitorRunner runs a refresh of a quality monitor, which recomputes
+// the metric tables of the monitored table.
+type qualityMonitorRunner struct {
+	key
+	nopArgsHandler
+
+	bundle  *bundle.Bundle
+	monitor *resources.QualityMonitor
+}
+
+func (r *qualityMonitorRunner) Name() string {
+	if r.monitor == nil {
+		return ""
+	}
+	return r.monitor.TableName
+}
+
+func isRefreshTerminal(state catalog.MonitorRefreshInfoState) bool {
+	switch state {
+	case catalog.MonitorRefreshInfoStateSuccess,
+		catalog.MonitorRefreshInfoStateFailed,
+		catalog.MonitorRefreshInfoStateCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+//nolint:staticcheck // Bundle quality_monitor still targets legacy monitor endpoints; v1 data-quality migration is separate work.
+func (r *qualityMonitorRunner) Run(ctx context.Context, opts *Options) (output.RunOutput, error) {
+	if r.monitor == nil {
+		return nil, errors.New("quality monitor is not defined")
+	}
+
+	tableName := r.monitor.TableName
+	ctx = log.NewContext(ctx, log.GetLogger(ctx).With("resource", r.Key()))
+	w := r.bundle.WorkspaceClient(ctx)
+
+	refresh, err := w.QualityMonitors.RunRefresh(ctx, catalog.RunRefreshRequest{
+		TableName: tableName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opts.RunID = strconv.FormatInt(refresh.RefreshId, 10)
+	logResourceProgress(ctx, r.Key(), string(refresh.State), refresh.Message)
+	if opts.NoWait {
+		return &output.QualityMonitorOutput{RefreshId: refresh.RefreshId, State: string(refresh.State)}, nil
+	}
+
+	// Poll the refresh for completion.
+	// Note: there is no "RunRefreshAndWait" wrapper for this API.
+	prevState := refresh.State
+	for !isRefreshTerminal(refresh.State) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(resourcePollInterval):
+		}
+
+		refresh, err = w.QualityMonitors.GetRefresh(ctx, catalog.GetRefreshRequest{
+			TableName: tableName,
+			RefreshId: refresh.RefreshId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Log only if the current state is different from the previous state.
+		if refresh.State != prevState {
+			logResourceProgress(ctx, r.Key(), string(refresh.State), refresh.Message)
+			prevState = refresh.State
+		}
+	}
+
+	switch refresh.State {
+	case catalog.MonitorRefreshInfoStateCanceled:
+		return nil, errors.New("refresh cancelled")
+	case catalog.MonitorRefreshInfoStateFailed:
+		return nil, fmt.Errorf("refresh failed: %s", refresh.Message)
+	}
+
+	return &output.QualityMonitorOutput{RefreshId: refresh.RefreshId, State: string(refresh.State)}, nil
+}
+
+//nolint:staticcheck // Bundle quality_monitor still targets legacy monitor endpoints; v1 data-quality migration is separate work.
+func (r *qualityMonitorRunner) Cancel(ctx context.Context) error {
+	if r.monitor == nil {
+		return errors.New("quality monitor is not defined")
+	}
+
+	w := r.bundle.WorkspaceClient(ctx)
+	refreshes, err := w.QualityMonitors.ListRefreshes(ctx, catalog.ListRefreshesRequest{
+		TableName: r.monitor.TableName,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, refresh := range refreshes.Refreshes {
+		if isRefreshTerminal(refresh.State) {
+			continue
+		}
+		err := w.QualityMonitors.CancelRefresh(ctx, catalog.CancelRefreshRequest{
+			TableName: r.monitor.TableName,
+			RefreshId: refresh.RefreshId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *qualityMonitorRunner) Restart(ctx context.Context, opts *Options) (output.RunOutput, error) {
+	sp := cmdio.NewSpinner(ctx)
+	sp.Update("Cancelling the active refreshes of the quality monitor")
+	err := r.Cancel(ctx)
+	sp.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Run(ctx, opts)
+}
@@ -0,0 +1,88 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/run/output"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// disableResourcePolling makes runners poll without waiting for the duration of the test.
+func disableResourcePolling(t *testing.T) {
+	prev := resourcePollInterval
+	resourcePollInterval = 0
+	t.Cleanup(func() { resourcePollInterval = prev })
+}
+
+func newTestQualityMonitorRunner(t *testing.T) (*qualityMonitorRunner, *mocks.MockWorkspaceClient) {
+	monitor := &resources.QualityMonitor{TableName: "main.default.t"}
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Resources: config.Resources{
+				QualityMonitors: map[string]*resources.QualityMonitor{
+					"my_monitor": monitor,
+				},
+			},
+		},
+	}
+
+	m := mocks.NewMockWorkspaceClient(t)
+	b.SetWorkpaceClient(m.WorkspaceClient)
+	disableResourcePolling(t)
+	return &qualityMonitorRunner{key: "quality_monitors.my_monitor", bundle: b, monitor: monitor}, m
+}
+
+func TestQualityMonitorRunWaitsForRefresh(t *testing.T) {
+	ctx := cmdio.MockDiscard(t.Context())
+	r, m := newTestQualityMonitorRunner(t)
+
+	api := m.GetMockQualityMonitorsAPI()
+	api.EXPECT().RunRefresh(mock.Anything, catalog.RunRefreshRequest{TableName: "main.default.t"}).
+		Return(&catalog.MonitorRefreshInfo{RefreshId: 1, State: catalog.MonitorRefreshInfoStatePending}, nil)
+	api.EXPECT().GetRefresh(mock.Anything, catalog.GetRefreshRequest{TableName: "main.default.t", RefreshId: 1}).
+		Return(&catalog.MonitorRefreshInfo{RefreshId: 1, State: catalog.MonitorRefreshInfoStateRunning}, nil).Once()
+	api.EXPECT().GetRefresh(mock.Anything, catalog.GetRefreshRequest{TableName: "main.default.t", RefreshId: 1}).
+		Return(&catalog.MonitorRefreshInfo{RefreshId: 1, State: catalog.MonitorRefreshInfoStateSuccess}, nil).Once()
+
+	out, err := r.Run(ctx, &Options{})
+	require.NoError(t, err)
+	assert.Equal(t, &output.QualityMonitorOutput{RefreshId: 1, State: "SUCCESS"}, out)
+}
+
+func TestQualityMonitorRunFailedRefresh(t *testing.T) {
+	ctx := cmdio.MockDiscard(t.Context())
+	r, m := newTestQualityMonitorRunner(t)
+
+	api := m.GetMockQualityMonitorsAPI()
+	api.EXPECT().RunRefresh(mock.Anything, catalog.RunRefreshRequest{TableName: "main.default.t"}).
+		Return(&catalog.MonitorRefreshInfo{RefreshId: 1, State: catalog.MonitorRefreshInfoStatePending}, nil)
+	api.EXPECT().GetRefresh(mock.Anything, catalog.GetRefreshRequest{TableName: "main.default.t", RefreshId: 1}).
+		Return(&catalog.MonitorRefreshInfo{RefreshId: 1, State: catalog.MonitorRefreshInfoStateFailed, Message: "boom"}, nil)
+
+	_, err := r.Run(ctx, &Options{})
+	assert.EqualError(t, err, "refresh failed: boom")
+}
+
+func TestQualityMonitorCancelActiveRefreshes(t *testing.T) {
+	ctx := cmdio.MockDiscard(t.Context())
+	r, m := newTestQualityMonitorRunner(t)
+
+	api := m.GetMockQualityMonitorsAPI()
+	api.EXPECT().ListRefreshes(mock.Anything, catalog.ListRefreshesRequest{TableName: "main.default.t"}).
+		Return(&catalog.MonitorRefreshListResponse{Refreshes: []catalog.MonitorRefreshInfo{
+			{RefreshId: 1, State: catalog.MonitorRefreshInfoStateSuccess},
+			{RefreshId: 2, State: catalog.MonitorRefreshInfoStateRunning},
+		}}, nil)
+	api.EXPECT().CancelRefresh(mock.Anything, catalog.CancelRefreshRequest{TableName: "main.default.t", RefreshId: 2}).
+		Return(nil)
+
+	require.NoError(t, r.Cancel(ctx))
+}
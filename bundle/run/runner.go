@@ -3,11 +3,15 @@ package run
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/databricks/cli/bundle"
 	"github.com/databricks/cli/bundle/config/resources"
 	refs "github.com/databricks/cli/bundle/resources"
 	"github.com/databricks/cli/bundle/run/output"
+	"github.com/databricks/cli/bundle/run/progress"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/log"
 )
 
 type key string
@@ -42,7 +46,8 @@ type Runner interface {
 // IsRunnable returns a filter that only allows runnable resources.
 func IsRunnable(ref refs.Reference) bool {
 	switch ref.Resource.(type) {
-	case *resources.Job, *resources.Pipeline, *resources.App:
+	case *resources.Job, *resources.Pipeline, *resources.App,
+		*resources.Dashboard, *resources.QualityMonitor, *resources.VectorSearchIndex, *resources.ModelServingEndpoint:
 		return true
 	default:
 		return false
@@ -62,7 +67,26 @@ func ToRunner(b *bundle.Bundle, ref refs.Reference) (Runner, error) {
 			bundle: b,
 			app:    resource,
 		}, nil
+	case *resources.Dashboard:
+		return &dashboardRunner{key: key(ref.KeyWithType), bundle: b, dashboard: resource}, nil
+	case *resources.QualityMonitor:
+		return &qualityMonitorRunner{key: key(ref.KeyWithType), bundle: b, monitor: resource}, nil
+	case *resources.VectorSearchIndex:
+		return &vectorSearchIndexRunner{key: key(ref.KeyWithType), bundle: b, index: resource}, nil
+	case *resources.ModelServingEndpoint:
+		return &servingEndpointRunner{key: key(ref.KeyWithType), bundle: b, endpoint: resource}, nil
 	default:
 		return nil, fmt.Errorf("unsupported resource type: %T", resource)
 	}
 }
+
+// resourcePollInterval is the interval at which runners for resources without
+// a waiter in the SDK poll for the state of a run.
+var resourcePollInterval = time.Second
+
+// logResourceProgress logs a state transition of a resource run.
+func logResourceProgress(ctx context.Context, key, state, message string) {
+	event := progress.NewResourceProgressEvent(key, state, message)
+	cmdio.Log(ctx, event)
+	log.Info(ctx, event.String())
+}
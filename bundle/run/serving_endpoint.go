@@ -0,0 +1,142 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/run/output"
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/databricks-sdk-go/service/serving"
+	"github.com/spf13/cobra"
+)
+
+// ServingEndpointOptions defines options for running a model serving endpoint.
+type ServingEndpointOptions struct {
+	// Request to send to the endpoint once it is ready.
+	Request *serving.QueryEndpointInput
+}
+
+// servingEndpointRunner waits for a model serving endpoint to be ready and
+// optionally sends it a test request, passed as a positional JSON argument:
+//
+//	databricks bundle run my_endpoint '{"inputs": [[1, 2, 3]]}'
+type servingEndpointRunner struct {
+	key
+
+	bundle   *bundle.Bundle
+	endpoint *resources.ModelServingEndpoint
+}
+
+func (r *servingEndpointRunner) Name() string {
+	if r.endpoint == nil {
+		return ""
+	}
+	return r.endpoint.Name
+}
+
+func endpointState(e *serving.ServingEndpointDetailed) string {
+	if e.State == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %s", e.State.Ready, e.State.ConfigUpdate)
+}
+
+func (r *servingEndpointRunner) Run(ctx context.Context, opts *Options) (output.RunOutput, error) {
+	if r.endpoint == nil {
+		return nil, errors.New("serving endpoint is not defined")
+	}
+
+	name := r.endpoint.Name
+	if opts.NoWait && opts.ServingEndpoint.Request != nil {
+		return nil, fmt.Errorf("cannot send a request to serving endpoint %s with --no-wait, as the request requires the endpoint to be ready", name)
+	}
+
+	ctx = log.NewContext(ctx, log.GetLogger(ctx).With("resource", r.Key()))
+	w := r.bundle.WorkspaceClient(ctx)
+
+	endpoint, err := w.ServingEndpoints.Get(ctx, serving.GetServingEndpointRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	state := endpointState(endpoint)
+	logResourceProgress(ctx, r.Key(), state, "")
+
+	// Wait for a pending configuration update to finish.
+	for !opts.NoWait && endpoint.State != nil && endpoint.State.ConfigUpdate == serving.EndpointStateConfigUpdateInProgress {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(resourcePollInterval):
+		}
+
+		endpoint, err = w.ServingEndpoints.Get(ctx, serving.GetServingEndpointRequest{Name: name})
+		if err != nil {
+			return nil, err
+		}
+
+		// Log only if the current state is different from the previous state.
+		if s := endpointState(endpoint); s != state {
+			logResourceProgress(ctx, r.Key(), s, "")
+			state = s
+		}
+	}
+
+	out := &output.ServingEndpointOutput{State: state}
+	if opts.NoWait {
+		return out, nil
+	}
+	if endpoint.State != nil && endpoint.State.ConfigUpdate == serving.EndpointStateConfigUpdateUpdateFailed {
+		return nil, fmt.Errorf("configuration update of serving endpoint %s failed", name)
+	}
+	if endpoint.State == nil || endpoint.State.Ready != serving.EndpointStateReadyReady {
+		return nil, fmt.Errorf("serving endpoint %s is not ready", name)
+	}
+
+	req := opts.ServingEndpoint.Request
+	if req == nil {
+		return out, nil
+	}
+
+	req.Name = name
+	out.Response, err = w.ServingEndpoints.Query(ctx, *req)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Cancel is a no-op because serving endpoints are not run; configuration
+// updates in progress cannot be cancelled.
+func (r *servingEndpointRunner) Cancel(ctx context.Context) error {
+	return nil
+}
+
+func (r *servingEndpointRunner) Restart(ctx context.Context, opts *Options) (output.RunOutput, error) {
+	return r.Run(ctx, opts)
+}
+
+func (r *servingEndpointRunner) ParseArgs(args []string, opts *Options) error {
+	switch len(args) {
+	case 0:
+		return nil
+	case 1:
+		var req serving.QueryEndpointInput
+		err := json.Unmarshal([]byte(args[0]), &req)
+		if err != nil {
+			return fmt.Errorf("failed to parse request for serving endpoint: %w", err)
+		}
+		opts.ServingEndpoint.Request = &req
+		return nil
+	default:
+		return fmt.Errorf("expected a single JSON request as positional argument, got %d arguments", len(args))
+	}
+}
+
+func (r *servingEndpointRunner) CompleteArgs(args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,78 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/run/output"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/serving"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServingEndpointParseArgs(t *testing.T) {
+	r := &servingEndpointRunner{}
+
+	var opts Options
+	require.NoError(t, r.ParseArgs(nil, &opts))
+	assert.Nil(t, opts.ServingEndpoint.Request)
+
+	require.NoError(t, r.ParseArgs([]string{`{"inputs": [[1, 2]]}`}, &opts))
+	require.NotNil(t, opts.ServingEndpoint.Request)
+	assert.Equal(t, []any{[]any{1.0, 2.0}}, opts.ServingEndpoint.Request.Inputs)
+
+	assert.ErrorContains(t, r.ParseArgs([]string{"not json"}, &opts), "failed to parse request")
+	assert.ErrorContains(t, r.ParseArgs([]string{"{}", "{}"}, &opts), "got 2 arguments")
+}
+
+func TestServingEndpointRunWaitsAndQueries(t *testing.T) {
+	ctx := cmdio.MockDiscard(t.Context())
+	endpoint := &resources.ModelServingEndpoint{
+		CreateServingEndpoint: serving.CreateServingEndpoint{Name: "my-endpoint"},
+	}
+	b := &bundle.Bundle{}
+	m := mocks.NewMockWorkspaceClient(t)
+	b.SetWorkpaceClient(m.WorkspaceClient)
+	disableResourcePolling(t)
+
+	api := m.GetMockServingEndpointsAPI()
+	api.EXPECT().Get(mock.Anything, serving.GetServingEndpointRequest{Name: "my-endpoint"}).
+		Return(&serving.ServingEndpointDetailed{State: &serving.EndpointState{
+			Ready:        serving.EndpointStateReadyNotReady,
+			ConfigUpdate: serving.EndpointStateConfigUpdateInProgress,
+		}}, nil).Once()
+	api.EXPECT().Get(mock.Anything, serving.GetServingEndpointRequest{Name: "my-endpoint"}).
+		Return(&serving.ServingEndpointDetailed{State: &serving.EndpointState{
+			Ready:        serving.EndpointStateReadyReady,
+			ConfigUpdate: serving.EndpointStateConfigUpdateNotUpdating,
+		}}, nil).Once()
+
+	response := &serving.QueryEndpointResponse{Predictions: []any{1.0}}
+	api.EXPECT().Query(mock.Anything, serving.QueryEndpointInput{Name: "my-endpoint", Inputs: []any{1.0}}).
+		Return(response, nil)
+
+	r := &servingEndpointRunner{key: "model_serving_endpoints.my_endpoint", bundle: b, endpoint: endpoint}
+	opts := &Options{ServingEndpoint: ServingEndpointOptions{
+		Request: &serving.QueryEndpointInput{Inputs: []any{1.0}},
+	}}
+	out, err := r.Run(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, &output.ServingEndpointOutput{State: "READY NOT_UPDATING", Response: response}, out)
+}
+
+func TestServingEndpointRunRejectsRequestWithNoWait(t *testing.T) {
+	ctx := cmdio.MockDiscard(t.Context())
+	endpoint := &resources.ModelServingEndpoint{
+		CreateServingEndpoint: serving.CreateServingEndpoint{Name: "my-endpoint"},
+	}
+	r := &servingEndpointRunner{key: "model_serving_endpoints.my_endpoint", bundle: &bundle.Bundle{}, endpoint: endpoint}
+	opts := &Options{NoWait: true, ServingEndpoint: ServingEndpointOptions{
+		Request: &serving.QueryEndpointInput{Inputs: []any{1.0}},
+	}}
+	_, err := r.Run(ctx, opts)
+	assert.EqualError(t, err, "cannot send a request to serving endpoint my-endpoint with --no-wait, as the request requires the endpoint to be ready")
+}
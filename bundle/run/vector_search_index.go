@@ -0,0 +1,162 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/run/output"
+	"github.com/databricks/cli/bundle/run/progress"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/databricks-sdk-go/service/pipelines"
+	"github.com/databricks/databricks-sdk-go/service/vectorsearch"
+)
+
+// vectorSearchIndexRunner syncs a Delta Sync vector search index with its
+// source table. The sync runs as an update of the pipeline backing the index.
+type vectorSearchIndexRunner struct {
+	key
+	nopArgsHandler
+
+	bundle *bundle.Bundle
+	index  *resources.VectorSearchIndex
+}
+
+func (r *vectorSearchIndexRunner) Name() string {
+	if r.index == nil {
+		return ""
+	}
+	return r.index.Name
+}
+
+// pipelineID returns the ID of the pipeline that syncs the index.
+func (r *vectorSearchIndexRunner) pipelineID(ctx context.Context) (string, error) {
+	if r.index == nil {
+		return "", errors.New("vector search index is not defined")
+	}
+
+	w := r.bundle.WorkspaceClient(ctx)
+	index, err := w.VectorSearchIndexes.GetIndex(ctx, vectorsearch.GetIndexRequest{
+		IndexName: r.index.Name,
+	})
+	if err != nil {
+		return "", err
+	}
+	if index.DeltaSyncIndexSpec == nil || index.DeltaSyncIndexSpec.PipelineId == "" {
+		return "", fmt.Errorf("vector search index %s is not a Delta Sync index and cannot be synced", r.index.Name)
+	}
+	return index.DeltaSyncIndexSpec.PipelineId, nil
+}
+
+// latestUpdateID returns the ID of the most recent update of the pipeline, if any.
+func latestUpdateID(ctx context.Context, w pipelines.PipelinesInterface, pipelineID string) (string, error) {
+	p, err := w.Get(ctx, pipelines.GetPipelineRequest{PipelineId: pipelineID})
+	if err != nil {
+		return "", err
+	}
+	if len(p.LatestUpdates) == 0 {
+		return "", nil
+	}
+	return p.LatestUpdates[0].UpdateId, nil
+}
+
+func (r *vectorSearchIndexRunner) Run(ctx context.Context, opts *Options) (output.RunOutput, error) {
+	ctx = log.NewContext(ctx, log.GetLogger(ctx).With("resource", r.Key()))
+	w := r.bundle.WorkspaceClient(ctx)
+
+	pipelineID, err := r.pipelineID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prevUpdateID, err := latestUpdateID(ctx, w.Pipelines, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.VectorSearchIndexes.SyncIndex(ctx, vectorsearch.SyncIndexRequest{
+		IndexName: r.index.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logResourceProgress(ctx, r.Key(), "SYNC_REQUESTED", "")
+	if opts.NoWait {
+		return &output.VectorSearchIndexOutput{PipelineId: pipelineID}, nil
+	}
+
+	// The sync starts a pipeline update asynchronously; wait for it to appear.
+	var updateID string
+	for {
+		updateID, err = latestUpdateID(ctx, w.Pipelines, pipelineID)
+		if err != nil {
+			return nil, err
+		}
+		if updateID != "" && updateID != prevUpdateID {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(resourcePollInterval):
+		}
+	}
+
+	opts.RunID = updateID
+	cmdio.Log(ctx, progress.NewPipelineUpdateUrlEvent(w.Config.Host, updateID, pipelineID))
+	pr := &pipelineRunner{key: r.key, bundle: r.bundle}
+	err = pr.waitForUpdate(ctx, pipelineID, updateID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &output.VectorSearchIndexOutput{PipelineId: pipelineID, UpdateId: updateID}
+	index, err := w.VectorSearchIndexes.GetIndex(ctx, vectorsearch.GetIndexRequest{
+		IndexName: r.index.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if index.Status != nil {
+		out.IndexedRowCount = index.Status.IndexedRowCount
+		logResourceProgress(ctx, r.Key(), "SYNCED", index.Status.Message)
+	}
+	return out, nil
+}
+
+func (r *vectorSearchIndexRunner) Cancel(ctx context.Context) error {
+	pipelineID, err := r.pipelineID(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := r.bundle.WorkspaceClient(ctx)
+	wait, err := w.Pipelines.Stop(ctx, pipelines.StopRequest{
+		PipelineId: pipelineID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Waits for the Idle state of the pipeline
+	_, err = wait.GetWithTimeout(jobRunTimeout)
+	return err
+}
+
+func (r *vectorSearchIndexRunner) Restart(ctx context.Context, opts *Options) (output.RunOutput, error) {
+	sp := cmdio.NewSpinner(ctx)
+	sp.Update("Cancelling the active sync of the vector search index")
+	err := r.Cancel(ctx)
+	sp.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Run(ctx, opts)
+}
@@ -0,0 +1,128 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/run/output"
+	"github.com/databricks/cli/libs/cmdio"
+	sdk_config "github.com/databricks/databricks-sdk-go/config"
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/pipelines"
+	"github.com/databricks/databricks-sdk-go/service/vectorsearch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVectorSearchIndexRunner(t *testing.T) (*vectorSearchIndexRunner, *mocks.MockWorkspaceClient) {
+	index := &resources.VectorSearchIndex{
+		CreateVectorIndexRequest: vectorsearch.CreateVectorIndexRequest{Name: "main.default.idx"},
+	}
+	b := &bundle.Bundle{}
+	m := mocks.NewMockWorkspaceClient(t)
+	m.WorkspaceClient.Config = &sdk_config.Config{Host: "https://test.test"}
+	b.SetWorkpaceClient(m.WorkspaceClient)
+	disableResourcePolling(t)
+
+	r := &vectorSearchIndexRunner{key: "vector_search_indexes.idx", bundle: b, index: index}
+	return r, m
+}
+
+func expectDeltaSyncIndex(m *mocks.MockWorkspaceClient, status *vectorsearch.VectorIndexStatus) {
+	m.GetMockVectorSearchIndexesAPI().EXPECT().
+		GetIndex(mock.Anything, vectorsearch.GetIndexRequest{IndexName: "main.default.idx"}).
+		Return(&vectorsearch.VectorIndex{
+			DeltaSyncIndexSpec: &vectorsearch.DeltaSyncVectorIndexSpecResponse{PipelineId: "p1"},
+			Status:             status,
+		}, nil).Once()
+}
+
+func expectLatestUpdate(m *mocks.MockWorkspaceClient, updateID string) {
+	var updates []pipelines.UpdateStateInfo
+	if updateID != "" {
+		updates = append(updates, pipelines.UpdateStateInfo{UpdateId: updateID})
+	}
+	m.GetMockPipelinesAPI().EXPECT().
+		Get(mock.Anything, pipelines.GetPipelineRequest{PipelineId: "p1"}).
+		Return(&pipelines.GetPipelineResponse{LatestUpdates: updates}, nil).Once()
+}
+
+func TestVectorSearchIndexRunWaitsForSync(t *testing.T) {
+	ctx := cmdio.MockDiscard(t.Context())
+	r, m := newTestVectorSearchIndexRunner(t)
+
+	expectDeltaSyncIndex(m, nil)
+	expectLatestUpdate(m, "u1")
+	m.GetMockVectorSearchIndexesAPI().EXPECT().
+		SyncIndex(mock.Anything, vectorsearch.SyncIndexRequest{IndexName: "main.default.idx"}).
+		Return(nil)
+
+	// The update started by the sync shows up after the previous one.
+	expectLatestUpdate(m, "u1")
+	expectLatestUpdate(m, "u2")
+
+	pipelineAPI := m.GetMockPipelinesAPI()
+	pipelineAPI.EXPECT().ListPipelineEventsAll(mock.Anything, pipelines.ListPipelineEventsRequest{
+		Filter:     `update_id = 'u2'`,
+		MaxResults: 100,
+		PipelineId: "p1",
+	}).Return([]pipelines.PipelineEvent{}, nil)
+	pipelineAPI.EXPECT().GetUpdateByPipelineIdAndUpdateId(mock.Anything, "p1", "u2").
+		Return(&pipelines.GetUpdateResponse{
+			Update: &pipelines.UpdateInfo{State: pipelines.UpdateInfoStateCompleted},
+		}, nil)
+
+	expectDeltaSyncIndex(m, &vectorsearch.VectorIndexStatus{IndexedRowCount: 42, Message: "synced"})
+
+	opts := &Options{}
+	out, err := r.Run(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, &output.VectorSearchIndexOutput{PipelineId: "p1", UpdateId: "u2", IndexedRowCount: 42}, out)
+	assert.Equal(t, "u2", opts.RunID)
+}
+
+func TestVectorSearchIndexRunNoWait(t *testing.T) {
+	ctx := cmdio.MockDiscard(t.Context())
+	r, m := newTestVectorSearchIndexRunner(t)
+
+	expectDeltaSyncIndex(m, nil)
+	expectLatestUpdate(m, "")
+	m.GetMockVectorSearchIndexesAPI().EXPECT().
+		SyncIndex(mock.Anything, vectorsearch.SyncIndexRequest{IndexName: "main.default.idx"}).
+		Return(nil)
+
+	out, err := r.Run(ctx, &Options{NoWait: true})
+	require.NoError(t, err)
+	assert.Equal(t, &output.VectorSearchIndexOutput{PipelineId: "p1"}, out)
+}
+
+func TestVectorSearchIndexRunRejectsDirectAccessIndex(t *testing.T) {
+	ctx := cmdio.MockDiscard(t.Context())
+	r, m := newTestVectorSearchIndexRunner(t)
+
+	m.GetMockVectorSearchIndexesAPI().EXPECT().
+		GetIndex(mock.Anything, vectorsearch.GetIndexRequest{IndexName: "main.default.idx"}).
+		Return(&vectorsearch.VectorIndex{}, nil)
+
+	_, err := r.Run(ctx, &Options{})
+	assert.EqualError(t, err, "vector search index main.default.idx is not a Delta Sync index and cannot be synced")
+}
+
+func TestVectorSearchIndexCancel(t *testing.T) {
+	r, m := newTestVectorSearchIndexRunner(t)
+
+	expectDeltaSyncIndex(m, nil)
+	mockWait := &pipelines.WaitGetPipelineIdle[struct{}]{
+		Poll: func(time.Duration, func(*pipelines.GetPipelineResponse)) (*pipelines.GetPipelineResponse, error) {
+			return nil, nil
+		},
+	}
+	m.GetMockPipelinesAPI().EXPECT().
+		Stop(mock.Anything, pipelines.StopRequest{PipelineId: "p1"}).
+		Return(mockWait, nil)
+
+	require.NoError(t, r.Cancel(t.Context()))
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/databricks/cli/bundle"
@@ -24,8 +26,17 @@ func Execute(hook config.ScriptHook) bundle.Mutator {
 	}
 }
 
+// ExecuteWithEnv is like [Execute] and passes additional environment variables to the script.
+func ExecuteWithEnv(hook config.ScriptHook, env map[string]string) bundle.Mutator {
+	return &script{
+		scriptHook: hook,
+		env:        env,
+	}
+}
+
 type script struct {
 	scriptHook config.ScriptHook
+	env        map[string]string
 }
 
 func (m *script) Name() string {
@@ -44,6 +55,12 @@ func (m *script) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
 		return diag.FromErr(err)
 	}
 
+	var env []string
+	for _, name := range slices.Sorted(maps.Keys(m.env)) {
+		env = append(env, name+"="+m.env[name])
+	}
+	executor.WithEnv(env)
+
 	cmd, err := executeHook(ctx, executor, command)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to execute script: %w", err))
@@ -75,3 +75,30 @@ func TestExecuteLargeStderrOutputDoesNotDeadlock(t *testing.T) {
 	// The script writes stderr first, but spooling preserves the stdout-then-stderr order.
 	assert.Less(t, strings.Index(output, "stdout-after-stderr"), strings.Index(output, "99999100000"))
 }
+
+func TestExecuteWithEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping on windows")
+	}
+
+	dir := t.TempDir()
+	b := &bundle.Bundle{
+		BundleRootPath: dir,
+		Config: config.Root{
+			Experimental: &config.Experimental{
+				Scripts: map[config.ScriptHook]config.Command{
+					config.ScriptPostRun: "echo \"$DATABRICKS_BUNDLE_RUN_KEY $DATABRICKS_BUNDLE_RUN_ID $DATABRICKS_BUNDLE_RUN_STATE\"",
+				},
+			},
+		},
+	}
+
+	ctx, stderr := cmdio.NewTestContextWithStderr(t.Context())
+	diags := bundle.Apply(ctx, b, scripts.ExecuteWithEnv(config.ScriptPostRun, map[string]string{
+		config.ScriptRunKeyEnv:   "jobs.foo",
+		config.ScriptRunIDEnv:    "1234",
+		config.ScriptRunStateEnv: "SUCCESS",
+	}))
+	require.NoError(t, diags.Error())
+	assert.Contains(t, stderr.String(), "jobs.foo 1234 SUCCESS")
+}
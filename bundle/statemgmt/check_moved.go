@@ -0,0 +1,81 @@
+package statemgmt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/engine"
+	"github.com/databricks/cli/bundle/deploy/terraform"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+)
+
+type checkMoved struct {
+	engine engine.EngineType
+}
+
+func (l *checkMoved) Name() string {
+	return "check-moved"
+}
+
+// checkMovedState validates the moved blocks against the deployment state.
+// A moved block is valid if its old key is in the state, or if its new key is
+// (the move was applied by an earlier deployment). Nothing is checked before
+// the first deployment.
+func checkMovedState(b *bundle.Bundle, state ExportedResourcesMap) diag.Diagnostics {
+	if len(state) == 0 {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	for i, mv := range b.Config.Moved {
+		_, hasFrom := state[mv.From]
+		_, hasTo := state[mv.To]
+
+		var summary string
+		switch {
+		case hasFrom && hasTo:
+			summary = fmt.Sprintf("cannot move %s to %s: both are present in the deployment state", mv.From, mv.To)
+		case !hasFrom && !hasTo:
+			summary = fmt.Sprintf("cannot move %s to %s: %s is not present in the deployment state", mv.From, mv.To, mv.From)
+		default:
+			continue
+		}
+
+		path := fmt.Sprintf("moved[%d]", i)
+		diags = append(diags, diag.Diagnostic{
+			Severity:  diag.Error,
+			Summary:   summary,
+			Paths:     []dyn.Path{dyn.MustPathFromString(path)},
+			Locations: b.Config.GetLocations(path),
+		})
+	}
+	return diags
+}
+
+func (l *checkMoved) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	if len(b.Config.Moved) == 0 {
+		return nil
+	}
+
+	var err error
+	var state ExportedResourcesMap
+
+	if l.engine.IsDirect() {
+		state = b.DeploymentBundle.ExportState(ctx)
+	} else {
+		state, err = terraform.ParseResourcesState(ctx, b)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return checkMovedState(b, state)
+}
+
+// CheckMoved returns a mutator that validates the moved blocks in the bundle
+// configuration against the pulled deployment state.
+func CheckMoved(engine engine.EngineType) bundle.Mutator {
+	return &checkMoved{engine: engine}
+}
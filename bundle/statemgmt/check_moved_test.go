@@ -0,0 +1,39 @@
+package statemgmt
+
+import (
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckMovedState(t *testing.T) {
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Moved: []config.Moved{
+				{From: "resources.jobs.foo", To: "resources.jobs.bar"},
+				{From: "resources.jobs.old", To: "resources.jobs.new"},
+				{From: "resources.jobs.missing", To: "resources.jobs.other"},
+				{From: "resources.jobs.a", To: "resources.jobs.b"},
+			},
+		},
+	}
+
+	state := ExportedResourcesMap{
+		"resources.jobs.foo": {ID: "1"},
+		"resources.jobs.new": {ID: "2"},
+		"resources.jobs.a":   {ID: "3"},
+		"resources.jobs.b":   {ID: "4"},
+	}
+
+	diags := checkMovedState(b, state)
+	require.Len(t, diags, 2)
+	assert.Equal(t, "cannot move resources.jobs.missing to resources.jobs.other: resources.jobs.missing is not present in the deployment state", diags[0].Summary)
+	assert.Equal(t, "moved[2]", diags[0].Paths[0].String())
+	assert.Equal(t, "cannot move resources.jobs.a to resources.jobs.b: both are present in the deployment state", diags[1].Summary)
+
+	// Nothing is checked before the first deployment.
+	assert.Empty(t, checkMovedState(b, nil))
+}
@@ -353,7 +353,7 @@ func commitMigration(ctx context.Context, b *bundle.Bundle, tempStatePath string
 	return nil
 }
 
-// pushDirectState uploads the direct-engine state file to the workspace and
+// pushDirectState uploads the direct-engine state file to the state backend and
 // moves the remote terraform state aside so it is no longer authoritative.
 // The caller passes the file whose contents to upload — this is the temp
 // state produced by the dry-run, uploaded before it is renamed into place
@@ -369,14 +369,17 @@ func pushDirectState(ctx context.Context, b *bundle.Bundle, localPath string) er
 		return err
 	}
 
-	remoteDirectPath, _ := b.StateFilenameDirect(ctx)
-	local, err := os.Open(localPath)
+	backend, err := DirectStateBackend(ctx, b)
 	if err != nil {
 		return err
 	}
-	defer local.Close()
 
-	if err := f.Write(ctx, remoteDirectPath, local, filer.CreateParentDirectories, filer.OverwriteIfExists); err != nil {
+	content, err := os.ReadFile(localPath)
+	if err != nil {
+		return err
+	}
+
+	if err := backend.Write(ctx, b.RemoteState, content); err != nil {
 		return err
 	}
 
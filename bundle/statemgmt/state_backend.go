@@ -0,0 +1,275 @@
+package statemgmt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/deploy"
+	"github.com/databricks/cli/libs/filer"
+	"github.com/databricks/databricks-sdk-go"
+)
+
+// ErrStateConflict is returned by [StateBackend.Write] if the remote state was
+// written by another deployment since it was read.
+var ErrStateConflict = errors.New("remote deployment state was modified by another deployment")
+
+// StateBackend stores the deployment state of the direct engine remotely.
+type StateBackend interface {
+	// Read returns the remote state, or nil if there is none.
+	Read(ctx context.Context) (*StateDesc, error)
+
+	// Write replaces the remote state with content.
+	//
+	// It is a compare-and-swap: the write fails with [ErrStateConflict] unless
+	// the remote state is at version expected, the version read when the state
+	// was pulled. If expected is nil, content only needs to be newer than the
+	// remote state.
+	Write(ctx context.Context, expected *bundle.StateVersion, content []byte) error
+
+	// Delete removes the remote state. Like Write, it fails with [ErrStateConflict]
+	// unless the remote state is at version expected.
+	Delete(ctx context.Context, expected *bundle.StateVersion) error
+}
+
+// stateVersion returns the version of a remote state. A nil state has the zero version.
+func stateVersion(state *StateDesc) bundle.StateVersion {
+	if state == nil {
+		return bundle.StateVersion{}
+	}
+	return bundle.StateVersion{Lineage: state.Lineage, Serial: state.Serial}
+}
+
+// checkStateVersion verifies that the remote state current is at version expected.
+// A nil expected version matches any state.
+func checkStateVersion(current *StateDesc, expected *bundle.StateVersion) error {
+	if expected == nil {
+		return nil
+	}
+	actual := stateVersion(current)
+	if actual == *expected {
+		return nil
+	}
+	if current == nil {
+		return fmt.Errorf("%w: the remote state was deleted", ErrStateConflict)
+	}
+	return fmt.Errorf("%w: the remote state has lineage %q and serial %d, but lineage %q and serial %d were read", ErrStateConflict, actual.Lineage, actual.Serial, expected.Lineage, expected.Serial)
+}
+
+// checkStateSwap verifies that the state next may replace the state current,
+// given that current was expected to be at version expected.
+// A nil current state means there is no remote state yet.
+//
+// It returns false if next is identical to current and does not need to be
+// written, which is the case for a deployment that did not change any resources.
+func checkStateSwap(current *StateDesc, expected *bundle.StateVersion, next []byte) (bool, error) {
+	var desc StateDesc
+	err := json.Unmarshal(next, &desc)
+	if err != nil {
+		return false, fmt.Errorf("parsing state: %w", err)
+	}
+
+	if current != nil && bytes.Equal(current.Content, next) {
+		return false, nil
+	}
+	err = checkStateVersion(current, expected)
+	if err != nil {
+		return false, err
+	}
+	if current == nil {
+		return true, nil
+	}
+	if current.Lineage != desc.Lineage {
+		return false, fmt.Errorf("%w: lineage %q does not match lineage %q of the remote state", ErrStateConflict, desc.Lineage, current.Lineage)
+	}
+	if current.Serial >= desc.Serial {
+		return false, fmt.Errorf("%w: serial %d is not newer than serial %d of the remote state", ErrStateConflict, desc.Serial, current.Serial)
+	}
+	return true, nil
+}
+
+// filerStateBackend stores the state in a file accessed through a [filer.Filer].
+//
+// Filers do not support conditional writes, so the compare-and-swap is done by
+// reading the remote state right before writing it. This detects concurrent
+// deployments unless both write at the same instant.
+type filerStateBackend struct {
+	filer filer.Filer
+	path  string
+}
+
+func (s *filerStateBackend) Read(ctx context.Context) (*StateDesc, error) {
+	return _filerRead(ctx, s.filer, s.path)
+}
+
+func (s *filerStateBackend) Write(ctx context.Context, expected *bundle.StateVersion, content []byte) error {
+	current, err := s.Read(ctx)
+	if err != nil {
+		return err
+	}
+
+	ok, err := checkStateSwap(current, expected, content)
+	if err != nil || !ok {
+		return err
+	}
+
+	return s.filer.Write(ctx, s.path, bytes.NewReader(content), filer.CreateParentDirectories, filer.OverwriteIfExists)
+}
+
+func (s *filerStateBackend) Delete(ctx context.Context, expected *bundle.StateVersion) error {
+	current, err := s.Read(ctx)
+	if err != nil || current == nil {
+		return err
+	}
+
+	err = checkStateVersion(current, expected)
+	if err != nil {
+		return err
+	}
+
+	err = s.filer.Delete(ctx, s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// NewWorkspaceStateBackend returns a [StateBackend] that stores the state as a
+// workspace file in the state path of the bundle.
+func NewWorkspaceStateBackend(ctx context.Context, b *bundle.Bundle) (StateBackend, error) {
+	f, err := deploy.StateFiler(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	remotePath, _ := b.StateFilenameDirect(ctx)
+	return &filerStateBackend{filer: f, path: remotePath}, nil
+}
+
+// NewVolumeStateBackend returns a [StateBackend] that stores the state as
+// a file at the relative path name in the UC Volume directory dir.
+func NewVolumeStateBackend(ctx context.Context, w *databricks.WorkspaceClient, dir, name string) (StateBackend, error) {
+	if !strings.HasPrefix(dir, "/Volumes/") {
+		return nil, fmt.Errorf("state path %s is not a UC Volume path; it must start with /Volumes/", dir)
+	}
+
+	f, err := filer.NewFilesClient(ctx, w, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filerStateBackend{filer: f, path: name}, nil
+}
+
+// DirectStateBackend returns the [StateBackend] configured for the bundle.
+//
+// A UC Volume state path can be shared by several bundles and targets, so the
+// state is stored in a subdirectory named after the bundle and the target.
+func DirectStateBackend(ctx context.Context, b *bundle.Bundle) (StateBackend, error) {
+	statePath := b.Config.Bundle.Deployment.StatePath
+	if statePath == "" {
+		return NewWorkspaceStateBackend(ctx, b)
+	}
+
+	remotePath, _ := b.StateFilenameDirect(ctx)
+	name := path.Join(b.Config.Bundle.Name, b.Config.Bundle.Target, remotePath)
+	return NewVolumeStateBackend(ctx, b.WorkspaceClient(ctx), statePath, name)
+}
+
+// CheckRemoteState verifies that the remote state of the direct engine was not
+// changed by another deployment since it was pulled. Deployments call it after
+// acquiring the lock, so that they fail before changing any resources.
+func CheckRemoteState(ctx context.Context, b *bundle.Bundle) error {
+	if b.RemoteState == nil {
+		return nil
+	}
+
+	backend, err := DirectStateBackend(ctx, b)
+	if err != nil {
+		return err
+	}
+
+	current, err := backend.Read(ctx)
+	if err != nil {
+		return err
+	}
+	return checkStateVersion(current, b.RemoteState)
+}
+
+// DeleteRemoteState removes the remote state of the direct engine.
+func DeleteRemoteState(ctx context.Context, b *bundle.Bundle) error {
+	backend, err := DirectStateBackend(ctx, b)
+	if err != nil {
+		return err
+	}
+	return backend.Delete(ctx, b.RemoteState)
+}
+
+// FakeStateBackend is an in-memory [StateBackend] for tests.
+type FakeStateBackend struct {
+	mu      sync.Mutex
+	content []byte
+}
+
+func (s *FakeStateBackend) Read(ctx context.Context) (*StateDesc, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read()
+}
+
+func (s *FakeStateBackend) read() (*StateDesc, error) {
+	if s.content == nil {
+		return nil, nil
+	}
+
+	state := &StateDesc{}
+	err := json.Unmarshal(s.content, state)
+	if err != nil {
+		return nil, fmt.Errorf("parsing state: %w", err)
+	}
+	state.Content = bytes.Clone(s.content)
+	return state, nil
+}
+
+func (s *FakeStateBackend) Write(ctx context.Context, expected *bundle.StateVersion, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	ok, err := checkStateSwap(current, expected, content)
+	if err != nil || !ok {
+		return err
+	}
+
+	s.content = bytes.Clone(content)
+	return nil
+}
+
+func (s *FakeStateBackend) Delete(ctx context.Context, expected *bundle.StateVersion) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.read()
+	if err != nil || current == nil {
+		return err
+	}
+
+	err = checkStateVersion(current, expected)
+	if err != nil {
+		return err
+	}
+
+	s.content = nil
+	return nil
+}
@@ -0,0 +1,188 @@
+package statemgmt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/engine"
+	"github.com/databricks/cli/libs/filer"
+	"github.com/databricks/cli/libs/logdiag"
+	"github.com/databricks/cli/libs/testserver"
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeStateBackendCompareAndSwap(t *testing.T) {
+	ctx := t.Context()
+	backend := &FakeStateBackend{}
+
+	state, err := backend.Read(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	require.NoError(t, backend.Write(ctx, &bundle.StateVersion{}, []byte(`{"lineage": "a", "serial": 1}`)))
+
+	state, err = backend.Read(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "a", state.Lineage)
+	assert.Equal(t, 1, state.Serial)
+
+	// Writing identical state is a no-op.
+	require.NoError(t, backend.Write(ctx, &bundle.StateVersion{Lineage: "a", Serial: 1}, []byte(`{"lineage": "a", "serial": 1}`)))
+
+	// Another deployment wrote the state after it was read.
+	err = backend.Write(ctx, &bundle.StateVersion{}, []byte(`{"lineage": "a", "serial": 2}`))
+	assert.ErrorIs(t, err, ErrStateConflict)
+
+	// Another deployment wrote the same serial first.
+	err = backend.Write(ctx, nil, []byte(`{"lineage": "a", "serial": 1, "state": {}}`))
+	assert.ErrorIs(t, err, ErrStateConflict)
+
+	// A state with a different lineage never replaces the remote state.
+	err = backend.Write(ctx, &bundle.StateVersion{Lineage: "a", Serial: 1}, []byte(`{"lineage": "b", "serial": 5}`))
+	assert.ErrorIs(t, err, ErrStateConflict)
+
+	require.NoError(t, backend.Write(ctx, &bundle.StateVersion{Lineage: "a", Serial: 1}, []byte(`{"lineage": "a", "serial": 2}`)))
+
+	// An older state does not replace a newer one.
+	err = backend.Write(ctx, nil, []byte(`{"lineage": "a", "serial": 1}`))
+	assert.ErrorIs(t, err, ErrStateConflict)
+
+	state, err = backend.Read(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, state.Serial)
+
+	// Deleting the state is also conditional.
+	err = backend.Delete(ctx, &bundle.StateVersion{Lineage: "a", Serial: 1})
+	assert.ErrorIs(t, err, ErrStateConflict)
+	require.NoError(t, backend.Delete(ctx, &bundle.StateVersion{Lineage: "a", Serial: 2}))
+
+	state, err = backend.Read(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestCheckStateSwapInvalidState(t *testing.T) {
+	_, err := checkStateSwap(nil, nil, []byte(`not json`))
+	assert.ErrorContains(t, err, "parsing state")
+}
+
+func TestFilerStateBackendCompareAndSwap(t *testing.T) {
+	ctx := t.Context()
+	f, err := filer.NewLocalClient(t.TempDir())
+	require.NoError(t, err)
+	backend := &filerStateBackend{filer: f, path: "state/resources.json"}
+
+	state, err := backend.Read(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	require.NoError(t, backend.Write(ctx, &bundle.StateVersion{}, []byte(`{"lineage": "a", "serial": 1}`)))
+
+	// A concurrent deployment that read the same version loses.
+	err = backend.Write(ctx, &bundle.StateVersion{}, []byte(`{"lineage": "a", "serial": 5}`))
+	assert.ErrorIs(t, err, ErrStateConflict)
+
+	require.NoError(t, backend.Write(ctx, &bundle.StateVersion{Lineage: "a", Serial: 1}, []byte(`{"lineage": "a", "serial": 2}`)))
+
+	state, err = backend.Read(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, state.Serial)
+
+	require.NoError(t, backend.Delete(ctx, &bundle.StateVersion{Lineage: "a", Serial: 2}))
+	state, err = backend.Read(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	// Deleting a missing state is a no-op.
+	require.NoError(t, backend.Delete(ctx, &bundle.StateVersion{Lineage: "a", Serial: 2}))
+}
+
+func TestPushResourcesStateVolume(t *testing.T) {
+	server := testserver.New(t)
+	testserver.AddDefaultHandlers(server)
+
+	w, err := databricks.NewWorkspaceClient(&databricks.Config{
+		Host:  server.URL,
+		Token: "testtoken",
+	})
+	require.NoError(t, err)
+
+	// The fake Files API does not create parent directories on upload.
+	for _, dir := range []string{"my_bundle/dev", "my_bundle/prod"} {
+		require.NoError(t, w.Files.CreateDirectory(t.Context(), files.CreateDirectoryRequest{DirectoryPath: "/Volumes/main/default/state/" + dir}))
+	}
+
+	newBundle := func(target string) *bundle.Bundle {
+		b := &bundle.Bundle{
+			BundleRootPath: t.TempDir(),
+			Config: config.Root{
+				Bundle: config.Bundle{
+					Name:   "my_bundle",
+					Target: target,
+					Deployment: config.Deployment{
+						StatePath: "/Volumes/main/default/state",
+					},
+				},
+			},
+			RemoteState: &bundle.StateVersion{},
+		}
+		b.SetWorkpaceClient(w)
+		return b
+	}
+
+	writeLocal := func(b *bundle.Bundle, content string) {
+		_, localPath := b.StateFilenameDirect(t.Context())
+		require.NoError(t, os.MkdirAll(filepath.Dir(localPath), 0o700))
+		require.NoError(t, os.WriteFile(localPath, []byte(content), 0o600))
+	}
+
+	ctx := logdiag.InitContext(t.Context())
+	logdiag.SetCollect(ctx, true)
+	dev := newBundle("dev")
+	writeLocal(dev, `{"lineage": "a", "serial": 1}`)
+	PushResourcesState(ctx, dev, engine.EngineDirect)
+	require.False(t, logdiag.HasError(ctx))
+	assert.Equal(t, &bundle.StateVersion{Lineage: "a", Serial: 1}, dev.RemoteState)
+
+	// Targets sharing the state path do not share the state.
+	prod := newBundle("prod")
+	require.NoError(t, CheckRemoteState(ctx, prod))
+	writeLocal(prod, `{"lineage": "b", "serial": 1}`)
+	PushResourcesState(ctx, prod, engine.EngineDirect)
+	require.False(t, logdiag.HasError(ctx))
+
+	// Another deployment of the dev target writes the state.
+	other := newBundle("dev")
+	other.RemoteState = &bundle.StateVersion{Lineage: "a", Serial: 1}
+	writeLocal(other, `{"lineage": "a", "serial": 2}`)
+	PushResourcesState(ctx, other, engine.EngineDirect)
+	require.False(t, logdiag.HasError(ctx))
+
+	assert.ErrorIs(t, CheckRemoteState(ctx, dev), ErrStateConflict)
+	writeLocal(dev, `{"lineage": "a", "serial": 3}`)
+	PushResourcesState(ctx, dev, engine.EngineDirect)
+	diags := logdiag.FlushCollected(ctx)
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Summary, `lineage "a" and serial 2, but lineage "a" and serial 1 were read`)
+
+	// A state with another lineage never replaces the remote state.
+	ctx = logdiag.InitContext(t.Context())
+	logdiag.SetCollect(ctx, true)
+	dev.RemoteState = &bundle.StateVersion{Lineage: "a", Serial: 2}
+	writeLocal(dev, `{"lineage": "c", "serial": 3}`)
+	PushResourcesState(ctx, dev, engine.EngineDirect)
+	require.True(t, logdiag.HasError(ctx))
+
+	backend, err := DirectStateBackend(ctx, dev)
+	require.NoError(t, err)
+	state, err := backend.Read(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "a", state.Lineage)
+	assert.Equal(t, 2, state.Serial)
+}
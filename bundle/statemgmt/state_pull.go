@@ -117,6 +117,23 @@ func filerRead(ctx context.Context, f filer.Filer, path string, engine engine.En
 	return state
 }
 
+// backendRead reads the state of the direct engine from the configured state backend.
+func backendRead(ctx context.Context, b *bundle.Bundle, path string) *StateDesc {
+	backend, err := DirectStateBackend(ctx, b)
+	if err != nil {
+		logdiag.LogError(ctx, err)
+		return nil
+	}
+	state, err := backend.Read(ctx)
+	if err != nil {
+		logdiag.LogError(ctx, fmt.Errorf("reading %s: %w", path, err))
+	} else if state != nil {
+		log.Debugf(ctx, "read %s: %s", path, state.String())
+		state.Engine = engine.EngineDirect
+	}
+	return state
+}
+
 // PullResourcesState determines correct state to use by reading all 4 states (terraform/direct, local/remote).
 // If state is present and the requested engine disagrees, a warning is issued and the state's engine is used.
 func PullResourcesState(ctx context.Context, b *bundle.Bundle, alwaysPull AlwaysPull, requiredEngine engine.EngineSetting) (context.Context, *StateDesc) {
@@ -237,7 +254,7 @@ func readStates(ctx context.Context, b *bundle.Bundle, alwaysPull AlwaysPull) []
 		var directRemoteState, terraformRemoteState *StateDesc
 
 		wg.Go(func() {
-			directRemoteState = filerRead(ctx, f, remotePathDirect, engine.EngineDirect)
+			directRemoteState = backendRead(ctx, b, remotePathDirect)
 		})
 
 		wg.Go(func() {
@@ -246,6 +263,11 @@ func readStates(ctx context.Context, b *bundle.Bundle, alwaysPull AlwaysPull) []
 
 		wg.Wait()
 
+		if !logdiag.HasError(ctx) {
+			v := stateVersion(directRemoteState)
+			b.RemoteState = &v
+		}
+
 		// find highest serial across all state files
 		// sorting is stable, so initial setting represents preference (later is preferred):
 		states = []*StateDesc{terraformRemoteState, terraformLocalState, directRemoteState, directLocalState}
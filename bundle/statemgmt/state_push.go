@@ -2,7 +2,9 @@ package statemgmt
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 
@@ -16,19 +18,18 @@ import (
 
 // PushResourcesState uploads the local state file to the remote location.
 func PushResourcesState(ctx context.Context, b *bundle.Bundle, engine engine.EngineType) {
+	if engine.IsDirect() {
+		pushDirectResourcesState(ctx, b)
+		return
+	}
+
 	f, err := deploy.StateFiler(ctx, b)
 	if err != nil {
 		logdiag.LogError(ctx, err)
 		return
 	}
 
-	var remotePath, localPath string
-
-	if engine.IsDirect() {
-		remotePath, localPath = b.StateFilenameDirect(ctx)
-	} else {
-		remotePath, localPath = b.StateFilenameTerraform(ctx)
-	}
+	remotePath, localPath := b.StateFilenameTerraform(ctx)
 
 	local, err := os.Open(localPath)
 	if errors.Is(err, fs.ErrNotExist) {
@@ -50,6 +51,38 @@ func PushResourcesState(ctx context.Context, b *bundle.Bundle, engine engine.Eng
 	}
 }
 
+// pushDirectResourcesState uploads the local state file of the direct engine to the state backend.
+func pushDirectResourcesState(ctx context.Context, b *bundle.Bundle) {
+	_, localPath := b.StateFilenameDirect(ctx)
+	content, err := os.ReadFile(localPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Debugf(ctx, "Local state file does not exist: %s", localPath)
+		return
+	}
+	if err != nil {
+		logdiag.LogError(ctx, err)
+		return
+	}
+
+	backend, err := DirectStateBackend(ctx, b)
+	if err != nil {
+		logdiag.LogError(ctx, err)
+		return
+	}
+
+	err = backend.Write(ctx, b.RemoteState, content)
+	if err != nil {
+		logdiag.LogError(ctx, fmt.Errorf("uploading deployment state: %w", err))
+		return
+	}
+
+	// Later writes by this process replace the state just written.
+	var desc StateDesc
+	if json.Unmarshal(content, &desc) == nil {
+		b.RemoteState = &bundle.StateVersion{Lineage: desc.Lineage, Serial: desc.Serial}
+	}
+}
+
 func BackupRemoteTerraformState(ctx context.Context, b *bundle.Bundle) {
 	f, err := deploy.StateFiler(ctx, b)
 	if err != nil {
@@ -2,10 +2,8 @@ package aitools
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"maps"
 	"os"
 	"slices"
@@ -138,11 +136,11 @@ func defaultListSkills(cmd *cobra.Command, scope string) error {
 	}
 
 	switch root.OutputType(cmd) {
-	case flags.OutputJSON:
-		return renderListJSON(cmd.OutOrStdout(), out)
-	default:
+	case flags.OutputText:
 		renderListText(ctx, out, scope)
 		return nil
+	default:
+		return cmdio.Render(ctx, out)
 	}
 }
 
@@ -307,12 +305,6 @@ func loadStateForScope(ctx context.Context, scopeFilter, excludeScope string, di
 	return state
 }
 
-func renderListJSON(w io.Writer, out listOutput) error {
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	return enc.Encode(out)
-}
-
 func renderListText(ctx context.Context, out listOutput, scope string) {
 	bothScopes := scope == "" &&
 		out.Summary[installer.ScopeGlobal].loaded &&
@@ -12,6 +12,7 @@ import (
 	"github.com/databricks/cli/libs/aitools/installer"
 	"github.com/databricks/cli/libs/cmdio"
 	"github.com/databricks/cli/libs/env"
+	"github.com/databricks/cli/libs/flags"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,6 +54,13 @@ func TestListCommandHasScopeFlags(t *testing.T) {
 	require.NotNil(t, f, "--scope flag should exist")
 }
 
+func renderListJSON(t *testing.T, out listOutput) *bytes.Buffer {
+	var buf bytes.Buffer
+	ctx := cmdio.InContext(t.Context(), cmdio.NewIO(t.Context(), flags.OutputJSON, nil, &buf, &buf, "", ""))
+	require.NoError(t, cmdio.Render(ctx, out))
+	return &buf
+}
+
 func TestRenderListJSON(t *testing.T) {
 	out := listOutput{
 		Release: "0.1.0",
@@ -79,8 +87,7 @@ func TestRenderListJSON(t *testing.T) {
 		},
 	}
 
-	var buf bytes.Buffer
-	require.NoError(t, renderListJSON(&buf, out))
+	buf := renderListJSON(t, out)
 
 	var got listOutput
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
@@ -129,8 +136,7 @@ func TestRenderListJSONWithAgents(t *testing.T) {
 		},
 	}
 
-	var buf bytes.Buffer
-	require.NoError(t, renderListJSON(&buf, out))
+	buf := renderListJSON(t, out)
 
 	var raw map[string]any
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
@@ -349,8 +355,7 @@ func TestRenderListJSONScopeFiltersSummary(t *testing.T) {
 		},
 	}
 
-	var buf bytes.Buffer
-	require.NoError(t, renderListJSON(&buf, out))
+	buf := renderListJSON(t, out)
 
 	var raw map[string]any
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 	"github.com/databricks/cli/libs/databrickscfg"
 	"github.com/databricks/cli/libs/env"
 	"github.com/databricks/cli/libs/flags"
+	"github.com/databricks/cli/libs/httprecord"
 	"github.com/databricks/databricks-sdk-go/client"
 	"github.com/databricks/databricks-sdk-go/config"
 	"github.com/spf13/cobra"
@@ -63,6 +65,8 @@ func makeCommand(method string) *cobra.Command {
 		payload         flags.JsonFlag
 		forceAccount    bool
 		workspaceIDFlag string
+		paginate        bool
+		maxItems        int
 	)
 
 	command := &cobra.Command{
@@ -72,6 +76,13 @@ func makeCommand(method string) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			path := args[0]
 
+			if maxItems < 0 {
+				return errors.New("--max-items must not be negative")
+			}
+			if cmd.Flags().Changed("max-items") && !paginate {
+				return errors.New("--max-items requires --paginate")
+			}
+
 			var request any
 			diags := payload.Unmarshal(&request)
 			if diags.HasError() {
@@ -79,6 +90,7 @@ func makeCommand(method string) *cobra.Command {
 			}
 
 			cfg := &config.Config{}
+			httprecord.Configure(cmd.Context(), cfg)
 
 			// Profile precedence, mirroring MustWorkspaceClient: --profile flag,
 			// then DATABRICKS_CONFIG_PROFILE (set here so our error messages name
@@ -123,6 +135,20 @@ func makeCommand(method string) *cobra.Command {
 				headers[auth.WorkspaceIDHeader] = orgID
 			}
 
+			if paginate {
+				path, pageRequest, err := paginationRequest(path, request)
+				if err != nil {
+					return err
+				}
+				fetch := func(ctx context.Context, request map[string]any) (map[string]any, error) {
+					var response map[string]any
+					err := api.Do(ctx, method, path, headers, nil, request, &response)
+					return response, err
+				}
+				ctx := cmdio.WithLimit(cmd.Context(), maxItems)
+				return cmdio.RenderIterator(ctx, newPageIterator(fetch, pageRequest))
+			}
+
 			var response any
 			err = api.Do(cmd.Context(), method, path, headers, nil, request, &response)
 			if err != nil {
@@ -137,6 +163,13 @@ func makeCommand(method string) *cobra.Command {
 		"Treat this call as account-scoped (skip the workspace routing identifier). Mutually exclusive with --workspace-id.")
 	command.Flags().StringVar(&workspaceIDFlag, "workspace-id", "",
 		"Override the workspace routing identifier on this call. Mutually exclusive with --account.")
+
+	if method == http.MethodGet {
+		command.Flags().BoolVar(&paginate, "paginate", false,
+			"Follow next_page_token or offset pagination and output the items of all pages as a single list. Use -o ndjson to stream one item per line.")
+		command.Flags().IntVar(&maxItems, "max-items", 0,
+			"Maximum number of items to output with --paginate (0 for no limit).")
+	}
 	return command
 }
 
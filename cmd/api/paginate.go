@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// pageTokenParam and nextPageTokenField implement token pagination, used by
+	// most list APIs: the token of the next page is passed back as a request
+	// parameter until the response no longer includes one.
+	pageTokenParam     = "page_token"
+	nextPageTokenField = "next_page_token"
+
+	// offsetParam and hasMoreField implement offset pagination, used by older
+	// list APIs (e.g. /api/2.0/jobs/list): the offset is advanced by the number
+	// of items returned for as long as the response reports there are more.
+	offsetParam  = "offset"
+	hasMoreField = "has_more"
+)
+
+// fetchPageFunc performs a request with the given parameters and returns the
+// decoded response object.
+type fetchPageFunc func(ctx context.Context, request map[string]any) (map[string]any, error)
+
+// pageIterator iterates over the items of all pages of a paginated list API.
+// Items are taken from the single array field of each response (e.g. "jobs"
+// or "clusters"). Pages are fetched lazily, so that output can be streamed
+// and a limit stops fetching further pages.
+type pageIterator struct {
+	fetch   fetchPageFunc
+	request map[string]any
+
+	// field is the name of the array field, taken from the first page that has one.
+	field string
+	items []any
+	done  bool
+	err   error
+}
+
+func newPageIterator(fetch fetchPageFunc, request map[string]any) *pageIterator {
+	return &pageIterator{fetch: fetch, request: request}
+}
+
+func (it *pageIterator) HasNext(ctx context.Context) bool {
+	for len(it.items) == 0 && !it.done && it.err == nil {
+		it.err = it.fetchPage(ctx)
+	}
+	return len(it.items) > 0 || it.err != nil
+}
+
+func (it *pageIterator) Next(ctx context.Context) (any, error) {
+	if !it.HasNext(ctx) {
+		return nil, errors.New("no more items")
+	}
+	if it.err != nil {
+		err := it.err
+		it.err = nil
+		it.done = true
+		return nil, err
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *pageIterator) fetchPage(ctx context.Context) error {
+	response, err := it.fetch(ctx, it.request)
+	if err != nil {
+		return err
+	}
+	it.items, err = it.pageItems(response)
+	if err != nil {
+		return err
+	}
+
+	if token, ok := response[nextPageTokenField].(string); ok && token != "" {
+		it.request[pageTokenParam] = token
+		return nil
+	}
+	if hasMore, ok := response[hasMoreField].(bool); ok && hasMore && len(it.items) > 0 {
+		offset, err := intParam(it.request, offsetParam)
+		if err != nil {
+			return err
+		}
+		it.request[offsetParam] = offset + len(it.items)
+		return nil
+	}
+	it.done = true
+	return nil
+}
+
+func (it *pageIterator) pageItems(response map[string]any) ([]any, error) {
+	if it.field != "" {
+		items, _ := response[it.field].([]any)
+		return items, nil
+	}
+
+	var fields []string
+	for k, v := range response {
+		if _, ok := v.([]any); ok {
+			fields = append(fields, k)
+		}
+	}
+	switch len(fields) {
+	case 0:
+		// Empty pages commonly omit the array field altogether.
+		return nil, nil
+	case 1:
+		it.field = fields[0]
+		return response[it.field].([]any), nil
+	default:
+		slices.Sort(fields)
+		return nil, fmt.Errorf("cannot paginate: the response has multiple list fields: %s", strings.Join(fields, ", "))
+	}
+}
+
+// intParam returns the integer value of a request parameter, or 0 if it is not set.
+func intParam(request map[string]any, name string) (int, error) {
+	switch v := request[name].(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return v, nil
+	case float64:
+		return int(v), nil
+	case string:
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s parameter %q: %w", name, v, err)
+		}
+		return i, nil
+	default:
+		return 0, fmt.Errorf("invalid %s parameter: %v", name, v)
+	}
+}
+
+// paginationRequest merges the query string of path with the request payload, so
+// that pagination parameters can be added to it. The SDK appends the parameters of
+// a GET request to the path, which must therefore not include a query string of its
+// own. Parameters in the payload take precedence, and only the first value of a
+// repeated query parameter is kept.
+func paginationRequest(path string, payload any) (string, map[string]any, error) {
+	request := map[string]any{}
+	switch p := payload.(type) {
+	case nil:
+	case map[string]any:
+		maps.Copy(request, p)
+	default:
+		return "", nil, errors.New("--paginate requires the request payload to be a JSON object")
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("parse path: %w", err)
+	}
+	for k, v := range u.Query() {
+		if _, ok := request[k]; !ok && len(v) > 0 {
+			request[k] = v[0]
+		}
+	}
+	u.RawQuery = ""
+	return u.String(), request, nil
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePages returns a fetch function that serves the given responses in order and
+// records the parameters of each request.
+func fakePages(responses ...map[string]any) (fetchPageFunc, *[]map[string]any) {
+	var requests []map[string]any
+	fetch := func(_ context.Context, request map[string]any) (map[string]any, error) {
+		requests = append(requests, maps.Clone(request))
+		if len(requests) > len(responses) {
+			return nil, errors.New("unexpected request")
+		}
+		return responses[len(requests)-1], nil
+	}
+	return fetch, &requests
+}
+
+func collect(t *testing.T, it *pageIterator) ([]any, error) {
+	var items []any
+	for it.HasNext(t.Context()) {
+		item, err := it.Next(t.Context())
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func TestPageIteratorToken(t *testing.T) {
+	fetch, requests := fakePages(
+		map[string]any{"jobs": []any{"a", "b"}, "next_page_token": "t1"},
+		map[string]any{"next_page_token": "t2"},
+		map[string]any{"jobs": []any{"c"}},
+	)
+
+	items, err := collect(t, newPageIterator(fetch, map[string]any{"limit": float64(2)}))
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a", "b", "c"}, items)
+	assert.Equal(t, []map[string]any{
+		{"limit": float64(2)},
+		{"limit": float64(2), "page_token": "t1"},
+		{"limit": float64(2), "page_token": "t2"},
+	}, *requests)
+}
+
+func TestPageIteratorOffset(t *testing.T) {
+	fetch, requests := fakePages(
+		map[string]any{"jobs": []any{"a", "b"}, "has_more": true},
+		map[string]any{"jobs": []any{"c"}, "has_more": false},
+	)
+
+	items, err := collect(t, newPageIterator(fetch, map[string]any{"offset": "10"}))
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a", "b", "c"}, items)
+	assert.Equal(t, []map[string]any{
+		{"offset": "10"},
+		{"offset": 12},
+	}, *requests)
+}
+
+func TestPageIteratorStopsFetching(t *testing.T) {
+	fetch, requests := fakePages(
+		map[string]any{"jobs": []any{"a", "b"}, "next_page_token": "t1"},
+		map[string]any{"jobs": []any{"c"}},
+	)
+
+	it := newPageIterator(fetch, map[string]any{})
+	require.True(t, it.HasNext(t.Context()))
+	_, err := it.Next(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, *requests, 1)
+}
+
+func TestPageIteratorErrors(t *testing.T) {
+	fetch, _ := fakePages(
+		map[string]any{"jobs": []any{"a"}, "next_page_token": "t1"},
+	)
+	items, err := collect(t, newPageIterator(fetch, map[string]any{}))
+	assert.Equal(t, []any{"a"}, items)
+	assert.EqualError(t, err, "unexpected request")
+
+	fetch, _ = fakePages(
+		map[string]any{"jobs": []any{"a"}, "tags": []any{"b"}},
+	)
+	_, err = collect(t, newPageIterator(fetch, map[string]any{}))
+	assert.EqualError(t, err, "cannot paginate: the response has multiple list fields: jobs, tags")
+}
+
+func TestPaginationRequest(t *testing.T) {
+	path, request, err := paginationRequest("/api/2.2/jobs/list?limit=5&name=foo&o=123", map[string]any{"name": "bar"})
+	require.NoError(t, err)
+	assert.Equal(t, "/api/2.2/jobs/list", path)
+	assert.Equal(t, map[string]any{"limit": "5", "name": "bar", "o": "123"}, request)
+
+	path, request, err = paginationRequest("/api/2.0/clusters/list", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/2.0/clusters/list", path)
+	assert.Equal(t, map[string]any{}, request)
+
+	_, _, err = paginationRequest("/api/2.0/clusters/list", []any{1})
+	assert.EqualError(t, err, "--paginate requires the request payload to be a JSON object")
+}
@@ -2,8 +2,6 @@ package auth
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"path/filepath"
 
 	"github.com/databricks/cli/cmd/root"
@@ -222,20 +220,9 @@ func render(ctx context.Context, cmd *cobra.Command, status *authStatus, templat
 			"Status":           status,
 			"ConfigAttributes": config.ConfigAttributes,
 		}, "", template)
-	case flags.OutputJSON:
-		buf, err := json.MarshalIndent(status, "", "  ")
-		if err != nil {
-			return err
-		}
-		_, err = cmd.OutOrStdout().Write(buf)
-		if err != nil {
-			return err
-		}
 	default:
-		return fmt.Errorf("unknown output type %s", root.OutputType(cmd))
+		return cmdio.Render(ctx, status)
 	}
-
-	return nil
 }
 
 type authStatus struct {
@@ -0,0 +1,420 @@
+// Package bulk adds a --from-ndjson mode to the generated create, update and
+// delete commands, which runs one request per line of its input.
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/flags"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// defaultParallelism is the number of requests run concurrently. APIs rate limit
+// requests per workspace, so this stays conservative.
+const defaultParallelism = 8
+
+// maxLineSize is the maximum size of a single line of input.
+const maxLineSize = 64 * 1024 * 1024
+
+const (
+	fromNdjsonFlag      = "from-ndjson"
+	parallelismFlag     = "parallelism"
+	continueOnErrorFlag = "continue-on-error"
+)
+
+// Enable adds the --from-ndjson flag to the create, update and delete commands of
+// group that accept --json. The generated commands keep their request in the
+// closure of the command, so every line runs on a fresh instance of the command
+// in a group returned by newGroup.
+func Enable(group *cobra.Command, newGroup func() *cobra.Command) {
+	for _, cmd := range group.Commands() {
+		if !isBulkCommand(cmd) {
+			continue
+		}
+		enableCommand(cmd, newGroup)
+	}
+}
+
+func isBulkCommand(cmd *cobra.Command) bool {
+	if cmd.Flags().Lookup("json") == nil {
+		return false
+	}
+	for _, name := range []string{fromNdjsonFlag, parallelismFlag, continueOnErrorFlag} {
+		if cmd.Flags().Lookup(name) != nil {
+			return false
+		}
+	}
+	for _, verb := range []string{"create", "update", "delete"} {
+		if cmd.Name() == verb || strings.HasPrefix(cmd.Name(), verb+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+func enableCommand(cmd *cobra.Command, newGroup func() *cobra.Command) {
+	var (
+		input           string
+		parallelism     int
+		continueOnError bool
+	)
+
+	cmd.Flags().StringVar(&input, fromNdjsonFlag, "",
+		"run one request per line of `FILE` (\"-\" for stdin) and write the results as NDJSON. Each line is a request body as accepted by --json, or an array of positional arguments optionally followed by the request body")
+	cmd.Flags().IntVar(&parallelism, parallelismFlag, defaultParallelism, "number of requests to run in parallel with --from-ndjson")
+	cmd.Flags().BoolVar(&continueOnError, continueOnErrorFlag, false, "with --from-ndjson, run the remaining lines after a request fails")
+	cmd.MarkFlagsMutuallyExclusive("json", fromNdjsonFlag)
+
+	args := cmd.Args
+	cmd.Args = func(cmd *cobra.Command, a []string) error {
+		if cmd.Flags().Changed(fromNdjsonFlag) {
+			err := root.ExactArgs(0)(cmd, a)
+			if err != nil {
+				return errors.New("when --from-ndjson flag is specified, no positional arguments are allowed. Provide them on each line of the input")
+			}
+			return nil
+		}
+		if args == nil {
+			return nil
+		}
+		return args(cmd, a)
+	}
+
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, a []string) error {
+		if !cmd.Flags().Changed(fromNdjsonFlag) {
+			return runE(cmd, a)
+		}
+		if parallelism < 1 {
+			return errors.New("--parallelism must be at least 1")
+		}
+
+		var in io.Reader = cmd.InOrStdin()
+		if input != "-" {
+			f, err := os.Open(input)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+
+		r := &runner{
+			name:            cmd.Name(),
+			newGroup:        newGroup,
+			inherited:       cmd.Parent().InheritedFlags(),
+			values:          changedFlags(cmd),
+			parallelism:     parallelism,
+			continueOnError: continueOnError,
+		}
+		return r.run(cmd.Context(), in, cmd.OutOrStdout())
+	}
+}
+
+// flagValue is the value of a flag that was set on the bulk command.
+type flagValue struct {
+	name  string
+	value string
+	slice []string
+}
+
+// changedFlags returns the flags that were set on the bulk command, so that they
+// can be applied to every request.
+func changedFlags(cmd *cobra.Command) []flagValue {
+	var values []flagValue
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		switch f.Name {
+		case fromNdjsonFlag, parallelismFlag, continueOnErrorFlag:
+			return
+		}
+		v := flagValue{name: f.Name, value: f.Value.String()}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			v.slice = sv.GetSlice()
+		}
+		values = append(values, v)
+	})
+	return values
+}
+
+// setFlags sets the given flag values on the command that runs a single line, or
+// on its group. Flags inherited from the root command are left as is.
+func setFlags(cmd, group *cobra.Command, values []flagValue) error {
+	for _, v := range values {
+		f := cmd.Flags().Lookup(v.name)
+		if f == nil {
+			f = group.PersistentFlags().Lookup(v.name)
+		}
+		if f == nil {
+			continue
+		}
+		var err error
+		if sv, ok := f.Value.(pflag.SliceValue); ok && v.slice != nil {
+			err = sv.Replace(v.slice)
+		} else {
+			err = f.Value.Set(v.value)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value for --%s: %w", v.name, err)
+		}
+		f.Changed = true
+	}
+	return nil
+}
+
+// lineResult is the result of the request on a single line of the input.
+type lineResult struct {
+	Line     int             `json:"line"`
+	Status   string          `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    *lineError      `json:"error,omitempty"`
+}
+
+type lineError struct {
+	Message    string            `json:"message"`
+	ErrorCode  string            `json:"error_code,omitempty"`
+	StatusCode int               `json:"status_code,omitempty"`
+	Reason     string            `json:"reason,omitempty"`
+	Domain     string            `json:"domain,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+func newLineError(err error) *lineError {
+	le := &lineError{Message: err.Error()}
+	var argsErr *root.InvalidArgsError
+	if errors.As(err, &argsErr) {
+		// Leave out the usage string.
+		le.Message = argsErr.Message
+	}
+	var apiErr *apierr.APIError
+	if errors.As(err, &apiErr) {
+		le.Message = apiErr.Message
+		le.ErrorCode = apiErr.ErrorCode
+		le.StatusCode = apiErr.StatusCode
+		if info := apiErr.ErrorDetails().ErrorInfo; info != nil {
+			le.Reason = info.Reason
+			le.Domain = info.Domain
+			le.Metadata = info.Metadata
+		}
+	}
+	return le
+}
+
+type runner struct {
+	// name is the name of the command in the groups returned by newGroup.
+	name     string
+	newGroup func() *cobra.Command
+
+	// inherited are the flags the group inherits from the root command, such as
+	// --profile. values are the other flags that were set on the bulk command.
+	inherited *pflag.FlagSet
+	values    []flagValue
+
+	parallelism     int
+	continueOnError bool
+}
+
+// run runs the request on every line of in, with at most parallelism requests in
+// flight, and writes their results to out in the order of the input. Unless
+// continueOnError is set, no new requests are started after one fails.
+func (r *runner) run(ctx context.Context, in io.Reader, out io.Writer) error {
+	queue := make(chan chan lineResult, r.parallelism)
+	sem := make(chan struct{}, r.parallelism)
+	var stopped atomic.Bool
+	var readErr error
+
+	go func() {
+		defer close(queue)
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(nil, maxLineSize)
+		for n := 1; scanner.Scan(); n++ {
+			if stopped.Load() {
+				return
+			}
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			line = bytes.Clone(line)
+			sem <- struct{}{}
+			if stopped.Load() {
+				return
+			}
+			ch := make(chan lineResult, 1)
+			queue <- ch
+			go func() {
+				defer func() { <-sem }()
+				res := r.runLine(ctx, n, line)
+				if res.Error != nil && !r.continueOnError {
+					stopped.Store(true)
+				}
+				ch <- res
+			}()
+		}
+		readErr = scanner.Err()
+	}()
+
+	enc := json.NewEncoder(out)
+	total, failed, firstFailed := 0, 0, 0
+	for ch := range queue {
+		res := <-ch
+		total++
+		if res.Error != nil {
+			failed++
+			if firstFailed == 0 {
+				firstFailed = res.Line
+			}
+		}
+		err := enc.Encode(res)
+		if err != nil {
+			stopped.Store(true)
+			for range queue {
+			}
+			return err
+		}
+	}
+
+	if readErr != nil {
+		return readErr
+	}
+	if failed == 0 {
+		return nil
+	}
+	if !r.continueOnError {
+		return fmt.Errorf("stopped after the request on line %d failed; use --continue-on-error to run the remaining lines", firstFailed)
+	}
+	return fmt.Errorf("%d of %d requests failed", failed, total)
+}
+
+func (r *runner) runLine(ctx context.Context, n int, line []byte) lineResult {
+	res := lineResult{Line: n, Status: "success"}
+	response, err := r.execute(ctx, line)
+	if err != nil {
+		res.Status = "error"
+		res.Error = newLineError(err)
+		return res
+	}
+	res.Response = response
+	return res
+}
+
+func (r *runner) execute(ctx context.Context, line []byte) (json.RawMessage, error) {
+	args, body, err := parseLine(line)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, cmd, err := r.newCommand()
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		err = cmd.Flags().Set("json", string(body))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Capture the rendered response as JSON. The query of the bulk command
+	// applies to its own output, not to the individual responses.
+	var out bytes.Buffer
+	ctx = cmdio.InContext(ctx, cmdio.NewIO(ctx, flags.OutputJSON, nil, &out, io.Discard, "", ""))
+	ctx = cmdio.WithoutQuery(ctx)
+
+	// Lines run concurrently, so they cannot prompt for a profile.
+	ctx = root.SkipPrompt(ctx)
+
+	// Run the line like a regular invocation of the command, so that its
+	// argument validation and pre-run hooks apply. The hooks of the root command
+	// already ran for the bulk command and configured ctx. Positional arguments
+	// follow "--" so that they are not parsed as flags.
+	parent.SetArgs(append([]string{cmd.Parent().Name(), cmd.Name(), "--"}, args...))
+	_, err = parent.ExecuteContextC(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := bytes.TrimSpace(out.Bytes())
+	if len(response) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(response) {
+		return json.Marshal(string(response))
+	}
+	return response, nil
+}
+
+// newCommand returns a fresh instance of the command and the root of its tree to
+// execute it from. The root has the flags that the group inherits, and the other
+// flags that were set on the bulk command are set on the fresh instances.
+func (r *runner) newCommand() (*cobra.Command, *cobra.Command, error) {
+	parent := &cobra.Command{
+		Use:           "databricks",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	parent.SetOut(io.Discard)
+	parent.SetErr(io.Discard)
+	r.inherited.VisitAll(func(f *pflag.Flag) {
+		// Lines run concurrently and cobra updates the flags it adds to a command.
+		copied := *f
+		parent.PersistentFlags().AddFlag(&copied)
+	})
+
+	group := r.newGroup()
+	parent.AddCommand(group)
+	for _, cmd := range group.Commands() {
+		if cmd.Name() == r.name {
+			return parent, cmd, setFlags(cmd, group, r.values)
+		}
+	}
+	return nil, nil, fmt.Errorf("command %s %s not found", group.Name(), r.name)
+}
+
+// parseLine parses a line of input. A line is either a JSON object with the request
+// body, or a JSON array of positional arguments optionally followed by an object
+// with the request body.
+func parseLine(line []byte) ([]string, json.RawMessage, error) {
+	switch line[0] {
+	case '{':
+		if !json.Valid(line) {
+			return nil, nil, errors.New("invalid JSON")
+		}
+		return nil, line, nil
+	case '[':
+		var elems []json.RawMessage
+		err := json.Unmarshal(line, &elems)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		var body json.RawMessage
+		if len(elems) > 0 && bytes.HasPrefix(elems[len(elems)-1], []byte("{")) {
+			body = elems[len(elems)-1]
+			elems = elems[:len(elems)-1]
+		}
+		args := make([]string, 0, len(elems))
+		for _, elem := range elems {
+			var arg string
+			err := json.Unmarshal(elem, &arg)
+			if err != nil {
+				return nil, nil, fmt.Errorf("positional arguments must be strings, got %s", elem)
+			}
+			args = append(args, arg)
+		}
+		return args, body, nil
+	default:
+		return nil, nil, errors.New("expected a JSON object with the request body or an array of positional arguments")
+	}
+}
@@ -0,0 +1,193 @@
+package bulk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/flags"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestGroup returns a command group shaped like the generated commands: the
+// request is captured by the closure of each command.
+func newTestGroup(calls *atomic.Int32) *cobra.Command {
+	group := &cobra.Command{Use: "things"}
+
+	create := &cobra.Command{Use: "create PARENT"}
+	var createReq struct {
+		Name string `json:"name"`
+		Size int    `json:"size"`
+	}
+	var createJson flags.JsonFlag
+	var dryRun bool
+	create.Flags().Var(&createJson, "json", "request body")
+	create.Flags().BoolVar(&dryRun, "dry-run", false, "")
+	create.Args = root.ExactArgs(1)
+	create.RunE = func(cmd *cobra.Command, args []string) error {
+		calls.Add(1)
+		if cmd.Flags().Changed("json") {
+			diags := createJson.Unmarshal(&createReq)
+			if diags.HasError() {
+				return diags.Error()
+			}
+		}
+		if createReq.Name == "taken" {
+			return &apierr.APIError{ErrorCode: "RESOURCE_ALREADY_EXISTS", Message: "thing taken already exists", StatusCode: 409}
+		}
+		return cmdio.Render(cmd.Context(), map[string]any{
+			"parent":  args[0],
+			"name":    createReq.Name,
+			"size":    createReq.Size,
+			"dry_run": dryRun,
+		})
+	}
+
+	get := &cobra.Command{Use: "get", RunE: func(*cobra.Command, []string) error { return nil }}
+	get.Flags().Var(&createJson, "json", "request body")
+
+	group.AddCommand(create, get)
+	return group
+}
+
+func runBulk(t *testing.T, input string, args ...string) (string, error, int32) {
+	var calls atomic.Int32
+	newGroup := func() *cobra.Command { return newTestGroup(&calls) }
+	group := newGroup()
+	Enable(group, newGroup)
+
+	var out bytes.Buffer
+	group.SetArgs(append([]string{"create", "--from-ndjson", "-"}, args...))
+	group.SetIn(strings.NewReader(input))
+	group.SetOut(&out)
+	group.SilenceErrors = true
+	group.SilenceUsage = true
+	err := group.ExecuteContext(t.Context())
+	return out.String(), err, calls.Load()
+}
+
+func TestEnableOnlyMutatingCommands(t *testing.T) {
+	var calls atomic.Int32
+	group := newTestGroup(&calls)
+	Enable(group, nil)
+
+	create, _, err := group.Find([]string{"create"})
+	require.NoError(t, err)
+	assert.NotNil(t, create.Flags().Lookup("from-ndjson"))
+
+	get, _, err := group.Find([]string{"get"})
+	require.NoError(t, err)
+	assert.Nil(t, get.Flags().Lookup("from-ndjson"))
+}
+
+func TestBulkRun(t *testing.T) {
+	input := `["a", {"name": "one", "size": 1}]
+
+["b", {"name": "two"}]
+["c"]
+`
+	out, err, calls := runBulk(t, input, "--dry-run", "--parallelism", "2")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls)
+	assert.Equal(t, `{"line":1,"status":"success","response":{"dry_run":true,"name":"one","parent":"a","size":1}}
+{"line":3,"status":"success","response":{"dry_run":true,"name":"two","parent":"b","size":0}}
+{"line":4,"status":"success","response":{"dry_run":true,"name":"","parent":"c","size":0}}
+`, out)
+}
+
+func TestBulkRunStopsOnError(t *testing.T) {
+	input := `["a", {"name": "taken"}]
+["b", {"name": "two"}]
+`
+	out, err, calls := runBulk(t, input, "--parallelism", "1")
+	assert.EqualError(t, err, "stopped after the request on line 1 failed; use --continue-on-error to run the remaining lines")
+	assert.Equal(t, int32(1), calls)
+	assert.Equal(t, `{"line":1,"status":"error","error":{"message":"thing taken already exists","error_code":"RESOURCE_ALREADY_EXISTS","status_code":409}}`+"\n", out)
+}
+
+func TestBulkRunContinueOnError(t *testing.T) {
+	input := `["a", {"name": "taken"}]
+{"name": "no-parent"}
+"text"
+["b", {"name": "two"}]
+`
+	out, err, calls := runBulk(t, input, "--continue-on-error")
+	assert.EqualError(t, err, "3 of 4 requests failed")
+	assert.Equal(t, int32(2), calls)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 4)
+	assert.Contains(t, lines[0], `"line":1,"status":"error"`)
+	assert.Equal(t, `{"line":2,"status":"error","error":{"message":"accepts 1 arg(s), received 0"}}`, lines[1])
+	assert.Equal(t, `{"line":3,"status":"error","error":{"message":"expected a JSON object with the request body or an array of positional arguments"}}`, lines[2])
+	assert.Contains(t, lines[3], `{"line":4,"status":"success"`)
+}
+
+func TestBulkRunUsesCommandHooks(t *testing.T) {
+	var calls atomic.Int32
+	newGroup := func() *cobra.Command {
+		group := newTestGroup(&calls)
+		group.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+			if profile := cmd.Flag("profile").Value.String(); profile != "dev" {
+				return fmt.Errorf("unexpected profile %q", profile)
+			}
+			return nil
+		}
+		create, _, _ := group.Find([]string{"create"})
+		create.PreRunE = func(cmd *cobra.Command, args []string) error {
+			// The pre-run hook also runs for the bulk command itself, without arguments.
+			if len(args) > 0 && args[0] == "" {
+				return errors.New("PARENT must not be empty")
+			}
+			return nil
+		}
+		return group
+	}
+
+	cli := &cobra.Command{Use: "databricks", SilenceErrors: true, SilenceUsage: true}
+	cli.PersistentFlags().String("profile", "", "")
+	group := newGroup()
+	cli.AddCommand(group)
+	Enable(group, newGroup)
+
+	var out bytes.Buffer
+	cli.SetArgs([]string{"things", "create", "--from-ndjson", "-", "--profile", "dev", "--continue-on-error"})
+	cli.SetIn(strings.NewReader(`["a", {"name": "one"}]` + "\n" + `[""]` + "\n" + `["--b"]` + "\n"))
+	cli.SetOut(&out)
+	err := cli.ExecuteContext(t.Context())
+	assert.EqualError(t, err, "1 of 3 requests failed")
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, `{"line":1,"status":"success","response":{"dry_run":false,"name":"one","parent":"a","size":0}}
+{"line":2,"status":"error","error":{"message":"PARENT must not be empty"}}
+{"line":3,"status":"success","response":{"dry_run":false,"name":"","parent":"--b","size":0}}
+`, out.String())
+}
+
+func TestBulkRunRejectsArgs(t *testing.T) {
+	_, err, _ := runBulk(t, "", "extra")
+	assert.EqualError(t, err, "when --from-ndjson flag is specified, no positional arguments are allowed. Provide them on each line of the input")
+}
+
+func TestParseLine(t *testing.T) {
+	args, body, err := parseLine([]byte(`["x", "y"]`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x", "y"}, args)
+	assert.Nil(t, body)
+
+	_, _, err = parseLine([]byte(`[1]`))
+	assert.EqualError(t, err, "positional arguments must be strings, got 1")
+
+	_, _, err = parseLine([]byte(`{"a":`))
+	assert.EqualError(t, err, "invalid JSON")
+
+	_, _, err = parseLine([]byte(`not json`))
+	assert.Error(t, err)
+}
@@ -1,8 +1,6 @@
 package debug
 
 import (
-	"encoding/json"
-	"fmt"
 	"maps"
 	"slices"
 	"strings"
@@ -96,14 +94,8 @@ func NewListTargetsCommand() *cobra.Command {
 				}
 				cmdio.LogString(ctx, strings.Join(parts, " "))
 			}
-		case flags.OutputJSON:
-			buf, err := json.MarshalIndent(listTargetsOutput{Targets: targets}, "", "  ")
-			if err != nil {
-				return err
-			}
-			_, _ = cmd.OutOrStdout().Write(buf)
 		default:
-			return fmt.Errorf("unknown output type %s", root.OutputType(cmd))
+			return cmdio.Render(ctx, listTargetsOutput{Targets: targets})
 		}
 
 		return nil
@@ -20,6 +20,11 @@ Common patterns:
   databricks bundle deploy --target dev     # Deploy to development
   databricks bundle deploy --target prod    # Deploy to production
 
+If a resource fails to deploy, the resources that do not depend on it are still deployed and
+the command lists the resources that were applied, failed and skipped. Use --stop-on-error to
+start no further resources after a failure, and --retry-failed to deploy only the resources
+that previous deployments did not complete.
+
 See https://docs.databricks.com/en/dev-tools/bundles/index.html for more information.`,
 		Args: root.NoArgs,
 	}
@@ -33,6 +38,8 @@ See https://docs.databricks.com/en/dev-tools/bundles/index.html for more informa
 	var quiet int
 	var readPlanPath string
 	var selectResources []string
+	var stopOnError bool
+	var retryFailed bool
 	cmd.Flags().BoolVar(&force, "force", false, "Force-override Git branch validation.")
 	cmd.Flags().BoolVar(&forceLock, "force-lock", false, "Force acquisition of deployment lock.")
 	cmd.Flags().BoolVar(&failOnActiveRuns, "fail-on-active-runs", false, "Fail if there are running jobs or pipelines in the deployment.")
@@ -44,6 +51,10 @@ See https://docs.databricks.com/en/dev-tools/bundles/index.html for more informa
 	cmd.Flags().CountVarP(&quiet, "quiet", "q", "Reduce output: -q prints only the summary, -qq prints only warnings and errors.")
 	cmd.Flags().StringVar(&readPlanPath, "plan", "", "Path to a JSON plan file to apply instead of planning (direct engine only).")
 	cmd.Flags().StringSliceVar(&selectResources, "select", nil, "Deploy only the specified resource (e.g. 'my_job' or 'jobs.my_job'). Can be repeated or comma-separated.")
+	cmd.Flags().BoolVar(&stopOnError, "stop-on-error", false, "Start no further resources after a resource fails to deploy (direct engine only).")
+	cmd.Flags().BoolVar(&retryFailed, "retry-failed", false, "Deploy only the resources that failed or were not deployed by previous deployments (direct engine only).")
+	cmd.MarkFlagsMutuallyExclusive("retry-failed", "select")
+	cmd.MarkFlagsMutuallyExclusive("retry-failed", "plan")
 	// Verbose flag currently only affects file sync output, it's used by the vscode extension
 	cmd.Flags().MarkHidden("verbose")
 
@@ -54,6 +65,8 @@ See https://docs.databricks.com/en/dev-tools/bundles/index.html for more informa
 				utils.SetForceLock(cmd, b, forceLock)
 				b.AutoApprove = autoApprove
 				b.Select = selectResources
+				b.StopOnError = stopOnError
+				b.RetryFailed = retryFailed
 				b.Quiet = bundle.QuietLevel(quiet)
 
 				if cmd.Flag("compute-id").Changed {
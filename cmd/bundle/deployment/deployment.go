@@ -28,5 +28,8 @@ After binding, the existing workspace resource will be managed by your bundle.`,
 	cmd.AddCommand(newBindCommand())
 	cmd.AddCommand(newUnbindCommand())
 	cmd.AddCommand(newMigrateCommand())
+	cmd.AddCommand(newStateCommand())
+	cmd.AddCommand(newLockCommand())
+	cmd.AddCommand(newRollbackCommand())
 	return cmd
 }
@@ -0,0 +1,176 @@
+package deployment
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"time"
+
+	"github.com/databricks/cli/cmd/bundle/utils"
+	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/locker"
+	"github.com/spf13/cobra"
+)
+
+func newLockCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Inspect and release the deployment lock",
+		Long: `Inspect and release the deployment lock.
+
+Deployments acquire a lock on the bundle's state directory in the workspace so
+that only one deployment of a target runs at a time. The holder refreshes the
+lock while it runs; a lock that has not been refreshed for the duration of its
+lease was abandoned (for example by a killed CI runner) and is taken over by the
+next deployment.`,
+	}
+
+	cmd.AddCommand(newLockStatusCommand())
+	cmd.AddCommand(newLockReleaseCommand())
+	return cmd
+}
+
+// lockStatus describes the deployment lock for output.
+type lockStatus struct {
+	Path            string     `json:"path"`
+	Held            bool       `json:"held"`
+	User            string     `json:"user,omitempty"`
+	Host            string     `json:"host,omitempty"`
+	CLIVersion      string     `json:"cli_version,omitempty"`
+	Forced          bool       `json:"forced,omitempty"`
+	AcquisitionTime *time.Time `json:"acquisition_time,omitempty"`
+	HeartbeatTime   *time.Time `json:"heartbeat_time,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	Expired         bool       `json:"expired,omitempty"`
+}
+
+// readLock returns a locker for the bundle's state directory and the state of
+// the lock, or nil if no lock is held.
+func readLock(cmd *cobra.Command) (*locker.Locker, *locker.LockState, *lockStatus, error) {
+	b, err := utils.ProcessBundle(cmd, utils.ProcessOptions{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ctx := cmd.Context()
+	dir := b.Config.Workspace.StatePath
+	l, err := locker.CreateLocker(b.Config.Workspace.CurrentUser.UserName, dir, b.WorkspaceClient(ctx))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	status := &lockStatus{Path: path.Join(dir, locker.LockFileName)}
+	state, err := l.GetActiveLockState(ctx)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil, status, nil
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	status.Held = true
+	status.User = state.User
+	status.Host = state.Host
+	status.CLIVersion = state.CLIVersion
+	status.Forced = state.IsForced
+	status.AcquisitionTime = &state.AcquisitionTime
+	if !state.HeartbeatTime.IsZero() {
+		status.HeartbeatTime = &state.HeartbeatTime
+	}
+	if expiresAt, ok := state.ExpiresAt(); ok {
+		status.ExpiresAt = &expiresAt
+		status.Expired = state.IsExpired(time.Now())
+	}
+	return l, state, status, nil
+}
+
+// age formats the time elapsed since t.
+func age(t time.Time) string {
+	return time.Since(t).Round(time.Second).String() + " ago"
+}
+
+// AcquiredAge is the time elapsed since the lock was acquired, for the text output.
+func (s *lockStatus) AcquiredAge() string {
+	return age(*s.AcquisitionTime)
+}
+
+// RefreshedAge is the time elapsed since the lock was refreshed, for the text output.
+func (s *lockStatus) RefreshedAge() string {
+	return age(*s.HeartbeatTime)
+}
+
+const lockStatusTemplate = `{{if not .Held}}No deployment lock is held.
+{{else}}Deployment lock held by {{.User}}
+  Host:        {{.Host}}
+  CLI version: {{.CLIVersion}}
+  Acquired:    {{.AcquisitionTime.Format "2006-01-02T15:04:05Z07:00"}} ({{.AcquiredAge}})
+{{if .HeartbeatTime}}  Refreshed:   {{.HeartbeatTime.Format "2006-01-02T15:04:05Z07:00"}} ({{.RefreshedAge}})
+{{end}}{{if not .ExpiresAt}}  Expires:     never (acquired by a CLI version without lock leases)
+{{else if .Expired}}  Expired:     {{.ExpiresAt.Format "2006-01-02T15:04:05Z07:00"}}; the next deployment takes over the lock
+{{else}}  Expires:     {{.ExpiresAt.Format "2006-01-02T15:04:05Z07:00"}}
+{{end}}{{if .Forced}}  The lock was acquired with --force-lock.
+{{end}}{{end}}`
+
+func newLockStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the holder of the deployment lock",
+		Args:  root.NoArgs,
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		_, _, status, err := readLock(cmd)
+		if err != nil {
+			return err
+		}
+
+		return cmdio.RenderWithTemplate(cmd.Context(), status, "", lockStatusTemplate)
+	}
+
+	return cmd
+}
+
+func newLockReleaseCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: "Release an abandoned deployment lock",
+		Long: `Release an abandoned deployment lock.
+
+Deletes the lock file regardless of who holds the lock. A lock whose lease has
+not expired may belong to a deployment that is still running; releasing it
+requires --force, and the running deployment no longer has exclusive access.`,
+		Args: root.NoArgs,
+	}
+
+	var force bool
+	cmd.Flags().BoolVar(&force, "force", false, "Release the lock even if its lease has not expired.")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		l, state, status, err := readLock(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		if state == nil {
+			cmdio.LogString(ctx, "No deployment lock is held.")
+			return nil
+		}
+		if !status.Expired && !force {
+			return fmt.Errorf("deployment lock acquired by %s on %s at %v has not expired.\n"+
+				"Another deployment may be in progress. Use --force to release it anyway",
+				state.User, state.Host, state.AcquisitionTime)
+		}
+
+		err = l.Release(ctx)
+		if err != nil {
+			return err
+		}
+		cmdio.LogString(ctx, "Released deployment lock held by "+state.User)
+		return nil
+	}
+
+	return cmd
+}
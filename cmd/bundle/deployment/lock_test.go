@@ -0,0 +1,50 @@
+package deployment
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockStatusText(t *testing.T) {
+	ctx, stdout := cmdio.NewTestContextWithStdout(t.Context())
+	require.NoError(t, cmdio.RenderWithTemplate(ctx, &lockStatus{Path: "/state/deploy.lock"}, "", lockStatusTemplate))
+	assert.Equal(t, "No deployment lock is held.\n", stdout.String())
+
+	acquired := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := acquired.Add(time.Hour)
+	status := &lockStatus{
+		Path:            "/state/deploy.lock",
+		Held:            true,
+		User:            "someone@example.com",
+		Host:            "ci",
+		CLIVersion:      "0.300.0",
+		Forced:          true,
+		AcquisitionTime: &acquired,
+		ExpiresAt:       &expires,
+		Expired:         true,
+	}
+	ctx, stdout = cmdio.NewTestContextWithStdout(t.Context())
+	require.NoError(t, cmdio.RenderWithTemplate(ctx, status, "", lockStatusTemplate))
+	assert.Contains(t, stdout.String(), "Deployment lock held by someone@example.com\n")
+	assert.Contains(t, stdout.String(), "  Acquired:    2026-01-02T03:04:05Z (")
+	assert.NotContains(t, stdout.String(), "Refreshed")
+	assert.Contains(t, stdout.String(), "  Expired:     2026-01-02T04:04:05Z; the next deployment takes over the lock\n")
+	assert.Contains(t, stdout.String(), "  The lock was acquired with --force-lock.\n")
+}
+
+func TestLockStatusStructured(t *testing.T) {
+	acquired := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	status := &lockStatus{Path: "/state/deploy.lock", Held: true, User: "someone@example.com", AcquisitionTime: &acquired}
+
+	stdout := &bytes.Buffer{}
+	ctx := cmdio.InContext(t.Context(), cmdio.NewIO(t.Context(), flags.OutputYAML, nil, stdout, io.Discard, "", ""))
+	require.NoError(t, cmdio.RenderWithTemplate(ctx, status, "", lockStatusTemplate))
+	assert.Contains(t, stdout.String(), "user: someone@example.com\n")
+}
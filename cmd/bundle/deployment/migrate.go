@@ -45,6 +45,11 @@ func getCommonArgs(cmd *cobra.Command) string {
 			}
 		}
 	}
+	if flag := cmd.Flag("var-file"); flag != nil && flag.Changed {
+		if varFile := flag.Value.String(); varFile != "" {
+			quotedArgs = append(quotedArgs, "--var-file", shellquote.BashArg(varFile))
+		}
+	}
 
 	if len(quotedArgs) == 0 {
 		return ""
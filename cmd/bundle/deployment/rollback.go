@@ -0,0 +1,174 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/deploy"
+	"github.com/databricks/cli/bundle/phases"
+	"github.com/databricks/cli/bundle/statemgmt"
+	"github.com/databricks/cli/cmd/bundle/utils"
+	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/flags"
+	"github.com/databricks/cli/libs/logdiag"
+	"github.com/spf13/cobra"
+)
+
+func newRollbackCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback [SERIAL]",
+		Short: "Roll back resources to a previous successful deployment",
+		Long: `Roll back resources to a previous successful deployment.
+
+Rollback is only supported with the direct deployment engine. Every successful deployment
+with the direct engine saves a snapshot of its resolved resource configuration and the list
+of its workspace files in the workspace state directory, identified by the serial of the
+deployment state. The last 5 snapshots are kept; set bundle.deployment.keep_deployments to
+keep a different number.
+
+This command re-applies the resources of the snapshot with the given serial, or of the
+deployment before the latest one if no serial is given. The changes are planned and
+approved like a regular deployment.
+
+Files are only rolled back for deployments that used experimental.immutable_folder, whose
+files remain available at their content-addressed location. For other deployments the
+snapshot lists the files but does not contain them, so the command refuses to roll back
+unless --resources-only is given. The resources then keep referring to the files that are
+currently deployed.
+
+Examples:
+  databricks bundle deployment rollback --list            # List the deployments that can be rolled back to
+  databricks bundle deployment rollback                   # Roll back to the previous deployment
+  databricks bundle deployment rollback 12                # Roll back to the deployment with serial 12
+  databricks bundle deployment rollback --resources-only  # Roll back resources but keep the deployed files`,
+		Args: root.MaximumNArgs(1),
+	}
+
+	var list bool
+	var autoApprove bool
+	var resourcesOnly bool
+	cmd.Flags().BoolVar(&list, "list", false, "List the saved deployments instead of rolling back.")
+	cmd.Flags().BoolVar(&autoApprove, "auto-approve", false, "Skip interactive approvals that might be required for rollback.")
+	cmd.Flags().BoolVar(&resourcesOnly, "resources-only", false, "Roll back resources even if the files of the deployment cannot be restored.")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		serial := -1
+		if len(args) == 1 {
+			var err error
+			serial, err = strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid deployment serial %q", args[0])
+			}
+		}
+
+		var snapshots []*deploy.DeploymentSnapshot
+		_, _, err := utils.ProcessBundleRet(cmd, utils.ProcessOptions{
+			AlwaysPull: true,
+			InitFunc: func(b *bundle.Bundle) {
+				b.AutoApprove = autoApprove
+			},
+			PostStateFunc: func(ctx context.Context, b *bundle.Bundle, stateDesc *statemgmt.StateDesc) error {
+				if !stateDesc.Engine.IsDirect() {
+					return errors.New("rollback requires the direct deployment engine; deployments with the terraform engine do not save snapshots to roll back to. See https://docs.databricks.com/aws/en/dev-tools/bundles/direct")
+				}
+
+				f, err := deploy.StateFiler(ctx, b)
+				if err != nil {
+					return err
+				}
+				snapshots, err = deploy.ListDeploymentSnapshots(ctx, f)
+				if err != nil {
+					return err
+				}
+				if list {
+					return nil
+				}
+
+				snapshot, err := selectSnapshot(snapshots, serial)
+				if err != nil {
+					return err
+				}
+				err = checkFilesRestorable(snapshot, resourcesOnly)
+				if err != nil {
+					return err
+				}
+				err = snapshot.Restore(b)
+				if err != nil {
+					return err
+				}
+
+				cmdio.LogString(ctx, fmt.Sprintf("Rolling back to deployment %d of %s", snapshot.Serial, snapshot.Timestamp.Local().Format(time.RFC3339)))
+				if snapshot.SnapshotPath == "" {
+					cmdio.LogString(ctx, "Files are not rolled back; resources refer to the files currently deployed to "+snapshot.FilePath)
+				}
+
+				phases.Rollback(ctx, b, stateDesc.Engine)
+				if logdiag.HasError(ctx) {
+					return root.ErrAlreadyPrinted
+				}
+				return nil
+			},
+		})
+		if err != nil || !list {
+			return err
+		}
+
+		switch root.OutputType(cmd) {
+		case flags.OutputText:
+			if len(snapshots) == 0 {
+				cmdio.LogString(cmd.Context(), "No deployments saved.")
+				return nil
+			}
+			for _, s := range snapshots {
+				line := fmt.Sprintf("%d\t%s", s.Serial, s.Timestamp.Local().Format(time.RFC3339))
+				if s.GitCommit != "" {
+					line += "\t" + s.GitCommit
+				}
+				cmdio.LogString(cmd.Context(), line)
+			}
+		default:
+			// The resolved configuration is left out of the listing.
+			for _, s := range snapshots {
+				s.Resources = nil
+				s.Files = nil
+			}
+			return cmdio.Render(cmd.Context(), snapshots)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// selectSnapshot returns the snapshot with the given serial, or the one before the latest
+// deployment if serial is negative. snapshots are ordered most recent first.
+func selectSnapshot(snapshots []*deploy.DeploymentSnapshot, serial int) (*deploy.DeploymentSnapshot, error) {
+	if serial < 0 {
+		if len(snapshots) < 2 {
+			return nil, errors.New("no previous deployment to roll back to")
+		}
+		return snapshots[1], nil
+	}
+
+	for _, s := range snapshots {
+		if s.Serial == serial {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("no saved deployment with serial %d; use --list to show the saved deployments", serial)
+}
+
+// checkFilesRestorable returns an error if the files of the snapshot cannot be restored
+// and the user did not opt in to rolling back only the resources. Only the files of
+// immutable folder deployments remain available after later deployments.
+func checkFilesRestorable(snapshot *deploy.DeploymentSnapshot, resourcesOnly bool) error {
+	if snapshot.SnapshotPath != "" || resourcesOnly {
+		return nil
+	}
+	return fmt.Errorf("the files of deployment %d cannot be restored because it did not use experimental.immutable_folder; use --resources-only to roll back the resources and keep the files currently deployed to %s", snapshot.Serial, snapshot.FilePath)
+}
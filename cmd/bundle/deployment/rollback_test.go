@@ -0,0 +1,36 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/databricks/cli/bundle/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectSnapshot(t *testing.T) {
+	snapshots := []*deploy.DeploymentSnapshot{{Serial: 3}, {Serial: 2}, {Serial: 1}}
+
+	s, err := selectSnapshot(snapshots, -1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.Serial)
+
+	s, err = selectSnapshot(snapshots, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.Serial)
+
+	_, err = selectSnapshot(snapshots, 4)
+	assert.EqualError(t, err, "no saved deployment with serial 4; use --list to show the saved deployments")
+
+	_, err = selectSnapshot(snapshots[:1], -1)
+	assert.EqualError(t, err, "no previous deployment to roll back to")
+}
+
+func TestCheckFilesRestorable(t *testing.T) {
+	mutable := &deploy.DeploymentSnapshot{Serial: 2, FilePath: "/Workspace/bundle/files"}
+	immutable := &deploy.DeploymentSnapshot{Serial: 2, FilePath: "/Workspace/bundle/files", SnapshotPath: "/Workspace/bundle/snapshots/abc"}
+
+	assert.NoError(t, checkFilesRestorable(immutable, false))
+	assert.NoError(t, checkFilesRestorable(mutable, true))
+	assert.EqualError(t, checkFilesRestorable(mutable, false), "the files of deployment 2 cannot be restored because it did not use experimental.immutable_folder; use --resources-only to roll back the resources and keep the files currently deployed to /Workspace/bundle/files")
+}
@@ -0,0 +1,220 @@
+package deployment
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/direct/dstate"
+	"github.com/databricks/cli/bundle/phases"
+	"github.com/databricks/cli/bundle/statemgmt"
+	"github.com/databricks/cli/cmd/bundle/utils"
+	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/logdiag"
+	"github.com/spf13/cobra"
+)
+
+var errStateNotDirect = errors.New("this bundle uses the terraform deployment engine; the state commands require the direct deployment engine")
+
+// stateKey returns the full state key for a resource key given on the command line.
+// Both "jobs.my_job" and "resources.jobs.my_job" refer to the same resource.
+func stateKey(key string) string {
+	if strings.HasPrefix(key, "resources.") {
+		return key
+	}
+	return "resources." + key
+}
+
+func newStateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Inspect and edit the deployment state",
+		Long: `Inspect and edit the deployment state of the direct deployment engine.
+
+The deployment state records the workspace resource that each resource in the
+bundle configuration is deployed to. These commands change the state only; they
+never create, update or delete resources in the workspace.
+
+Resource keys are given as GROUP.NAME, for example jobs.my_job.
+
+To import an existing workspace resource into the state, use:
+  databricks bundle deployment bind <KEY> <RESOURCE_ID>`,
+	}
+
+	cmd.AddCommand(newStateListCommand())
+	cmd.AddCommand(newStateShowCommand())
+	cmd.AddCommand(newStateMvCommand())
+	cmd.AddCommand(newStateRmCommand())
+	return cmd
+}
+
+// readDirectState pulls the deployment state and returns its resources.
+func readDirectState(cmd *cobra.Command) (map[string]dstate.ResourceEntry, error) {
+	b, stateDesc, err := utils.ProcessBundleRet(cmd, utils.ProcessOptions{
+		AlwaysPull: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !stateDesc.Engine.IsDirect() {
+		return nil, errStateNotDirect
+	}
+
+	ctx := cmd.Context()
+	_, statePath := b.StateFilenameDirect(ctx)
+	return b.DeploymentBundle.ReadState(ctx, statePath)
+}
+
+type stateListEntry struct {
+	Key string `json:"key"`
+	ID  string `json:"id"`
+}
+
+func newStateListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List resources in the deployment state",
+		Args:  root.NoArgs,
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		state, err := readDirectState(cmd)
+		if err != nil {
+			return err
+		}
+
+		entries := make([]stateListEntry, 0, len(state))
+		for _, key := range slices.Sorted(maps.Keys(state)) {
+			entries = append(entries, stateListEntry{Key: key, ID: state[key].ID})
+		}
+
+		return cmdio.RenderWithTemplate(cmd.Context(), entries, "", "{{range .}}{{.Key}}\t{{.ID}}\n{{end}}")
+	}
+
+	return cmd
+}
+
+func newStateShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show KEY",
+		Short: "Show a resource in the deployment state",
+		Long: `Show the ID, recorded configuration and dependencies of a resource in the deployment state.
+
+Arguments:
+  KEY - The resource key, for example jobs.my_job`,
+		Args: root.ExactArgs(1),
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		state, err := readDirectState(cmd)
+		if err != nil {
+			return err
+		}
+
+		key := stateKey(args[0])
+		entry, ok := state[key]
+		if !ok {
+			return fmt.Errorf("resource %s not found in the deployment state", key)
+		}
+
+		return cmdio.Render(cmd.Context(), entry)
+	}
+
+	return cmd
+}
+
+// editDirectState loads the bundle for a state edit and runs edit on it.
+// The state is only read to determine the engine here; the edit pulls the
+// state again once it holds the deployment lock.
+func editDirectState(cmd *cobra.Command, forceLock bool, edit func(b *bundle.Bundle, stateDesc *statemgmt.StateDesc)) error {
+	b, stateDesc, err := utils.ProcessBundleRet(cmd, utils.ProcessOptions{
+		InitFunc: func(b *bundle.Bundle) {
+			utils.SetForceLock(cmd, b, forceLock)
+		},
+		ReadState: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	edit(b, stateDesc)
+	if logdiag.HasError(cmd.Context()) {
+		return root.ErrAlreadyPrinted
+	}
+	return nil
+}
+
+func newStateMvCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mv OLD_KEY NEW_KEY",
+		Short: "Move a resource to a different key in the deployment state",
+		Long: `Move a resource to a different key in the deployment state.
+
+Use this command after renaming a resource in the bundle configuration, so that
+the next deployment updates the existing workspace resource instead of deleting
+it and creating a new one. Permissions and grants of the resource are moved
+along with it.
+
+Arguments:
+  OLD_KEY - The resource key currently recorded in the state, for example jobs.old_name
+  NEW_KEY - The new resource key, for example jobs.new_name
+
+Examples:
+  # Rename a job resource from "etl" to "daily_etl" in databricks.yml, then:
+  databricks bundle deployment state mv jobs.etl jobs.daily_etl
+  databricks bundle deploy`,
+		Args: root.ExactArgs(2),
+	}
+
+	var forceLock bool
+	cmd.Flags().BoolVar(&forceLock, "force-lock", false, "Force acquisition of deployment lock.")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		from, to := stateKey(args[0]), stateKey(args[1])
+		return editDirectState(cmd, forceLock, func(b *bundle.Bundle, stateDesc *statemgmt.StateDesc) {
+			ctx := cmd.Context()
+			phases.StateMove(ctx, b, from, to, stateDesc.Engine)
+			if !logdiag.HasError(ctx) {
+				cmdio.LogString(ctx, fmt.Sprintf("Moved %s to %s", from, to))
+			}
+		})
+	}
+
+	return cmd
+}
+
+func newStateRmCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rm KEY",
+		Short: "Remove a resource from the deployment state",
+		Long: `Remove a resource from the deployment state.
+
+The workspace resource is not deleted and is no longer managed by the bundle.
+If the resource is still defined in the bundle configuration, the next
+deployment creates a new copy of it.
+
+Arguments:
+  KEY - The resource key, for example jobs.my_job`,
+		Args: root.ExactArgs(1),
+	}
+
+	var forceLock bool
+	cmd.Flags().BoolVar(&forceLock, "force-lock", false, "Force acquisition of deployment lock.")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		key := stateKey(args[0])
+		return editDirectState(cmd, forceLock, func(b *bundle.Bundle, stateDesc *statemgmt.StateDesc) {
+			ctx := cmd.Context()
+			phases.StateRemove(ctx, b, key, stateDesc.Engine)
+			if !logdiag.HasError(ctx) {
+				cmdio.LogString(ctx, "Removed "+key)
+			}
+		})
+	}
+
+	return cmd
+}
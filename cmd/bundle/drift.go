@@ -0,0 +1,98 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/configsync"
+	"github.com/databricks/cli/bundle/statemgmt"
+	"github.com/databricks/cli/cmd/bundle/utils"
+	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/flags"
+	"github.com/spf13/cobra"
+)
+
+// driftExitCode is the exit status of "bundle drift" when drift is detected.
+// It differs from the status 1 of a failed check, so that CI can tell them apart.
+const driftExitCode = 2
+
+// errDriftDetected is returned after the drift report has been printed.
+var errDriftDetected = &root.ExitCodeError{Code: driftExitCode, Err: root.ErrAlreadyPrinted}
+
+func newDriftCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Report changes made to deployed resources outside the bundle",
+		Long: `Report changes made to deployed resources outside the bundle.
+
+This command reads every resource in the deployment state from the workspace and compares it
+with the last deployed state and with the current configuration. Fields changed outside the
+bundle (for example in the UI or through the API) are reported, as well as resources deleted
+outside the bundle. Nothing is deployed.
+
+The command exits with status 2 when drift is detected and with status 1 when the check itself
+fails, so it can be used in scheduled CI checks. It requires the direct deployment engine.
+
+Examples:
+  databricks bundle drift                      # Report drift of all resources
+  databricks bundle drift --select jobs.my_job # Report drift of a single resource
+  databricks bundle drift -o json              # Report drift as JSON`,
+		Args: root.NoArgs,
+	}
+
+	var selectResources []string
+	cmd.Flags().StringSliceVar(&selectResources, "select", nil, "Check only the specified resource (e.g. 'my_job' or 'jobs.my_job'). Can be repeated or comma-separated.")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		var report *configsync.DriftReport
+
+		_, _, err := utils.ProcessBundleRet(cmd, utils.ProcessOptions{
+			ReadState:  true,
+			Build:      true,
+			AlwaysPull: true,
+			InitFunc: func(b *bundle.Bundle) {
+				b.Select = selectResources
+			},
+			PostStateFunc: func(ctx context.Context, b *bundle.Bundle, stateDesc *statemgmt.StateDesc) error {
+				if !stateDesc.Engine.IsDirect() {
+					return errors.New("bundle drift requires the direct deployment engine. See https://docs.databricks.com/aws/en/dev-tools/bundles/direct")
+				}
+
+				plan, err := b.DeploymentBundle.CalculatePlan(ctx, b.WorkspaceClient(ctx), &b.Config)
+				if err != nil {
+					return fmt.Errorf("failed to detect changes: %w", err)
+				}
+				if len(b.Select) > 0 {
+					plan.FilterToSelected(b.Select)
+				}
+
+				report = configsync.DetectDrift(plan, &b.DeploymentBundle.StateDB)
+				return nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		switch root.OutputType(cmd) {
+		case flags.OutputText:
+			fmt.Fprint(out, configsync.FormatDriftText(report))
+		default:
+			err := cmdio.Render(cmd.Context(), report)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(report.Resources) > 0 {
+			return errDriftDetected
+		}
+		return nil
+	}
+
+	return cmd
+}
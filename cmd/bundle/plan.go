@@ -1,15 +1,20 @@
 package bundle
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/deployplan"
 	"github.com/databricks/cli/bundle/phases"
+	"github.com/databricks/cli/bundle/scripts"
 	"github.com/databricks/cli/cmd/bundle/utils"
 	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
 	"github.com/databricks/cli/libs/flags"
 	"github.com/databricks/cli/libs/logdiag"
 	"github.com/spf13/cobra"
@@ -22,7 +27,17 @@ func newPlanCommand() *cobra.Command {
 		Long: `Show the deployment plan for the current bundle configuration.
 
 This command builds the bundle and displays the actions which will be done on resources that would be deployed, without making any changes.
-It is useful for previewing changes before running 'bundle deploy'.`,
+It is useful for previewing changes before running 'bundle deploy'.
+
+With --estimate, the plan also summarizes how the compute of each planned resource changes
+(node types, worker counts, autoscaling, warehouse size and serverless vs classic compute).
+The estimate is derived from the deployed and planned resource state and requires the direct deployment engine.
+
+With --diff, the plan lists every changed field with its old and new value, whether the change
+comes from the configuration or from changes made outside the bundle (remote drift), and whether
+it forces the resource to be recreated. With --output json, the diff is printed in a versioned
+format (see "diff_version") suitable for automated review. Sensitive values are redacted.
+The diff requires the direct deployment engine.`,
 		Args: root.NoArgs,
 	}
 
@@ -30,12 +45,16 @@ It is useful for previewing changes before running 'bundle deploy'.`,
 	var quiet int
 	var clusterId string
 	var selectResources []string
+	var estimate bool
+	var showDiff bool
 	cmd.Flags().BoolVar(&force, "force", false, "Force-override Git branch validation.")
 	cmd.Flags().CountVarP(&quiet, "quiet", "q", "Reduce output: -q prints only the summary, -qq prints nothing on success.")
 	cmd.Flags().StringVar(&clusterId, "compute-id", "", "Override cluster in the deployment with the given compute ID.")
 	cmd.Flags().StringVarP(&clusterId, "cluster-id", "c", "", "Override cluster in the deployment with the given cluster ID.")
 	cmd.Flags().MarkDeprecated("compute-id", "use --cluster-id instead")
 	cmd.Flags().StringSliceVar(&selectResources, "select", nil, "Plan only the specified resource (e.g. 'my_job' or 'jobs.my_job'). Can be repeated or comma-separated.")
+	cmd.Flags().BoolVar(&showDiff, "diff", false, "Show field-level changes with their old and new values.")
+	cmd.Flags().BoolVar(&estimate, "estimate", false, "Summarize compute footprint changes (node types, workers, warehouse size) of the plan.")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		opts := utils.ProcessOptions{
@@ -63,6 +82,18 @@ It is useful for previewing changes before running 'bundle deploy'.`,
 		}
 		ctx := cmd.Context()
 
+		if estimate && !stateDesc.Engine.IsDirect() {
+			return errors.New("--estimate is only supported by the direct deployment engine")
+		}
+		if showDiff && !stateDesc.Engine.IsDirect() {
+			return errors.New("--diff is only supported by the direct deployment engine")
+		}
+
+		bundle.ApplyContext(ctx, b, scripts.Execute(config.ScriptPrePlan))
+		if logdiag.HasError(ctx) {
+			return root.ErrAlreadyPrinted
+		}
+
 		plan := phases.RunPlan(ctx, b, stateDesc.Engine)
 		if logdiag.HasError(ctx) {
 			return root.ErrAlreadyPrinted
@@ -70,19 +101,36 @@ It is useful for previewing changes before running 'bundle deploy'.`,
 
 		counts := plan.CountActions()
 
+		var est *deployplan.ComputeEstimate
+		if estimate {
+			est, err = plan.Estimate()
+			if err != nil {
+				return err
+			}
+		}
+
 		out := cmd.OutOrStdout()
 
 		switch root.OutputType(cmd) {
 		case flags.OutputText:
 			// Print summary line and actions to stdout
-			totalChanges := counts.Create + counts.Change + counts.Delete
-			if totalChanges > 0 && bundle.QuietLevel(quiet) < bundle.QuietSummary {
+			totalChanges := counts.Create + counts.Change + counts.Delete + counts.Move
+			if showDiff && totalChanges > 0 && bundle.QuietLevel(quiet) < bundle.QuietSummary {
+				err := plan.Diff().WriteText(out)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out)
+			} else if totalChanges > 0 && bundle.QuietLevel(quiet) < bundle.QuietSummary {
 				// Print all actions in the order they were processed
 				for _, action := range plan.GetActions() {
+					key := strings.TrimPrefix(action.ResourceKey, "resources.")
+					if action.MovedFrom != "" && !action.IsChildResource() {
+						fmt.Fprintf(out, "move %s -> %s\n", strings.TrimPrefix(action.MovedFrom, "resources."), key)
+					}
 					if action.ActionType == deployplan.Skip {
 						continue
 					}
-					key := strings.TrimPrefix(action.ResourceKey, "resources.")
 					fmt.Fprintf(out, "%s %s\n", action.ActionType.StringShort(), key)
 				}
 				fmt.Fprintln(out)
@@ -96,12 +144,22 @@ It is useful for previewing changes before running 'bundle deploy'.`,
 				fmt.Fprintf(out, ", %d not selected", plan.NotSelected)
 			}
 			fmt.Fprintln(out)
-		case flags.OutputJSON:
-			buf, err := json.MarshalIndent(plan, "", "  ")
+			if est != nil {
+				printEstimate(out, est)
+			}
+		default:
+			var v any = plan
+			if showDiff {
+				diff := plan.Diff()
+				diff.Estimate = est
+				v = diff
+			} else if est != nil {
+				v = planWithEstimate{Plan: plan, Estimate: est}
+			}
+			err := cmdio.Render(ctx, v)
 			if err != nil {
 				return err
 			}
-			fmt.Fprintln(out, string(buf))
 			if logdiag.HasError(ctx) {
 				return root.ErrAlreadyPrinted
 			}
@@ -117,3 +175,65 @@ It is useful for previewing changes before running 'bundle deploy'.`,
 
 	return cmd
 }
+
+// planWithEstimate is the JSON output of "bundle plan --estimate": the plan with an
+// additional top-level "estimate" field.
+type planWithEstimate struct {
+	*deployplan.Plan
+	Estimate *deployplan.ComputeEstimate `json:"estimate"`
+}
+
+func printEstimate(out io.Writer, est *deployplan.ComputeEstimate) {
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Compute estimate:")
+	if len(est.Resources) == 0 {
+		fmt.Fprintln(out, "  no compute changes")
+	}
+	for _, r := range est.Resources {
+		fmt.Fprintf(out, "  %s %s\n", r.Action.StringShort(), strings.TrimPrefix(r.ResourceKey, "resources."))
+		for _, line := range estimateLines(r) {
+			fmt.Fprintf(out, "    %s\n", line)
+		}
+	}
+	fmt.Fprintf(out, "Peak workers: %d -> %d (%+d)\n", est.PeakWorkersBefore, est.PeakWorkersAfter, est.PeakWorkersAfter-est.PeakWorkersBefore)
+}
+
+// estimateLines pairs the compute of a resource before and after the plan by name
+// and returns a line for each compute that changes.
+func estimateLines(r deployplan.ResourceEstimate) []string {
+	var names []string
+	for _, s := range append(slices.Clone(r.Before), r.After...) {
+		if !slices.Contains(names, s.Name) {
+			names = append(names, s.Name)
+		}
+	}
+
+	find := func(shapes []deployplan.ComputeShape, name string) *deployplan.ComputeShape {
+		i := slices.IndexFunc(shapes, func(s deployplan.ComputeShape) bool { return s.Name == name })
+		if i < 0 {
+			return nil
+		}
+		return &shapes[i]
+	}
+	describe := func(s *deployplan.ComputeShape) string {
+		if s == nil {
+			return "none"
+		}
+		return s.String()
+	}
+
+	var lines []string
+	for _, name := range names {
+		before := find(r.Before, name)
+		after := find(r.After, name)
+		if before != nil && after != nil && *before == *after {
+			continue
+		}
+		prefix := ""
+		if name != "" {
+			prefix = name + ": "
+		}
+		lines = append(lines, fmt.Sprintf("%s%s -> %s", prefix, describe(before), describe(after)))
+	}
+	return lines
+}
@@ -2,7 +2,6 @@ package bundle
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"maps"
@@ -15,6 +14,7 @@ import (
 	"github.com/databricks/cli/bundle/resources"
 	"github.com/databricks/cli/bundle/run"
 	"github.com/databricks/cli/bundle/run/output"
+	"github.com/databricks/cli/bundle/scripts"
 	"github.com/databricks/cli/bundle/statemgmt"
 	"github.com/databricks/cli/cmd/bundle/utils"
 	"github.com/databricks/cli/cmd/root"
@@ -87,8 +87,8 @@ func keyToRunner(b *bundle.Bundle, arg string) (run.Runner, error) {
 func newRunCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "run [flags] [KEY]",
-		Short: "Run a job, pipeline update or app",
-		Long: `Run the job, pipeline or app identified by KEY.
+		Short: "Run a job, pipeline update, app or other resource",
+		Long: `Run the job, pipeline, app or other resource identified by KEY.
 
 The KEY is the unique identifier of the resource to run. In addition to
 customizing the run using any of the available flags, you can also specify
@@ -107,6 +107,15 @@ parameter names.
 If the specified job does not use job parameters and the job has a Python file
 task or a Python wheel task, the second example applies.
 
+Other resources deployed by the bundle can be run as well:
+  - dashboards are published, which refreshes their data;
+  - quality monitors refresh their metric tables;
+  - Delta Sync vector search indexes are synced with their source table;
+  - model serving endpoints are checked to be ready. A JSON request passed as
+    positional argument is sent to the endpoint:
+
+   databricks bundle run my_endpoint '{"inputs": [[1, 2, 3]]}'
+
 ---------------------------------------------------------
 
 You can also use the bundle run command to execute scripts / commands in the same
@@ -183,15 +192,27 @@ Example usage:
 				}
 
 				runOptions.NoWait = noWait
+				hookEnv := map[string]string{config.ScriptRunKeyEnv: runner.Key()}
+				bundle.ApplyContext(ctx, b, scripts.ExecuteWithEnv(config.ScriptPreRun, hookEnv))
+				if logdiag.HasError(ctx) {
+					return root.ErrAlreadyPrinted
+				}
+
 				var runOutput output.RunOutput
 				if restart {
 					runOutput, err = runner.Restart(ctx, &runOptions)
 				} else {
 					runOutput, err = runner.Run(ctx, &runOptions)
 				}
+
+				// The postrun script also runs if the run failed, e.g. to send a notification.
+				bundle.ApplyContext(ctx, b, scripts.ExecuteWithEnv(config.ScriptPostRun, postRunEnv(hookEnv, runOptions, err)))
 				if err != nil {
 					return err
 				}
+				if logdiag.HasError(ctx) {
+					return root.ErrAlreadyPrinted
+				}
 
 				if runOutput != nil {
 					switch root.OutputType(cmd) {
@@ -204,18 +225,11 @@ Example usage:
 						if err != nil {
 							return err
 						}
-					case flags.OutputJSON:
-						b, err := json.MarshalIndent(runOutput, "", "  ")
-						if err != nil {
-							return err
-						}
-						_, err = cmd.OutOrStdout().Write(b)
+					default:
+						err := cmdio.Render(ctx, runOutput)
 						if err != nil {
 							return err
 						}
-						_, _ = cmd.OutOrStdout().Write([]byte{'\n'})
-					default:
-						return fmt.Errorf("unknown output type %s", root.OutputType(cmd))
 					}
 				}
 				return nil
@@ -277,6 +291,24 @@ func scriptEnv(cmd *cobra.Command, b *bundle.Bundle) []string {
 	return out
 }
 
+// postRunEnv returns the environment variables for the postrun script.
+func postRunEnv(hookEnv map[string]string, opts run.Options, runErr error) map[string]string {
+	env := maps.Clone(hookEnv)
+	if opts.RunID != "" {
+		env[config.ScriptRunIDEnv] = opts.RunID
+	}
+	switch {
+	case runErr != nil:
+		env[config.ScriptRunStateEnv] = "FAILED"
+		env[config.ScriptRunErrorEnv] = runErr.Error()
+	case opts.NoWait:
+		env[config.ScriptRunStateEnv] = "STARTED"
+	default:
+		env[config.ScriptRunStateEnv] = "SUCCESS"
+	}
+	return env
+}
+
 func executeScript(script config.Script, cmd *cobra.Command, b *bundle.Bundle) error {
 	env := scriptEnv(cmd, b)
 	// Append after the auth/target variables so a script's env: section takes
@@ -54,8 +54,5 @@ func showSummary(cmd *cobra.Command, b *bundle.Bundle) error {
 	if root.OutputType(cmd) == flags.OutputText {
 		return render.RenderSummary(cmd.Context(), cmd.OutOrStdout(), b)
 	}
-	if root.OutputType(cmd) == flags.OutputJSON {
-		return renderJsonOutput(cmd, b)
-	}
-	return nil
+	return renderStructuredOutput(cmd, b)
 }
@@ -17,10 +17,14 @@ import (
 )
 
 type syncFlags struct {
-	interval time.Duration
-	full     bool
-	watch    bool
-	dryRun   bool
+	interval  time.Duration
+	full      bool
+	checksum  bool
+	watch     bool
+	watchMode sync.WatchMode
+	dryRun    bool
+
+	branchCheck sync.BranchCheck
 }
 
 func (f *syncFlags) syncOptionsFromBundle(cmd *cobra.Command, b *bundle.Bundle) (*sync.SyncOptions, error) {
@@ -36,19 +40,22 @@ func (f *syncFlags) syncOptionsFromBundle(cmd *cobra.Command, b *bundle.Bundle)
 		switch root.OutputType(cmd) {
 		case flags.OutputText:
 			outputFunc = sync.TextOutput
-		case flags.OutputJSON:
+		case flags.OutputJSON, flags.OutputNDJSON:
 			outputFunc = sync.JsonOutput
+		default:
+			return nil, fmt.Errorf("%s output is not supported by sync; use text, json or ndjson", root.OutputType(cmd))
 		}
-		if outputFunc != nil {
-			opts.OutputHandler = func(ctx context.Context, c <-chan sync.Event) {
-				outputFunc(ctx, c, cmd.OutOrStdout())
-			}
+		opts.OutputHandler = func(ctx context.Context, c <-chan sync.Event) {
+			outputFunc(ctx, c, cmd.OutOrStdout())
 		}
 	}
 
 	opts.Full = f.full
+	opts.Checksum = f.checksum
 	opts.PollInterval = f.interval
+	opts.WatchMode = f.watchMode
 	opts.DryRun = f.dryRun
+	opts.BranchCheck = f.branchCheck
 	return opts, nil
 }
 
@@ -72,8 +79,13 @@ Use 'databricks bundle deploy' for full resource deployment.`,
 	var f syncFlags
 	cmd.Flags().DurationVar(&f.interval, "interval", 1*time.Second, "file system polling interval (for --watch)")
 	cmd.Flags().BoolVar(&f.full, "full", false, "perform full synchronization (default is incremental)")
+	cmd.Flags().BoolVar(&f.checksum, "checksum", false, "compare file contents to skip files whose contents did not change")
 	cmd.Flags().BoolVar(&f.watch, "watch", false, "watch local file system for changes")
+	f.watchMode = sync.WatchModeAuto
+	cmd.Flags().Var(&f.watchMode, "watch-mode", "how to detect local changes (for --watch): auto, notify, or poll")
 	cmd.Flags().BoolVar(&f.dryRun, "dry-run", false, "simulate sync execution without making actual changes")
+	f.branchCheck = sync.BranchCheckOff
+	cmd.Flags().Var(&f.branchCheck, "branch-check", "what to do if the workspace file path is in a Git folder with a different branch checked out: off, warn, or error")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		b, err := utils.ProcessBundle(cmd, utils.ProcessOptions{})
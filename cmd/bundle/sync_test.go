@@ -7,6 +7,7 @@ import (
 	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/cmd/root"
 	"github.com/databricks/cli/libs/flags"
+	"github.com/databricks/cli/libs/sync"
 	"github.com/databricks/cli/libs/vfs"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -27,9 +28,9 @@ func TestBundleSyncShorthandFlags(t *testing.T) {
 	assert.Equal(t, "myprofile", cmd.Flag("profile").Value.String())
 }
 
-func TestBundleSyncOutputHandlerOnlyWhenOutputSet(t *testing.T) {
+func newTestSyncBundle(t *testing.T) *bundle.Bundle {
 	tempDir := t.TempDir()
-	b := &bundle.Bundle{
+	return &bundle.Bundle{
 		BundleRootPath: tempDir,
 		BundleRoot:     vfs.MustNew(tempDir),
 		SyncRootPath:   tempDir,
@@ -43,7 +44,10 @@ func TestBundleSyncOutputHandlerOnlyWhenOutputSet(t *testing.T) {
 			},
 		},
 	}
+}
 
+func TestBundleSyncOutputHandlerOnlyWhenOutputSet(t *testing.T) {
+	b := newTestSyncBundle(t)
 	f := syncFlags{}
 
 	cmd := newTestSyncCommand(t)
@@ -60,3 +64,20 @@ func TestBundleSyncOutputHandlerOnlyWhenOutputSet(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, opts.OutputHandler)
 }
+
+func TestBundleSyncBranchCheck(t *testing.T) {
+	cmd := newTestSyncCommand(t)
+	assert.Equal(t, "off", cmd.Flag("branch-check").Value.String())
+	require.NoError(t, cmd.ParseFlags([]string{"--branch-check", "error"}))
+	assert.Equal(t, "error", cmd.Flag("branch-check").Value.String())
+	assert.Error(t, cmd.ParseFlags([]string{"--branch-check", "invalid"}))
+
+	b := newTestSyncBundle(t)
+	f := syncFlags{branchCheck: sync.BranchCheckWarn}
+	cmd = newTestSyncCommand(t)
+	cmd.SetContext(t.Context())
+	require.NoError(t, cmd.ParseFlags(nil))
+	opts, err := f.syncOptionsFromBundle(cmd, b)
+	require.NoError(t, err)
+	assert.Equal(t, sync.BranchCheckWarn, opts.BranchCheck)
+}
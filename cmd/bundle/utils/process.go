@@ -11,6 +11,7 @@ import (
 	"github.com/databricks/cli/bundle/config/engine"
 	"github.com/databricks/cli/bundle/config/mutator"
 	"github.com/databricks/cli/bundle/config/validate"
+	"github.com/databricks/cli/bundle/config/variable"
 	"github.com/databricks/cli/bundle/deploy/terraform"
 	"github.com/databricks/cli/bundle/deployplan"
 	"github.com/databricks/cli/bundle/direct"
@@ -129,8 +130,13 @@ func ProcessBundleRet(cmd *cobra.Command, opts ProcessOptions) (b *bundle.Bundle
 		return b, nil, err
 	}
 
+	var varFile string
+	if flag := cmd.Flag("var-file"); flag != nil {
+		varFile = flag.Value.String()
+	}
+
 	// Initialize variables by assigning them values passed as command line flags
-	configureVariables(cmd, b, variables)
+	configureVariables(cmd, b, variables, varFile)
 
 	if b == nil || logdiag.HasError(ctx) {
 		return b, nil, root.ErrAlreadyPrinted
@@ -286,6 +292,11 @@ func ProcessBundleRet(cmd *cobra.Command, opts ProcessOptions) (b *bundle.Bundle
 			logdiag.LogError(ctx, err)
 			return b, stateDesc, root.ErrAlreadyPrinted
 		}
+		err = plan.RestoreValues(variable.SensitiveValues(b.Config.Variables))
+		if err != nil {
+			logdiag.LogError(ctx, err)
+			return b, stateDesc, root.ErrAlreadyPrinted
+		}
 		currentVersion := build.GetInfo().Version
 		if plan.CLIVersion != currentVersion {
 			log.Warnf(ctx, "Plan was created with CLI version %s but current version is %s", plan.CLIVersion, currentVersion)
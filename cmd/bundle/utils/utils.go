@@ -25,11 +25,33 @@ func SetForceLock(cmd *cobra.Command, b *bundle.Bundle, forceLock bool) {
 	b.Config.Bundle.Deployment.Lock.Force = forceLock
 }
 
-func configureVariables(cmd *cobra.Command, b *bundle.Bundle, variables []string) {
+func configureVariables(cmd *cobra.Command, b *bundle.Bundle, variables []string, varFile string) {
 	bundle.ApplyFuncContext(cmd.Context(), b, func(ctx context.Context, b *bundle.Bundle) {
 		err := b.Config.InitializeVariables(variables)
 		if err != nil {
 			logdiag.LogError(ctx, err)
+			return
+		}
+
+		// Values from --var take precedence over values from --var-file,
+		// which take precedence over values from the var_file of the target.
+		for _, path := range []string{varFile, targetVarFile(b)} {
+			if path == "" {
+				continue
+			}
+			for _, d := range b.Config.InitializeVariablesFromFile(path) {
+				logdiag.LogDiag(ctx, d)
+			}
+			if logdiag.HasError(ctx) {
+				return
+			}
 		}
 	})
 }
+
+func targetVarFile(b *bundle.Bundle) string {
+	if b.Target == nil {
+		return ""
+	}
+	return b.Target.VarFile
+}
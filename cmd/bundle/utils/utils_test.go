@@ -1,10 +1,14 @@
 package utils
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/databricks/cli/bundle"
 	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/variable"
+	"github.com/databricks/cli/internal/testutil"
+	"github.com/databricks/cli/libs/logdiag"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -69,3 +73,37 @@ func TestSetForceLock(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigureVariablesFromTargetVarFile(t *testing.T) {
+	dir := t.TempDir()
+	varFile := filepath.Join(dir, ".env")
+	targetVarFile := filepath.Join(dir, ".env.dev")
+	testutil.WriteFile(t, varFile, "bar=from-var-file\n")
+	testutil.WriteFile(t, targetVarFile, "foo=from-target\nbar=from-target\nbaz=from-target\nunknown=x\n")
+
+	ctx := logdiag.InitContext(t.Context())
+	logdiag.SetCollect(ctx, true)
+	cmd := &cobra.Command{Use: "deploy"}
+	cmd.SetContext(ctx)
+
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Variables: map[string]*variable.Variable{
+				"foo": {},
+				"bar": {},
+				"baz": {},
+			},
+		},
+		Target: &config.Target{VarFile: targetVarFile},
+	}
+
+	configureVariables(cmd, b, []string{"foo=from-flag"}, varFile)
+
+	diags := logdiag.FlushCollected(ctx)
+	require.NoError(t, diags.Error())
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Summary, "variable unknown in "+targetVarFile+" has not been defined")
+	assert.Equal(t, "from-flag", b.Config.Variables["foo"].Value)
+	assert.Equal(t, "from-var-file", b.Config.Variables["bar"].Value)
+	assert.Equal(t, "from-target", b.Config.Variables["baz"].Value)
+}
@@ -1,24 +1,29 @@
 package bundle
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/validate"
+	"github.com/databricks/cli/bundle/config/variable"
 	"github.com/databricks/cli/bundle/direct/dresources"
 	"github.com/databricks/cli/bundle/render"
 	"github.com/databricks/cli/cmd/bundle/utils"
 	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
 	"github.com/databricks/cli/libs/dyn/convert"
 	"github.com/databricks/cli/libs/flags"
 	"github.com/databricks/cli/libs/logdiag"
 	"github.com/spf13/cobra"
 )
 
-func renderJsonOutput(cmd *cobra.Command, b *bundle.Bundle) error {
+func renderStructuredOutput(cmd *cobra.Command, b *bundle.Bundle) error {
 	if b == nil {
 		return nil
 	}
+	// Collect the values of sensitive variables before they are redacted below.
+	sensitive := variable.SensitiveValues(b.Config.Variables)
 	redactedRoot, err := dresources.RedactSensitiveConfigValues(&b.Config)
 	if err != nil {
 		return err
@@ -27,14 +32,11 @@ func renderJsonOutput(cmd *cobra.Command, b *bundle.Bundle) error {
 	if err != nil {
 		return err
 	}
-	buf, err := json.MarshalIndent(converted.AsAny(), "", "  ")
+	converted, err = variable.Redact(converted, sensitive)
 	if err != nil {
 		return err
 	}
-	out := cmd.OutOrStdout()
-	_, _ = out.Write(buf)
-	_, _ = out.Write([]byte{'\n'})
-	return nil
+	return cmdio.Render(cmd.Context(), converted.AsAny())
 }
 
 func newValidateCommand() *cobra.Command {
@@ -47,8 +49,11 @@ Run validate before deploy to catch configuration issues early:
   databricks bundle validate                  # Validate default target
   databricks bundle validate --target prod    # Validate specific target
   databricks bundle validate --strict         # Fail on warnings
+  databricks bundle validate --policy dir     # Check policy rules in dir
 
 Validation checks the configuration syntax and schema, permissions etc.
+Policy rules in --policy files or directories are checked in addition to
+those listed in bundle.policies.
 
 Please run this command before deploying to ensure configuration quality.`,
 		Args: root.NoArgs,
@@ -56,14 +61,22 @@ Please run this command before deploying to ensure configuration quality.`,
 
 	var includeLocations bool
 	var strict bool
+	var policies []string
 	cmd.Flags().BoolVar(&includeLocations, "include-locations", false, "Include location information in the output")
 	cmd.Flags().MarkHidden("include-locations")
 	cmd.Flags().BoolVar(&strict, "strict", false, "Treat warnings as errors")
+	cmd.Flags().StringSliceVar(&policies, "policy", nil, "Policy file or directory to check the configuration against (can be repeated)")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		b, err := utils.ProcessBundle(cmd, utils.ProcessOptions{
 			Validate:         true,
 			IncludeLocations: includeLocations,
+			PostInitFunc: func(ctx context.Context, b *bundle.Bundle) error {
+				if len(policies) > 0 {
+					bundle.ApplyContext(ctx, b, validate.PolicyPaths(policies))
+				}
+				return nil
+			},
 		})
 		ctx := cmd.Context()
 
@@ -79,10 +92,8 @@ Please run this command before deploying to ensure configuration quality.`,
 			if err1 != nil {
 				return err1
 			}
-		}
-
-		if root.OutputType(cmd) == flags.OutputJSON {
-			err1 := renderJsonOutput(cmd, b)
+		} else {
+			err1 := renderStructuredOutput(cmd, b)
 			if err1 != nil {
 				return err1
 			}
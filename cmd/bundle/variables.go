@@ -6,4 +6,5 @@ import (
 
 func initVariableFlag(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringSlice("var", []string{}, `set values for variables defined in bundle config. Example: --var="foo=bar"`)
+	cmd.PersistentFlags().String("var-file", "", `set values for variables defined in bundle config from a .env-style file of name=value lines. Example: --var-file=.env.prod`)
 }
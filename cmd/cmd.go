@@ -11,10 +11,12 @@ import (
 	"github.com/databricks/cli/cmd/account"
 	"github.com/databricks/cli/cmd/api"
 	"github.com/databricks/cli/cmd/auth"
+	"github.com/databricks/cli/cmd/bulk"
 	"github.com/databricks/cli/cmd/bundle"
 	"github.com/databricks/cli/cmd/cache"
 	"github.com/databricks/cli/cmd/completion"
 	"github.com/databricks/cli/cmd/configure"
+	"github.com/databricks/cli/cmd/debug"
 	"github.com/databricks/cli/cmd/experimental"
 	"github.com/databricks/cli/cmd/fs"
 	"github.com/databricks/cli/cmd/labs"
@@ -66,8 +68,8 @@ func New(ctx context.Context) *cobra.Command {
 	cli.AddCommand(accountCommand())
 
 	// Add workspace subcommands.
-	workspaceCommands := workspace.All()
-	for _, cmd := range workspaceCommands {
+	for _, newGroup := range workspace.Constructors() {
+		cmd := newGroup()
 		// Order the permissions subcommands after the main commands.
 		for _, sub := range cmd.Commands() {
 			// some commands override groups in overrides.go, leave them as-is
@@ -102,6 +104,9 @@ func New(ctx context.Context) *cobra.Command {
 		}
 
 		configureGroups(cmd, groups)
+
+		// Add --from-ndjson to the create, update and delete commands.
+		bulk.Enable(cmd, newGroup)
 	}
 
 	// Add other subcommands.
@@ -114,6 +119,7 @@ func New(ctx context.Context) *cobra.Command {
 	cli.AddCommand(experimental.New())
 	cli.AddCommand(psql.New())
 	cli.AddCommand(configure.New())
+	cli.AddCommand(debug.New())
 	cli.AddCommand(fs.New())
 	cli.AddCommand(labs.New(ctx))
 	cli.AddCommand(sandbox.New())
@@ -0,0 +1,16 @@
+package debug
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "debug",
+		Short:   "Tools for developing and testing against Databricks",
+		GroupID: "development",
+	}
+
+	cmd.AddCommand(newLocalWorkspace())
+	return cmd
+}
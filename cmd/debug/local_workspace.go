@@ -0,0 +1,142 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/databrickscfg"
+	"github.com/databricks/cli/libs/env"
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/cli/libs/testserver"
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/spf13/cobra"
+)
+
+// localWorkspaceToken is the token of the generated profile. The prefix makes
+// the server treat requests as coming from its test user.
+const localWorkspaceToken = testserver.UserNameTokenPrefix + "local-workspace"
+
+func newLocalWorkspace() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "local-workspace",
+		Short: "Emulate a Databricks workspace on this machine",
+		Long: `Emulate a Databricks workspace on this machine.
+
+The emulated workspace is the fake workspace that backs the CLI's own acceptance
+tests. It covers jobs, pipelines, workspace files, Unity Catalog schemas and
+volumes, secrets, apps, dashboards and more, but it does not run any code.`,
+	}
+
+	cmd.AddCommand(newLocalWorkspaceServe())
+	return cmd
+}
+
+func newLocalWorkspaceServe() *cobra.Command {
+	var port int
+	var stateDir string
+	var profileName string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve an emulated workspace on localhost",
+		Long: `Serve an emulated workspace on localhost until interrupted.
+
+The command saves a profile that points at the emulated workspace, so that other
+commands can use it with --profile. Bundles deploy to it with the direct
+deployment engine:
+
+  DATABRICKS_BUNDLE_ENGINE=direct databricks bundle deploy --profile local-workspace
+
+Without --state-dir, the workspace starts empty and its state is lost when the
+command exits.`,
+		Args: root.NoArgs,
+	}
+
+	cmd.Flags().IntVar(&port, "port", 8765, "port to listen on; 0 picks a free port")
+	cmd.Flags().StringVar(&stateDir, "state-dir", "", "directory to persist the workspace state to")
+	cmd.Flags().StringVar(&profileName, "profile-name", "local-workspace", "name of the profile to save; empty to not save a profile")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		if err != nil {
+			return err
+		}
+
+		t := &serverT{ctx: ctx}
+		defer t.cleanup()
+
+		server := testserver.NewLocalWorkspace(t, l)
+		testserver.AddDefaultHandlers(server)
+		if stateDir != "" {
+			err = server.PersistTo(stateDir)
+			if err != nil {
+				return err
+			}
+		}
+
+		if profileName != "" {
+			err = databrickscfg.SaveToProfile(ctx, &config.Config{
+				Profile:    profileName,
+				Host:       server.URL,
+				Token:      localWorkspaceToken,
+				AuthType:   "pat",
+				ConfigFile: env.Get(ctx, "DATABRICKS_CONFIG_FILE"),
+			}, "account_id", "workspace_id", "cluster_id", "serverless_compute_id")
+			if err != nil {
+				return fmt.Errorf("saving profile %q: %w", profileName, err)
+			}
+			cmdio.LogString(ctx, fmt.Sprintf("Profile %s points at %s", profileName, server.URL))
+		}
+
+		cmdio.LogString(ctx, fmt.Sprintf("Serving an emulated workspace at %s. Press Ctrl+C to stop.", server.URL))
+		<-ctx.Done()
+		return nil
+	}
+
+	return cmd
+}
+
+// serverT reports the problems of the emulated workspace to the user and runs
+// its cleanup when the command exits.
+type serverT struct {
+	ctx context.Context
+
+	mu       sync.Mutex
+	cleanups []func()
+}
+
+func (t *serverT) Logf(format string, args ...any) {
+	log.Debugf(t.ctx, format, args...)
+}
+
+func (t *serverT) Errorf(format string, args ...any) {
+	cmdio.LogString(t.ctx, fmt.Sprintf(format, args...))
+}
+
+func (t *serverT) Cleanup(f func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cleanups = append(t.cleanups, f)
+}
+
+// cleanup runs the registered functions in reverse order, like [testing.T] does.
+func (t *serverT) cleanup() {
+	t.mu.Lock()
+	cleanups := t.cleanups
+	t.cleanups = nil
+	t.mu.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
@@ -0,0 +1,18 @@
+package debug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerTCleanupRunsInReverseOrder(t *testing.T) {
+	st := &serverT{ctx: t.Context()}
+	var order []int
+	st.Cleanup(func() { order = append(order, 1) })
+	st.Cleanup(func() { order = append(order, 2) })
+
+	st.cleanup()
+	st.cleanup()
+	assert.Equal(t, []int{2, 1}, order)
+}
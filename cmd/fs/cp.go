@@ -43,6 +43,10 @@ type copy struct {
 	// would each fight for the spinner line).
 	showProgress bool
 
+	// journal records copied files so an interrupted copy can be resumed.
+	// It is set only if the --resume flag is specified.
+	journal *copyJournal
+
 	mu sync.Mutex // protect output from concurrent writes
 }
 
@@ -122,6 +126,19 @@ func (c *copy) cpFileToDir(ctx context.Context, sourcePath, targetDir string) er
 }
 
 func (c *copy) cpFileToFile(ctx context.Context, sourcePath, targetPath string) error {
+	// Skip files that were copied by a previous, interrupted invocation.
+	var sourceInfo fs.FileInfo
+	if c.journal != nil {
+		var err error
+		sourceInfo, err = c.sourceFiler.Stat(ctx, sourcePath)
+		if err != nil {
+			return err
+		}
+		if c.journal.copied(sourcePath, sourceInfo) {
+			return c.emitFileResumedEvent(ctx, sourcePath, targetPath)
+		}
+	}
+
 	// Get reader for file at source path
 	r, err := c.sourceFiler.Read(ctx, sourcePath)
 	if err != nil {
@@ -129,6 +146,12 @@ func (c *copy) cpFileToFile(ctx context.Context, sourcePath, targetPath string)
 	}
 	defer r.Close()
 
+	// The journal records the progress of large uploads to UC Volumes so an
+	// interrupted upload resumes from the last completed part.
+	if c.journal != nil {
+		ctx = filer.WithUploadSessionStore(ctx, c.journal)
+	}
+
 	// For a single large-file copy, attach a progress callback to the context
 	// that the Files filer forwards to the upload engine, rendering an upload bar.
 	// The spinner is stopped before any event line is emitted so its final frame
@@ -155,6 +178,12 @@ func (c *copy) cpFileToFile(ctx context.Context, sourcePath, targetPath string)
 	if writeErr != nil {
 		return writeErr
 	}
+	if c.journal != nil {
+		err = c.journal.record(sourcePath, sourceInfo)
+		if err != nil {
+			return err
+		}
+	}
 	return c.emitFileCopiedEvent(ctx, sourcePath, targetPath)
 }
 
@@ -178,6 +207,24 @@ func (c *copy) emitFileSkippedEvent(ctx context.Context, sourcePath, targetPath
 	return cmdio.RenderWithTemplate(ctx, event, "", template)
 }
 
+func (c *copy) emitFileResumedEvent(ctx context.Context, sourcePath, targetPath string) error {
+	fullSourcePath := sourcePath
+	if c.sourceScheme != "" {
+		fullSourcePath = path.Join(c.sourceScheme+":", sourcePath)
+	}
+	fullTargetPath := targetPath
+	if c.targetScheme != "" {
+		fullTargetPath = path.Join(c.targetScheme+":", targetPath)
+	}
+
+	event := newFileSkippedEvent(fullSourcePath, fullTargetPath)
+	template := "{{.SourcePath}} -> {{.TargetPath}} (skipped; copied before resuming)\n"
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return cmdio.RenderWithTemplate(ctx, event, "", template)
+}
+
 func (c *copy) emitFileCopiedEvent(ctx context.Context, sourcePath, targetPath string) error {
 	fullSourcePath := sourcePath
 	if c.sourceScheme != "" {
@@ -220,6 +267,11 @@ func newCpCommand() *cobra.Command {
 
 	  When copying a file, if TARGET_PATH is a directory, the file will be created
 	  inside the directory, otherwise the file is created at TARGET_PATH.
+
+	  Use --resume to continue a copy that was interrupted. Files that were copied
+	  before the interruption and have not changed since are skipped. A large file
+	  that was partially uploaded to a UC Volume in parts resumes from the last
+	  uploaded part.
 	`,
 		Args: root.ExactArgs(2),
 	}
@@ -229,6 +281,9 @@ func newCpCommand() *cobra.Command {
 	cmd.Flags().BoolVarP(&c.recursive, "recursive", "r", false, "recursively copy files from directory")
 	cmd.Flags().IntVar(&c.concurrency, "concurrency", defaultConcurrency, "number of parallel copy operations")
 
+	var resume bool
+	cmd.Flags().BoolVar(&resume, "resume", false, "skip files copied by a previous interrupted invocation")
+
 	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		if c.concurrency <= 0 {
 			return errInvalidConcurrency
@@ -265,34 +320,23 @@ func newCpCommand() *cobra.Command {
 		c.sourceFiler = sourceFiler
 		c.targetFiler = targetFiler
 
-		// Get information about file at source path
-		sourceInfo, err := sourceFiler.Stat(ctx, sourcePath)
-		if err != nil {
-			return err
-		}
-
-		// case 1: source path is a directory, then recursively create files at target path
-		if sourceInfo.IsDir() {
-			return c.cpDirToDir(ctx, sourcePath, targetPath)
+		if resume {
+			c.journal, err = openCopyJournal(ctx, journalKey(fullSourcePath), journalKey(fullTargetPath))
+			if err != nil {
+				return err
+			}
 		}
 
-		// A single large file copied to a Volume goes through the multipart engine,
-		// which reports progress; render an upload bar for it.
-		c.showProgress = filer.MultipartUploadEnabled(ctx) && strings.HasPrefix(targetPath, "/Volumes/")
-
-		// If target path has a trailing separator, trim it and let case 2 handle it
-		if hasTrailingDirSeparator(fullTargetPath) {
-			targetPath = trimTrailingDirSeparators(targetPath)
+		err = c.cp(ctx, sourcePath, targetPath, hasTrailingDirSeparator(fullTargetPath))
+		if err != nil {
+			return err
 		}
 
-		// case 2: source path is a file, and target path is a directory. In this case
-		// we copy the file to inside the directory
-		if targetInfo, err := targetFiler.Stat(ctx, targetPath); err == nil && targetInfo.IsDir() {
-			return c.cpFileToDir(ctx, sourcePath, targetPath)
+		// The copy completed; there is nothing left to resume.
+		if c.journal != nil {
+			return c.journal.remove()
 		}
-
-		// case 3: source path is a file, and target path is a file
-		return c.cpFileToFile(ctx, sourcePath, targetPath)
+		return nil
 	}
 
 	v := newValidArgs()
@@ -302,3 +346,47 @@ func newCpCommand() *cobra.Command {
 
 	return cmd
 }
+
+// journalKey returns the path used to identify a copy in the journal.
+// Local paths are made absolute so the journal does not depend on the working directory.
+func journalKey(fullPath string) string {
+	if isDbfsPath(fullPath) {
+		return fullPath
+	}
+	abs, err := filepath.Abs(fullPath)
+	if err != nil {
+		return fullPath
+	}
+	return abs
+}
+
+func (c *copy) cp(ctx context.Context, sourcePath, targetPath string, trailingSeparator bool) error {
+	// Get information about file at source path
+	sourceInfo, err := c.sourceFiler.Stat(ctx, sourcePath)
+	if err != nil {
+		return err
+	}
+
+	// case 1: source path is a directory, then recursively create files at target path
+	if sourceInfo.IsDir() {
+		return c.cpDirToDir(ctx, sourcePath, targetPath)
+	}
+
+	// A single large file copied to a Volume goes through the multipart engine,
+	// which reports progress; render an upload bar for it.
+	c.showProgress = filer.MultipartUploadEnabled(ctx) && strings.HasPrefix(targetPath, "/Volumes/")
+
+	// If target path has a trailing separator, trim it and let case 2 handle it
+	if trailingSeparator {
+		targetPath = trimTrailingDirSeparators(targetPath)
+	}
+
+	// case 2: source path is a file, and target path is a directory. In this case
+	// we copy the file to inside the directory
+	if targetInfo, err := c.targetFiler.Stat(ctx, targetPath); err == nil && targetInfo.IsDir() {
+		return c.cpFileToDir(ctx, sourcePath, targetPath)
+	}
+
+	// case 3: source path is a file, and target path is a file
+	return c.cpFileToFile(ctx, sourcePath, targetPath)
+}
@@ -0,0 +1,215 @@
+package fs
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/databricks/cli/libs/cache"
+	"github.com/databricks/cli/libs/filer"
+	"github.com/databricks/cli/libs/log"
+)
+
+// journalEntry records a file that was copied successfully.
+type journalEntry struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+
+	// Upload is set instead of the fields above on lines that record the
+	// progress of a multipart upload. A session without a token marks the
+	// upload as finished.
+	Upload *filer.UploadSession `json:"upload,omitempty"`
+}
+
+// copyJournal keeps track of the files copied by an invocation of fs cp, so an
+// interrupted copy can be resumed without copying the same files again.
+//
+// The journal is an append-only file with one JSON entry per line. A line that
+// was only partially written when the copy was interrupted is ignored on load.
+//
+// Large files are uploaded to UC Volumes in parts. The journal implements
+// [filer.UploadSessionStore] and records the upload session and the parts
+// uploaded so far, so an interrupted copy also resumes a partially uploaded file.
+type copyJournal struct {
+	path string
+
+	mu       sync.Mutex
+	entries  map[string]journalEntry
+	sessions map[string]*filer.UploadSession
+}
+
+// journalPath returns the location of the journal for copying source to target.
+func journalPath(ctx context.Context, source, target string) (string, error) {
+	dir, err := cache.BaseDir(ctx)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(source + "\x00" + target))
+	return filepath.Join(dir, "fs-cp", hex.EncodeToString(sum[:8])+".jsonl"), nil
+}
+
+// openCopyJournal loads the journal for copying source to target, if one exists.
+func openCopyJournal(ctx context.Context, source, target string) (*copyJournal, error) {
+	path, err := journalPath(ctx, source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &copyJournal{
+		path:     path,
+		entries:  make(map[string]journalEntry),
+		sessions: make(map[string]*filer.UploadSession),
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry journalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			log.Debugf(ctx, "Ignoring malformed entry in copy journal %s: %s", path, err)
+			continue
+		}
+		switch {
+		case entry.Upload == nil:
+			j.entries[entry.Path] = entry
+		case entry.Upload.SessionToken == "":
+			delete(j.sessions, entry.Upload.Path)
+		default:
+			j.sessions[entry.Upload.Path] = entry.Upload
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	log.Debugf(ctx, "Resuming copy with %d files and %d uploads recorded in %s", len(j.entries), len(j.sessions), path)
+	return j, nil
+}
+
+// copied returns true if the file was copied before and has not changed since.
+func (j *copyJournal) copied(path string, info fs.FileInfo) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entry, ok := j.entries[path]
+	return ok && entry.Size == info.Size() && entry.ModTime.Equal(info.ModTime())
+}
+
+// record appends an entry for a file that was copied successfully.
+func (j *copyJournal) record(path string, info fs.FileInfo) error {
+	entry := journalEntry{
+		Path:    path,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	err := j.append(entry)
+	if err != nil {
+		return err
+	}
+
+	j.entries[path] = entry
+	return nil
+}
+
+// LoadUploadSession implements [filer.UploadSessionStore].
+func (j *copyJournal) LoadUploadSession(path string) *filer.UploadSession {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	s, ok := j.sessions[path]
+	if !ok {
+		return nil
+	}
+	c := *s
+	c.Parts = maps.Clone(s.Parts)
+	if c.Parts == nil {
+		c.Parts = make(map[int]string)
+	}
+	return &c
+}
+
+// SaveUploadSession implements [filer.UploadSessionStore].
+func (j *copyJournal) SaveUploadSession(s *filer.UploadSession) error {
+	c := *s
+	c.Parts = maps.Clone(s.Parts)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	err := j.append(journalEntry{Upload: &c})
+	if err != nil {
+		return err
+	}
+
+	j.sessions[c.Path] = &c
+	return nil
+}
+
+// DeleteUploadSession implements [filer.UploadSessionStore].
+func (j *copyJournal) DeleteUploadSession(path string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, ok := j.sessions[path]; !ok {
+		return nil
+	}
+
+	err := j.append(journalEntry{Upload: &filer.UploadSession{Path: path}})
+	if err != nil {
+		return err
+	}
+
+	delete(j.sessions, path)
+	return nil
+}
+
+// append writes an entry to the journal file. The caller must hold j.mu.
+func (j *copyJournal) append(entry journalEntry) error {
+	buf, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(j.path), 0o700)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(buf, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// remove deletes the journal. It is called once the copy has completed.
+func (j *copyJournal) remove() error {
+	err := os.Remove(j.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/env"
+	"github.com/databricks/cli/libs/filer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyJournal(t *testing.T) {
+	ctx := env.Set(t.Context(), "DATABRICKS_CACHE_DIR", t.TempDir())
+	path := filepath.Join(t.TempDir(), "a.txt")
+	writeFile(t, path, "a", time.Now().Add(-time.Hour))
+	info := mustStat(t, path)
+
+	j, err := openCopyJournal(ctx, "/src", "dbfs:/Volumes/a/b/c")
+	require.NoError(t, err)
+
+	assert.False(t, j.copied("/src/a.txt", info))
+	require.NoError(t, j.record("/src/a.txt", info))
+
+	// Simulate an entry that was only partially written before an interruption.
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"path":"/src/b.t`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	j, err = openCopyJournal(ctx, "/src", "dbfs:/Volumes/a/b/c")
+	require.NoError(t, err)
+	assert.True(t, j.copied("/src/a.txt", info))
+	assert.False(t, j.copied("/src/b.txt", info))
+
+	// A file that changed since it was copied is copied again.
+	writeFile(t, path, "a", time.Now())
+	assert.False(t, j.copied("/src/a.txt", mustStat(t, path)))
+
+	// The journal for another target is independent.
+	other, err := openCopyJournal(ctx, "/src", "dbfs:/Volumes/a/b/d")
+	require.NoError(t, err)
+	assert.False(t, other.copied("/src/a.txt", info))
+
+	require.NoError(t, j.remove())
+	assert.NoFileExists(t, j.path)
+	require.NoError(t, j.remove())
+}
+
+func TestCopyJournalUploadSession(t *testing.T) {
+	ctx := env.Set(t.Context(), "DATABRICKS_CACHE_DIR", t.TempDir())
+
+	j, err := openCopyJournal(ctx, "/src", "dbfs:/Volumes/a/b/c")
+	require.NoError(t, err)
+	assert.Nil(t, j.LoadUploadSession("/Volumes/a/b/c/big.bin"))
+
+	s := &filer.UploadSession{
+		Path:         "/Volumes/a/b/c/big.bin",
+		SessionToken: "token",
+		Size:         100,
+		PartSize:     10,
+		Parts:        map[int]string{1: "etag-1"},
+	}
+	require.NoError(t, j.SaveUploadSession(s))
+	s.Parts[2] = "etag-2"
+	require.NoError(t, j.SaveUploadSession(s))
+
+	// The last recorded session is loaded when the copy is resumed.
+	j, err = openCopyJournal(ctx, "/src", "dbfs:/Volumes/a/b/c")
+	require.NoError(t, err)
+	assert.Equal(t, s, j.LoadUploadSession("/Volumes/a/b/c/big.bin"))
+
+	// A finished upload is not resumed.
+	require.NoError(t, j.DeleteUploadSession("/Volumes/a/b/c/big.bin"))
+	j, err = openCopyJournal(ctx, "/src", "dbfs:/Volumes/a/b/c")
+	require.NoError(t, err)
+	assert.Nil(t, j.LoadUploadSession("/Volumes/a/b/c/big.bin"))
+}
+
+func TestCpResume(t *testing.T) {
+	ctx := env.Set(cmdio.MockDiscard(t.Context()), "DATABRICKS_CACHE_DIR", t.TempDir())
+	src := t.TempDir()
+	dst := t.TempDir()
+	writeFile(t, filepath.Join(src, "a.txt"), "a", time.Now())
+	writeFile(t, filepath.Join(src, "b.txt"), "b", time.Now())
+
+	f, err := filer.NewLocalClient("")
+	require.NoError(t, err)
+
+	j, err := openCopyJournal(ctx, src, dst)
+	require.NoError(t, err)
+	require.NoError(t, j.record(filepath.Join(src, "a.txt"), mustStat(t, filepath.Join(src, "a.txt"))))
+
+	c := &copy{
+		recursive:   true,
+		overwrite:   true,
+		concurrency: 1,
+		sourceFiler: f,
+		targetFiler: f,
+		journal:     j,
+	}
+	require.NoError(t, c.cp(ctx, src, dst, false))
+
+	// The file recorded in the journal is not copied again.
+	assert.NoFileExists(t, filepath.Join(dst, "a.txt"))
+	assert.FileExists(t, filepath.Join(dst, "b.txt"))
+	assert.True(t, j.copied(filepath.Join(src, "b.txt"), mustStat(t, filepath.Join(src, "b.txt"))))
+}
+
+func mustStat(t *testing.T, path string) os.FileInfo {
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return info
+}
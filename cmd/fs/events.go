@@ -11,6 +11,8 @@ type EventType string
 const (
 	EventTypeFileCopied  = EventType("FILE_COPIED")
 	EventTypeFileSkipped = EventType("FILE_SKIPPED")
+	EventTypeFileMoved   = EventType("FILE_MOVED")
+	EventTypeFileDeleted = EventType("FILE_DELETED")
 )
 
 func newFileCopiedEvent(sourcePath, targetPath string) fileIOEvent {
@@ -28,3 +30,18 @@ func newFileSkippedEvent(sourcePath, targetPath string) fileIOEvent {
 		Type:       EventTypeFileSkipped,
 	}
 }
+
+func newFileMovedEvent(sourcePath, targetPath string) fileIOEvent {
+	return fileIOEvent{
+		SourcePath: sourcePath,
+		TargetPath: targetPath,
+		Type:       EventTypeFileMoved,
+	}
+}
+
+func newFileDeletedEvent(targetPath string) fileIOEvent {
+	return fileIOEvent{
+		TargetPath: targetPath,
+		Type:       EventTypeFileDeleted,
+	}
+}
@@ -17,7 +17,9 @@ func New() *cobra.Command {
 		newCpCommand(),
 		newLsCommand(),
 		newMkdirCommand(),
+		newMvCommand(),
 		newRmCommand(),
+		newSyncCommand(),
 	)
 
 	return cmd
@@ -49,6 +49,21 @@ func filerForPath(ctx context.Context, fullPath string) (filer.Filer, string, er
 	return f, path, err
 }
 
+// filerForWorkspacePath is like filerForPath, but also uses the workspace files
+// API for paths with the "Workspace" prefix. Only the commands that document
+// support for workspace files use it, so that the other commands keep using
+// DBFS for these paths.
+func filerForWorkspacePath(ctx context.Context, fullPath string) (filer.Filer, string, error) {
+	path, ok := strings.CutPrefix(fullPath, dbfsPrefix)
+	if !ok || !strings.HasPrefix(path, "/Workspace/") {
+		return filerForPath(ctx, fullPath)
+	}
+
+	w := cmdctx.WorkspaceClient(ctx)
+	f, err := filer.NewWorkspaceFilesClient(w, "/")
+	return f, path, err
+}
+
 const dbfsPrefix string = "dbfs:"
 
 func isDbfsPath(path string) bool {
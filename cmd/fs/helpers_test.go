@@ -67,6 +67,39 @@ func TestFilerForPathForDbfsScheme(t *testing.T) {
 	}
 }
 
+func TestFilerForWorkspacePath(t *testing.T) {
+	tcases := []struct {
+		fullPath string
+		path     string
+		filer    filer.Filer
+	}{
+		{"dbfs:/Workspace/foo/bar/baz", "/Workspace/foo/bar/baz", &filer.WorkspaceFilesClient{}},
+		{"dbfs:/Volumes/foo/bar/baz", "/Volumes/foo/bar/baz", &filer.FilesClient{}},
+		{"dbfs:/foo/bar/baz", "/foo/bar/baz", &filer.DbfsClient{}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.fullPath, func(t *testing.T) {
+			m := mocks.NewMockWorkspaceClient(t)
+			m.WorkspaceClient.Config = &databrickscfg.Config{}
+			ctx := cmdctx.SetWorkspaceClient(t.Context(), m.WorkspaceClient)
+
+			f, path, err := filerForWorkspacePath(ctx, tc.fullPath)
+			require.NoError(t, err)
+			assert.Equal(t, tc.path, path)
+			assert.IsType(t, tc.filer, f)
+		})
+	}
+
+	// Other commands keep using DBFS for these paths.
+	m := mocks.NewMockWorkspaceClient(t)
+	m.WorkspaceClient.Config = &databrickscfg.Config{}
+	ctx := cmdctx.SetWorkspaceClient(t.Context(), m.WorkspaceClient)
+	f, _, err := filerForPath(ctx, "dbfs:/Workspace/foo")
+	require.NoError(t, err)
+	assert.IsType(t, &filer.DbfsClient{}, f)
+}
+
 func testWindowsFilerForPath(t *testing.T, ctx context.Context, fullPath string) {
 	f, path, err := filerForPath(ctx, fullPath)
 	assert.NoError(t, err)
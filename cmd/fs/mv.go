@@ -0,0 +1,182 @@
+package fs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+
+	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/filer"
+	"github.com/databricks/cli/libs/log"
+	"github.com/spf13/cobra"
+)
+
+type move struct {
+	copy
+}
+
+// canMoveInPlace returns true if the source and target filers are the same
+// kind of backend and the backend supports moving files without copying them.
+func canMoveInPlace(source, target filer.Filer) bool {
+	if reflect.TypeOf(source) != reflect.TypeOf(target) {
+		return false
+	}
+	_, ok := source.(filer.Mover)
+	return ok
+}
+
+func (m *move) mv(ctx context.Context, sourcePath, targetPath string) error {
+	sourceInfo, err := m.sourceFiler.Stat(ctx, sourcePath)
+	if err != nil {
+		return err
+	}
+	if sourceInfo.IsDir() && !m.recursive {
+		return fmt.Errorf("source path %s is a directory. Please specify the --recursive flag", sourcePath)
+	}
+
+	// If the target is an existing directory, move the source into it.
+	if targetInfo, err := m.targetFiler.Stat(ctx, targetPath); err == nil && targetInfo.IsDir() {
+		targetPath = path.Join(targetPath, path.Base(sourcePath))
+	}
+
+	if canMoveInPlace(m.sourceFiler, m.targetFiler) {
+		return m.mvInPlace(ctx, sourcePath, targetPath)
+	}
+
+	// Files that already exist at the target would be skipped by the copy
+	// and then lost when deleting the source, so refuse to move onto them.
+	if !m.overwrite {
+		_, err := m.targetFiler.Stat(ctx, targetPath)
+		if err == nil {
+			return fmt.Errorf("target path %s already exists. Please specify the --overwrite flag", targetPath)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	log.Debugf(ctx, "Moving %s to %s by copying and deleting the source", sourcePath, targetPath)
+	if sourceInfo.IsDir() {
+		err = m.cpDirToDir(ctx, sourcePath, targetPath)
+	} else {
+		err = m.cpFileToFile(ctx, sourcePath, targetPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	if sourceInfo.IsDir() {
+		return m.sourceFiler.Delete(ctx, sourcePath, filer.DeleteRecursively)
+	}
+	return m.sourceFiler.Delete(ctx, sourcePath)
+}
+
+func (m *move) mvInPlace(ctx context.Context, sourcePath, targetPath string) error {
+	mover := m.sourceFiler.(filer.Mover)
+
+	if m.overwrite {
+		err := m.targetFiler.Delete(ctx, targetPath, filer.DeleteRecursively)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	err := mover.Move(ctx, sourcePath, targetPath)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("target path %s already exists. Please specify the --overwrite flag", targetPath)
+	}
+	if err != nil {
+		return err
+	}
+	return m.emitFileMovedEvent(ctx, sourcePath, targetPath)
+}
+
+func (m *move) emitFileMovedEvent(ctx context.Context, sourcePath, targetPath string) error {
+	fullSourcePath := sourcePath
+	if m.sourceScheme != "" {
+		fullSourcePath = path.Join(m.sourceScheme+":", sourcePath)
+	}
+	fullTargetPath := targetPath
+	if m.targetScheme != "" {
+		fullTargetPath = path.Join(m.targetScheme+":", targetPath)
+	}
+
+	event := newFileMovedEvent(fullSourcePath, fullTargetPath)
+	template := "{{.SourcePath}} -> {{.TargetPath}}\n"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return cmdio.RenderWithTemplate(ctx, event, "", template)
+}
+
+func newMvCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mv SOURCE_PATH TARGET_PATH",
+		Short: "Move files and directories.",
+		Long: `Move files and directories to and from any paths on DBFS, UC Volumes, UC Skills, workspace files or your local filesystem.
+
+	  For paths in DBFS, UC Volumes, UC Skills and workspace files, it is required that you specify the "dbfs" scheme.
+	  For example: dbfs:/foo/bar or dbfs:/Workspace/Users/someone@example.com/foo.
+
+	  If both paths are on DBFS or on your local filesystem, the move is performed
+	  without copying the contents. Otherwise, including moves within UC Volumes
+	  and within workspace files, the files are copied to TARGET_PATH and then
+	  deleted from SOURCE_PATH. Each copied file is reported like with "fs cp",
+	  and SOURCE_PATH is deleted only after all files were copied.
+
+	  When TARGET_PATH is an existing directory, SOURCE_PATH is moved inside it.
+	`,
+		Args: root.ExactArgs(2),
+	}
+
+	var m move
+	cmd.Flags().BoolVar(&m.overwrite, "overwrite", false, "overwrite existing files")
+	cmd.Flags().BoolVarP(&m.recursive, "recursive", "r", false, "recursively move files from directory")
+	cmd.Flags().IntVar(&m.concurrency, "concurrency", defaultConcurrency, "number of parallel copy operations")
+
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if m.concurrency <= 0 {
+			return errInvalidConcurrency
+		}
+		return root.MustWorkspaceClient(cmd, args)
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		sourceFiler, sourcePath, err := filerForWorkspacePath(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		targetFiler, targetPath, err := filerForWorkspacePath(ctx, args[1])
+		if err != nil {
+			return err
+		}
+
+		if isDbfsPath(args[0]) {
+			m.sourceScheme = "dbfs"
+		}
+		if isDbfsPath(args[1]) {
+			m.targetScheme = "dbfs"
+		}
+		m.sourceFiler = sourceFiler
+		m.targetFiler = targetFiler
+
+		// If target path has a trailing separator, trim it.
+		if hasTrailingDirSeparator(args[1]) {
+			targetPath = trimTrailingDirSeparators(targetPath)
+		}
+
+		return m.mv(ctx, sourcePath, targetPath)
+	}
+
+	v := newValidArgs()
+	v.pathArgCount = 2
+	cmd.ValidArgsFunction = v.Validate
+
+	return cmd
+}
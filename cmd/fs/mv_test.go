@@ -0,0 +1,66 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/filer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanMoveInPlace(t *testing.T) {
+	local, err := filer.NewLocalClient("")
+	require.NoError(t, err)
+
+	assert.True(t, canMoveInPlace(local, local))
+	assert.False(t, canMoveInPlace(local, &mockFiler{}))
+	assert.False(t, canMoveInPlace(&mockFiler{}, &mockFiler{}))
+}
+
+func newLocalMove(t *testing.T) *move {
+	f, err := filer.NewLocalClient("")
+	require.NoError(t, err)
+	return &move{
+		copy: copy{
+			recursive:   true,
+			concurrency: 1,
+			sourceFiler: f,
+			targetFiler: f,
+		},
+	}
+}
+
+func TestMvIntoDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "src/a.txt"), "a", time.Now())
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "dst"), 0o755))
+
+	ctx := cmdio.MockDiscard(t.Context())
+	m := newLocalMove(t)
+	require.NoError(t, m.mv(ctx, filepath.Join(dir, "src"), filepath.Join(dir, "dst")))
+
+	assert.NoDirExists(t, filepath.Join(dir, "src"))
+	assert.FileExists(t, filepath.Join(dir, "dst/src/a.txt"))
+}
+
+func TestMvExistingTarget(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.txt"), "a", time.Now())
+	writeFile(t, filepath.Join(dir, "b.txt"), "b", time.Now())
+
+	ctx := cmdio.MockDiscard(t.Context())
+	m := newLocalMove(t)
+	err := m.mv(ctx, filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt"))
+	assert.ErrorContains(t, err, "already exists")
+
+	m.overwrite = true
+	require.NoError(t, m.mv(ctx, filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")))
+	assert.NoFileExists(t, filepath.Join(dir, "a.txt"))
+	b, err := os.ReadFile(filepath.Join(dir, "b.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(b))
+}
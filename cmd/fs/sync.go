@@ -0,0 +1,330 @@
+package fs
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/filer"
+	ignore "github.com/sabhiram/go-gitignore"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+type syncer struct {
+	copy
+
+	delete bool
+	dryRun bool
+
+	// Gitignore-style patterns that select files to synchronize.
+	// Paths are matched relative to the source and target directories.
+	include *ignore.GitIgnore
+	exclude *ignore.GitIgnore
+}
+
+// selected returns true if the file at the relative path is subject to synchronization.
+func (s *syncer) selected(relPath string) bool {
+	if s.include != nil && !s.include.MatchesPath(relPath) {
+		return false
+	}
+	if s.exclude != nil && s.exclude.MatchesPath(relPath) {
+		return false
+	}
+	return true
+}
+
+// listFiles returns information about all files under the specified
+// directory, keyed by their path relative to that directory.
+// A directory that does not exist is treated as empty.
+func listFiles(ctx context.Context, f filer.Filer, dir string) (map[string]fs.FileInfo, error) {
+	out := make(map[string]fs.FileInfo)
+	err := fs.WalkDir(filer.NewFS(ctx, f), dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		out[filepath.ToSlash(relPath)] = info
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return out, nil
+	}
+	return out, err
+}
+
+// needsCopy returns true if the target file is missing or differs from the source file.
+// Like rsync, files are considered different if their size differs or if the source
+// file was modified after the target file.
+func needsCopy(source, target fs.FileInfo) bool {
+	if target == nil {
+		return true
+	}
+	if source.Size() != target.Size() {
+		return true
+	}
+	return source.ModTime().After(target.ModTime())
+}
+
+func (s *syncer) sync(ctx context.Context, sourceDir, targetDir string) error {
+	sourceInfo, err := s.sourceFiler.Stat(ctx, sourceDir)
+	if err != nil {
+		return err
+	}
+	if !sourceInfo.IsDir() {
+		return fmt.Errorf("source path %s is not a directory", sourceDir)
+	}
+
+	sourceFiles, err := listFiles(ctx, s.sourceFiler, sourceDir)
+	if err != nil {
+		return err
+	}
+	targetFiles, err := listFiles(ctx, s.targetFiler, targetDir)
+	if err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(s.concurrency)
+
+	for _, relPath := range slices.Sorted(maps.Keys(sourceFiles)) {
+		if !s.selected(relPath) || !needsCopy(sourceFiles[relPath], targetFiles[relPath]) {
+			continue
+		}
+
+		sourcePath := path.Join(sourceDir, relPath)
+		targetPath := path.Join(targetDir, relPath)
+		g.Go(func() error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return s.syncFile(ctx, sourcePath, targetPath)
+		})
+	}
+
+	var deleted []string
+	if s.delete {
+		for _, relPath := range slices.Sorted(maps.Keys(targetFiles)) {
+			if _, ok := sourceFiles[relPath]; ok || !s.selected(relPath) {
+				continue
+			}
+
+			deleted = append(deleted, relPath)
+			targetPath := path.Join(targetDir, relPath)
+			g.Go(func() error {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return s.deleteFile(ctx, targetPath)
+			})
+		}
+	}
+
+	err = g.Wait()
+	if err != nil || s.dryRun {
+		return err
+	}
+	return s.deleteEmptyDirs(ctx, sourceDir, targetDir, deleted)
+}
+
+// deleteEmptyDirs deletes the directories of the deleted files that no longer
+// contain any entries and do not exist at the source. The target directory
+// itself is never deleted.
+func (s *syncer) deleteEmptyDirs(ctx context.Context, sourceDir, targetDir string, deleted []string) error {
+	dirs := make(map[string]bool)
+	for _, relPath := range deleted {
+		for dir := path.Dir(relPath); dir != "."; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	// Visit nested directories before their parents, so that a parent
+	// that only contained empty directories is deleted as well.
+	sorted := slices.SortedFunc(maps.Keys(dirs), func(a, b string) int {
+		return cmp.Or(
+			cmp.Compare(strings.Count(b, "/"), strings.Count(a, "/")),
+			cmp.Compare(a, b),
+		)
+	})
+
+	for _, dir := range sorted {
+		targetPath := path.Join(targetDir, dir)
+		entries, err := s.targetFiler.ReadDir(ctx, targetPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			continue
+		}
+
+		// Keep empty directories that also exist at the source.
+		_, err = s.sourceFiler.Stat(ctx, path.Join(sourceDir, dir))
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		err = s.deleteFile(ctx, targetPath)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *syncer) syncFile(ctx context.Context, sourcePath, targetPath string) error {
+	if !s.dryRun {
+		r, err := s.sourceFiler.Read(ctx, sourcePath)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		err = s.targetFiler.Write(ctx, targetPath, r, filer.OverwriteIfExists, filer.CreateParentDirectories)
+		if err != nil {
+			return err
+		}
+	}
+
+	event := newFileCopiedEvent(s.fullSourcePath(sourcePath), s.fullTargetPath(targetPath))
+	template := "{{.SourcePath}} -> {{.TargetPath}}\n"
+	if s.dryRun {
+		template = "{{.SourcePath}} -> {{.TargetPath}} (dry run)\n"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cmdio.RenderWithTemplate(ctx, event, "", template)
+}
+
+func (s *syncer) deleteFile(ctx context.Context, targetPath string) error {
+	if !s.dryRun {
+		err := s.targetFiler.Delete(ctx, targetPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	event := newFileDeletedEvent(s.fullTargetPath(targetPath))
+	template := "deleted {{.TargetPath}}\n"
+	if s.dryRun {
+		template = "deleted {{.TargetPath}} (dry run)\n"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cmdio.RenderWithTemplate(ctx, event, "", template)
+}
+
+func (s *syncer) fullSourcePath(p string) string {
+	if s.sourceScheme == "" {
+		return p
+	}
+	return path.Join(s.sourceScheme+":", p)
+}
+
+func (s *syncer) fullTargetPath(p string) string {
+	if s.targetScheme == "" {
+		return p
+	}
+	return path.Join(s.targetScheme+":", p)
+}
+
+func newSyncCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync SOURCE_PATH TARGET_PATH",
+		Short: "Synchronize the contents of two directories.",
+		Long: `Synchronize the contents of a directory to another directory on DBFS, UC Volumes, UC Skills, workspace files or your local filesystem.
+
+	  For paths in DBFS, UC Volumes, UC Skills and workspace files, it is required that you specify the "dbfs" scheme.
+	  For example: dbfs:/foo/bar.
+
+	  Files are copied if they do not exist at TARGET_PATH, if their size differs,
+	  or if the file at SOURCE_PATH was modified more recently than the file at TARGET_PATH.
+	  Use --delete to remove files at TARGET_PATH that do not exist at SOURCE_PATH,
+	  along with the directories that they leave empty.
+
+	  The --include and --exclude flags take gitignore-style patterns that are
+	  matched against paths relative to SOURCE_PATH and TARGET_PATH.
+	`,
+		Args: root.ExactArgs(2),
+	}
+
+	var s syncer
+	var includes, excludes []string
+	cmd.Flags().BoolVar(&s.delete, "delete", false, "delete files at the target that do not exist at the source")
+	cmd.Flags().BoolVar(&s.dryRun, "dry-run", false, "print the changes without applying them")
+	cmd.Flags().StringSliceVar(&includes, "include", nil, "only synchronize files matching these patterns")
+	cmd.Flags().StringSliceVar(&excludes, "exclude", nil, "do not synchronize files matching these patterns")
+	cmd.Flags().IntVar(&s.concurrency, "concurrency", defaultConcurrency, "number of parallel copy operations")
+
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if s.concurrency <= 0 {
+			return errInvalidConcurrency
+		}
+		return root.MustWorkspaceClient(cmd, args)
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		sourceFiler, sourcePath, err := filerForWorkspacePath(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		targetFiler, targetPath, err := filerForWorkspacePath(ctx, args[1])
+		if err != nil {
+			return err
+		}
+
+		if isDbfsPath(args[0]) {
+			s.sourceScheme = "dbfs"
+		}
+		if isDbfsPath(args[1]) {
+			s.targetScheme = "dbfs"
+		}
+		s.sourceFiler = sourceFiler
+		s.targetFiler = targetFiler
+
+		if len(includes) > 0 {
+			s.include = ignore.CompileIgnoreLines(includes...)
+		}
+		if len(excludes) > 0 {
+			s.exclude = ignore.CompileIgnoreLines(excludes...)
+		}
+
+		return s.sync(ctx, sourcePath, targetPath)
+	}
+
+	v := newValidArgs()
+	v.pathArgCount = 2
+	cmd.ValidArgsFunction = v.Validate
+
+	return cmd
+}
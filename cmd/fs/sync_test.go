@@ -0,0 +1,133 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/filer"
+	ignore "github.com/sabhiram/go-gitignore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string, modTime time.Time) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func newLocalSyncer(t *testing.T) *syncer {
+	f, err := filer.NewLocalClient("")
+	require.NoError(t, err)
+	return &syncer{
+		copy: copy{
+			concurrency: 1,
+			sourceFiler: f,
+			targetFiler: f,
+		},
+	}
+}
+
+func TestSync(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	now := time.Now().Truncate(time.Second)
+
+	writeFile(t, filepath.Join(src, "new.txt"), "new", now)
+	writeFile(t, filepath.Join(src, "dir/size.txt"), "changed", now)
+	writeFile(t, filepath.Join(dst, "dir/size.txt"), "old", now)
+	writeFile(t, filepath.Join(src, "newer.txt"), "bbb", now)
+	writeFile(t, filepath.Join(dst, "newer.txt"), "aaa", now.Add(-time.Hour))
+	writeFile(t, filepath.Join(src, "same.txt"), "bbb", now)
+	writeFile(t, filepath.Join(dst, "same.txt"), "aaa", now)
+	writeFile(t, filepath.Join(dst, "stale.txt"), "stale", now)
+
+	ctx := cmdio.MockDiscard(t.Context())
+	s := newLocalSyncer(t)
+	s.delete = true
+	require.NoError(t, s.sync(ctx, src, dst))
+
+	assertContent := func(name, content string) {
+		b, err := os.ReadFile(filepath.Join(dst, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(b))
+	}
+	assertContent("new.txt", "new")
+	assertContent("dir/size.txt", "changed")
+	assertContent("newer.txt", "bbb")
+	assertContent("same.txt", "aaa")
+	assert.NoFileExists(t, filepath.Join(dst, "stale.txt"))
+}
+
+func TestSyncDryRun(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	now := time.Now()
+
+	writeFile(t, filepath.Join(src, "new.txt"), "new", now)
+	writeFile(t, filepath.Join(dst, "stale.txt"), "stale", now)
+
+	ctx, stdout := cmdio.NewTestContextWithStdout(t.Context())
+	s := newLocalSyncer(t)
+	s.delete = true
+	s.dryRun = true
+	require.NoError(t, s.sync(ctx, src, dst))
+
+	assert.NoFileExists(t, filepath.Join(dst, "new.txt"))
+	assert.FileExists(t, filepath.Join(dst, "stale.txt"))
+	assert.Contains(t, stdout.String(), "new.txt (dry run)")
+	assert.Contains(t, stdout.String(), "deleted "+filepath.Join(dst, "stale.txt")+" (dry run)")
+}
+
+func TestSyncIncludeExclude(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	now := time.Now()
+
+	writeFile(t, filepath.Join(src, "a.py"), "a", now)
+	writeFile(t, filepath.Join(src, "b.txt"), "b", now)
+	writeFile(t, filepath.Join(src, "skip/c.py"), "c", now)
+	writeFile(t, filepath.Join(dst, "keep.txt"), "keep", now)
+	writeFile(t, filepath.Join(dst, "remove.py"), "remove", now)
+
+	ctx := cmdio.MockDiscard(t.Context())
+	s := newLocalSyncer(t)
+	s.delete = true
+	s.include = ignore.CompileIgnoreLines("*.py")
+	s.exclude = ignore.CompileIgnoreLines("skip/")
+	require.NoError(t, s.sync(ctx, src, dst))
+
+	assert.FileExists(t, filepath.Join(dst, "a.py"))
+	assert.NoFileExists(t, filepath.Join(dst, "b.txt"))
+	assert.NoFileExists(t, filepath.Join(dst, "skip/c.py"))
+	assert.FileExists(t, filepath.Join(dst, "keep.txt"))
+	assert.NoFileExists(t, filepath.Join(dst, "remove.py"))
+}
+
+func TestSyncDeletesEmptiedDirectories(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	now := time.Now()
+
+	writeFile(t, filepath.Join(src, "keep/a.txt"), "a", now)
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "empty"), 0o755))
+	writeFile(t, filepath.Join(dst, "keep/a.txt"), "a", now)
+	writeFile(t, filepath.Join(dst, "keep/stale.txt"), "stale", now)
+	writeFile(t, filepath.Join(dst, "gone/nested/stale.txt"), "stale", now)
+	writeFile(t, filepath.Join(dst, "empty/stale.txt"), "stale", now)
+
+	ctx := cmdio.MockDiscard(t.Context())
+	s := newLocalSyncer(t)
+	s.delete = true
+	require.NoError(t, s.sync(ctx, src, dst))
+
+	assert.FileExists(t, filepath.Join(dst, "keep/a.txt"))
+	assert.NoFileExists(t, filepath.Join(dst, "keep/stale.txt"))
+	assert.NoDirExists(t, filepath.Join(dst, "gone"))
+	assert.DirExists(t, filepath.Join(dst, "empty"))
+	assert.NoFileExists(t, filepath.Join(dst, "empty/stale.txt"))
+	assert.DirExists(t, dst)
+}
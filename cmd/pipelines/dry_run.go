@@ -3,8 +3,6 @@
 package pipelines
 
 import (
-	"encoding/json"
-	"fmt"
 	"maps"
 	"slices"
 
@@ -13,6 +11,7 @@ import (
 	"github.com/databricks/cli/bundle/run/output"
 	"github.com/databricks/cli/cmd/bundle/utils"
 	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
 	"github.com/databricks/cli/libs/flags"
 	"github.com/databricks/cli/libs/logdiag"
 	"github.com/spf13/cobra"
@@ -85,18 +84,11 @@ If there is only one pipeline in the project, KEY is optional and the pipeline w
 				if err != nil {
 					return err
 				}
-			case flags.OutputJSON:
-				b, err := json.MarshalIndent(output, "", "  ")
-				if err != nil {
-					return err
-				}
-				_, err = cmd.OutOrStdout().Write(b)
+			default:
+				err := cmdio.Render(ctx, output)
 				if err != nil {
 					return err
 				}
-				_, _ = cmd.OutOrStdout().Write([]byte{'\n'})
-			default:
-				return fmt.Errorf("unknown output type %s", root.OutputType(cmd))
 			}
 		}
 		return nil
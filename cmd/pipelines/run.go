@@ -4,7 +4,6 @@ package pipelines
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"maps"
@@ -308,18 +307,11 @@ Refreshes all tables in the pipeline unless otherwise specified.`,
 				if err != nil {
 					return err
 				}
-			case flags.OutputJSON:
-				b, err := json.MarshalIndent(runOutput, "", "  ")
-				if err != nil {
-					return err
-				}
-				_, err = cmd.OutOrStdout().Write(b)
+			default:
+				err := cmdio.Render(ctx, runOutput)
 				if err != nil {
 					return err
 				}
-				_, _ = cmd.OutOrStdout().Write([]byte{'\n'})
-			default:
-				return fmt.Errorf("unknown output type %s", root.OutputType(cmd))
 			}
 		}
 
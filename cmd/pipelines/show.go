@@ -93,18 +93,16 @@ func resolveWarehouseID(ctx context.Context, w *databricks.WorkspaceClient, flag
 	return warehouse.Id, nil
 }
 
-// writes the result as JSON (--output json) or a terminal-width-aware table.
+// writes the result as a terminal-width-aware table or in the selected structured output format.
 func render(ctx context.Context, cmd *cobra.Command, columns []string, rows [][]string) error {
 	out := cmd.OutOrStdout()
 	switch root.OutputType(cmd) {
-	case flags.OutputJSON:
-		return renderJSON(out, columns, rows)
 	case flags.OutputText:
 		if len(columns) == 0 {
 			return nil
 		}
 		return renderTable(ctx, columns, rows, detectWidth(out))
 	default:
-		return fmt.Errorf("unknown output type %s", root.OutputType(cmd))
+		return renderRows(ctx, columns, rows)
 	}
 }
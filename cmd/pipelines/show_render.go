@@ -67,18 +67,13 @@ func (r orderedRow) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// writes rows as an indented JSON array of column-ordered objects.
-func renderJSON(w io.Writer, columns []string, rows [][]string) error {
+// writes rows as a list of column-ordered objects in the selected output format.
+func renderRows(ctx context.Context, columns []string, rows [][]string) error {
 	objects := make([]orderedRow, len(rows))
 	for i, row := range rows {
 		objects[i] = orderedRow{columns: columns, values: row}
 	}
-	output, err := json.MarshalIndent(objects, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal results: %w", err)
-	}
-	fmt.Fprintf(w, "%s\n", output)
-	return nil
+	return cmdio.Render(ctx, objects)
 }
 
 // renderTable writes a width-aware text table: cells cap at maxColumnWidth, and a positive width that the table exceeds drops trailing columns behind a "..." marker.
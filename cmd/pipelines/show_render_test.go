@@ -21,32 +21,38 @@ func renderTableString(t *testing.T, columns []string, rows [][]string, width in
 	return buf.String()
 }
 
-func TestRenderJSON(t *testing.T) {
+func renderRowsString(t *testing.T, format flags.Output, columns []string, rows [][]string) string {
+	t.Helper()
 	var buf bytes.Buffer
-	err := renderJSON(&buf, []string{"id", "name"}, [][]string{{"1", "alice"}, {"2", "bob"}})
-	require.NoError(t, err)
-	assert.JSONEq(t, `[{"id":"1","name":"alice"},{"id":"2","name":"bob"}]`, buf.String())
+	io := cmdio.NewIO(t.Context(), format, nil, &buf, &buf, "", "")
+	ctx := cmdio.InContext(t.Context(), io)
+	require.NoError(t, renderRows(ctx, columns, rows))
+	return buf.String()
+}
+
+func TestRenderJSON(t *testing.T) {
+	out := renderRowsString(t, flags.OutputJSON, []string{"id", "name"}, [][]string{{"1", "alice"}, {"2", "bob"}})
+	assert.JSONEq(t, `[{"id":"1","name":"alice"},{"id":"2","name":"bob"}]`, out)
 }
 
 func TestRenderJSONEmpty(t *testing.T) {
-	var buf bytes.Buffer
-	err := renderJSON(&buf, []string{"id"}, nil)
-	require.NoError(t, err)
-	assert.JSONEq(t, `[]`, buf.String())
+	out := renderRowsString(t, flags.OutputJSON, []string{"id"}, nil)
+	assert.JSONEq(t, `[]`, out)
 }
 
 func TestRenderJSONPreservesColumnOrder(t *testing.T) {
-	var buf bytes.Buffer
-	err := renderJSON(&buf, []string{"name", "id"}, [][]string{{"alice", "1"}})
-	require.NoError(t, err)
-	assert.Equal(t, "[\n  {\n    \"name\": \"alice\",\n    \"id\": \"1\"\n  }\n]\n", buf.String())
+	out := renderRowsString(t, flags.OutputJSON, []string{"name", "id"}, [][]string{{"alice", "1"}})
+	assert.Equal(t, "[\n  {\n    \"name\": \"alice\",\n    \"id\": \"1\"\n  }\n]\n", out)
+}
+
+func TestRenderCSVPreservesColumnOrder(t *testing.T) {
+	out := renderRowsString(t, flags.OutputCSV, []string{"name", "id"}, [][]string{{"alice", "1"}})
+	assert.Equal(t, "name,id\nalice,1\n", out)
 }
 
 func TestRenderJSONEscapesValues(t *testing.T) {
-	var buf bytes.Buffer
-	err := renderJSON(&buf, []string{"c"}, [][]string{{`a"b	c`}})
-	require.NoError(t, err)
-	assert.JSONEq(t, `[{"c":"a\"b\tc"}]`, buf.String())
+	out := renderRowsString(t, flags.OutputJSON, []string{"c"}, [][]string{{`a"b	c`}})
+	assert.JSONEq(t, `[{"c":"a\"b\tc"}]`, out)
 }
 
 func TestRenderTableNoCap(t *testing.T) {
@@ -0,0 +1,30 @@
+package root
+
+import "errors"
+
+// ExitCodeError makes the CLI exit with the specified status instead of 1.
+// Commands use it to let scripts tell different outcomes apart, for example
+// "bundle drift" exits with status 2 when it detects drift.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the status the CLI exits with for the error returned by [Execute].
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if e, ok := errors.AsType[*ExitCodeError](err); ok {
+		return e.Code
+	}
+	return 1
+}
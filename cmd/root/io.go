@@ -13,6 +13,7 @@ const envOutputFormat = "DATABRICKS_OUTPUT_FORMAT"
 
 type outputFlag struct {
 	output flags.Output
+	query  string
 }
 
 func initOutputFlag(cmd *cobra.Command) *outputFlag {
@@ -26,7 +27,8 @@ func initOutputFlag(cmd *cobra.Command) *outputFlag {
 		f.output.Set(v) //nolint:errcheck
 	}
 
-	cmd.PersistentFlags().VarP(&f.output, "output", "o", "output type: text or json")
+	cmd.PersistentFlags().VarP(&f.output, "output", "o", "output type: text, json, yaml, csv, tsv or ndjson")
+	cmd.PersistentFlags().StringVar(&f.query, "query", "", "JMESPath expression to select and reshape the output")
 	return &f
 }
 
@@ -49,6 +51,13 @@ func (f *outputFlag) initializeIO(ctx context.Context, cmd *cobra.Command) (cont
 
 	cmdIO := cmdio.NewIO(ctx, f.output, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), headerTemplate, template)
 	ctx = cmdio.InContext(ctx, cmdIO)
+	if f.query != "" {
+		var err error
+		ctx, err = cmdio.WithQuery(ctx, f.query)
+		if err != nil {
+			return ctx, err
+		}
+	}
 	cmd.SetContext(ctx)
 	return ctx, nil
 }
@@ -0,0 +1,56 @@
+package root
+
+import (
+	"context"
+	"errors"
+
+	"github.com/databricks/cli/libs/env"
+	"github.com/databricks/cli/libs/httprecord"
+	"github.com/spf13/cobra"
+)
+
+const (
+	envRecord = "DATABRICKS_CLI_RECORD"
+	envReplay = "DATABRICKS_CLI_REPLAY"
+)
+
+type recordFlags struct {
+	record string
+	replay string
+}
+
+func initRecordFlags(cmd *cobra.Command) *recordFlags {
+	f := recordFlags{}
+	cmd.PersistentFlags().StringVar(&f.record, "record", "", "append the API requests and responses of this command to `FILE`, with secrets redacted")
+	cmd.PersistentFlags().StringVar(&f.replay, "replay", "", "answer API requests with the responses recorded in `FILE` instead of calling the API")
+	return &f
+}
+
+func (f *recordFlags) initializeContext(ctx context.Context, cmd *cobra.Command) (context.Context, error) {
+	record, replay := f.record, f.replay
+
+	// Flags take precedence over the environment, and a flag for one mode
+	// overrides the environment variable for the other.
+	if !cmd.Flags().Changed("record") && !cmd.Flags().Changed("replay") {
+		record = env.Get(ctx, envRecord)
+		replay = env.Get(ctx, envReplay)
+	}
+
+	switch {
+	case record != "" && replay != "":
+		return ctx, errors.New("cannot record and replay API requests at the same time")
+	case record != "":
+		r, err := httprecord.NewRecorder(record)
+		if err != nil {
+			return ctx, err
+		}
+		return httprecord.WithRecorder(ctx, r), nil
+	case replay != "":
+		r, err := httprecord.NewReplayer(replay)
+		if err != nil {
+			return ctx, err
+		}
+		return httprecord.WithReplayer(ctx, r), nil
+	}
+	return ctx, nil
+}
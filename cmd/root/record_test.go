@@ -0,0 +1,52 @@
+package root
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/libs/env"
+	"github.com/databricks/cli/libs/httprecord"
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initRecordContext(t *testing.T, ctx context.Context, args ...string) (context.Context, error) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.SetContext(ctx)
+	f := initRecordFlags(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	return f.initializeContext(ctx, cmd)
+}
+
+func TestRecordFlagsFromEnvironment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.ndjson")
+	ctx := env.Set(t.Context(), envRecord, path)
+
+	ctx, err := initRecordContext(t, ctx)
+	require.NoError(t, err)
+	assert.FileExists(t, path)
+
+	cfg := &config.Config{}
+	httprecord.Configure(ctx, cfg)
+	assert.NotNil(t, cfg.HTTPTransport)
+}
+
+func TestReplayFlagOverridesRecordEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	replay := filepath.Join(dir, "replay.ndjson")
+	require.NoError(t, os.WriteFile(replay, nil, 0o600))
+	ctx := env.Set(t.Context(), envRecord, filepath.Join(dir, "record.ndjson"))
+
+	_, err := initRecordContext(t, ctx, "--replay", replay)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "record.ndjson"))
+}
+
+func TestRecordAndReplayFlagsConflict(t *testing.T) {
+	_, err := initRecordContext(t, t.Context(), "--record", "a", "--replay", "b")
+	assert.EqualError(t, err, "cannot record and replay API requests at the same time")
+}
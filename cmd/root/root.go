@@ -8,6 +8,7 @@ import (
 	"os"
 	"runtime"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 
@@ -47,6 +48,7 @@ func New(ctx context.Context) *cobra.Command {
 	// Initialize flags
 	logFlags := initLogFlags(cmd)
 	outputFlag := initOutputFlag(cmd)
+	recordFlags := initRecordFlags(cmd)
 	initProfileFlag(cmd)
 	initEnvironmentFlag(cmd)
 	initTargetFlag(cmd)
@@ -71,6 +73,12 @@ func New(ctx context.Context) *cobra.Command {
 			return err
 		}
 
+		// Record or replay API requests if asked to.
+		ctx, err = recordFlags.initializeContext(ctx, cmd)
+		if err != nil {
+			return err
+		}
+
 		logger := log.GetLogger(ctx)
 		logger.Info("start",
 			slog.String("version", build.GetInfo().Version),
@@ -152,6 +160,10 @@ Stack Trace:
 
 	// Run the command
 	cmd, err = cmd.ExecuteContextC(ctx)
+	if err == nil {
+		// Commands that do not render their output with cmdio cannot apply --query.
+		err = cmdio.CheckQueryApplied(cmd.Context())
+	}
 	if err != nil && !errors.Is(err, ErrAlreadyPrinted) {
 		if cmdctx.HasConfigUsed(cmd.Context()) {
 			cfg := cmdctx.ConfigUsed(cmd.Context())
@@ -166,6 +178,8 @@ Stack Trace:
 		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s\n", err.Error())
 	}
 
+	exitCode := ExitCode(err)
+
 	// Log exit status and error
 	// We only log if logger initialization succeeded and is stored in command
 	// context
@@ -175,21 +189,16 @@ Stack Trace:
 				slog.String("exit_code", "0"))
 		} else if errors.Is(err, ErrAlreadyPrinted) {
 			logger.Debug("failed execution",
-				slog.String("exit_code", "1"),
+				slog.String("exit_code", strconv.Itoa(exitCode)),
 			)
 		} else {
 			logger.Info("failed execution",
-				slog.String("exit_code", "1"),
+				slog.String("exit_code", strconv.Itoa(exitCode)),
 				slog.String("error", err.Error()),
 			)
 		}
 	}
 
-	exitCode := 0
-	if err != nil {
-		exitCode = 1
-	}
-
 	commandStr := commandString(cmd)
 	ctx = cmd.Context()
 
@@ -177,3 +177,24 @@ func TestExecuteErrAlreadyPrintedNotEnriched(t *testing.T) {
 	require.Error(t, err)
 	assert.Empty(t, stderr.String())
 }
+
+func TestExecuteExitCodeError(t *testing.T) {
+	ctx := t.Context()
+	stderr := &bytes.Buffer{}
+
+	cmd := &cobra.Command{
+		Use:           "test",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return &ExitCodeError{Code: 2, Err: ErrAlreadyPrinted}
+		},
+	}
+	cmd.SetErr(stderr)
+
+	err := Execute(ctx, cmd)
+	assert.Equal(t, 2, ExitCode(err))
+	assert.Empty(t, stderr.String())
+	assert.Equal(t, 1, ExitCode(errors.New("failed")))
+	assert.Equal(t, 0, ExitCode(nil))
+}
@@ -28,7 +28,12 @@ type syncFlags struct {
 	// project files polling interval
 	interval    time.Duration
 	full        bool
+	checksum    bool
 	watch       bool
+	watchMode   sync.WatchMode
+	direction   sync.Direction
+	onConflict  sync.ConflictPolicy
+	branchCheck sync.BranchCheck
 	exclude     []string
 	include     []string
 	dryRun      bool
@@ -81,7 +86,12 @@ func (f *syncFlags) syncOptionsFromBundle(cmd *cobra.Command, args []string, b *
 	}
 
 	opts.Full = f.full
+	opts.Checksum = f.checksum
 	opts.PollInterval = f.interval
+	opts.WatchMode = f.watchMode
+	opts.Direction = f.direction
+	opts.OnConflict = f.onConflict
+	opts.BranchCheck = f.branchCheck
 	opts.WorktreeRoot = b.WorktreeRoot
 	opts.Exclude = append(opts.Exclude, f.exclude...)
 	opts.Exclude = append(opts.Exclude, excludePatterns...)
@@ -100,15 +110,14 @@ func (f *syncFlags) syncOptionsFromArgs(cmd *cobra.Command, args []string) (*syn
 	switch root.OutputType(cmd) {
 	case flags.OutputText:
 		outputFunc = sync.TextOutput
-	case flags.OutputJSON:
+	case flags.OutputJSON, flags.OutputNDJSON:
 		outputFunc = sync.JsonOutput
+	default:
+		return nil, fmt.Errorf("%s output is not supported by sync; use text, json or ndjson", root.OutputType(cmd))
 	}
 
-	var outputHandler sync.OutputHandler
-	if outputFunc != nil {
-		outputHandler = func(ctx context.Context, events <-chan sync.Event) {
-			outputFunc(ctx, events, cmd.OutOrStdout())
-		}
+	outputHandler := func(ctx context.Context, events <-chan sync.Event) {
+		outputFunc(ctx, events, cmd.OutOrStdout())
 	}
 
 	ctx := cmd.Context()
@@ -151,7 +160,12 @@ func (f *syncFlags) syncOptionsFromArgs(cmd *cobra.Command, args []string) (*syn
 
 		RemotePath:   args[1],
 		Full:         f.full,
+		Checksum:     f.checksum,
 		PollInterval: f.interval,
+		WatchMode:    f.watchMode,
+		Direction:    f.direction,
+		OnConflict:   f.onConflict,
+		BranchCheck:  f.branchCheck,
 
 		// We keep existing behavior for VS Code extension where if there is
 		// no bundle defined, we store the snapshots in `.databricks`.
@@ -177,7 +191,16 @@ func New() *cobra.Command {
 	var f syncFlags
 	cmd.Flags().DurationVar(&f.interval, "interval", 1*time.Second, "file system polling interval (for --watch)")
 	cmd.Flags().BoolVar(&f.full, "full", false, "perform full synchronization (default is incremental)")
+	cmd.Flags().BoolVar(&f.checksum, "checksum", false, "compare file contents to skip files whose contents did not change")
 	cmd.Flags().BoolVar(&f.watch, "watch", false, "watch local file system for changes")
+	f.watchMode = sync.WatchModeAuto
+	cmd.Flags().Var(&f.watchMode, "watch-mode", "how to detect local changes (for --watch): auto, notify, or poll")
+	f.direction = sync.DirectionPush
+	cmd.Flags().Var(&f.direction, "direction", "direction of synchronization: push, pull, or both")
+	f.onConflict = sync.ConflictKeepLocal
+	cmd.Flags().Var(&f.onConflict, "on-conflict", "how to resolve files changed both locally and remotely (for --direction=pull|both): keep-local, keep-remote, or copy (to a local .conflict file that is not uploaded)")
+	f.branchCheck = sync.BranchCheckOff
+	cmd.Flags().Var(&f.branchCheck, "branch-check", "what to do if DST is in a Git folder with a different branch checked out: off, warn, or error")
 	cmd.Flags().StringSliceVar(&f.exclude, "exclude", nil, "patterns to exclude from sync (can be specified multiple times)")
 	cmd.Flags().StringSliceVar(&f.include, "include", nil, "patterns to include in sync (can be specified multiple times)")
 	cmd.Flags().StringVar(&f.excludeFrom, "exclude-from", "", "file containing patterns to exclude from sync (one pattern per line)")
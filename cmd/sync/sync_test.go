@@ -75,6 +75,15 @@ func TestSyncOptionsFromArgs(t *testing.T) {
 	assert.Equal(t, remote, opts.RemotePath)
 }
 
+func TestSyncOptionsFromArgsUnsupportedOutput(t *testing.T) {
+	f := syncFlags{}
+	cmd := newTestSyncCommand(t)
+	cmd.SetContext(cmdctx.SetWorkspaceClient(t.Context(), nil))
+	require.NoError(t, cmd.ParseFlags([]string{"-o", "yaml"}))
+	_, err := f.syncOptionsFromArgs(cmd, []string{t.TempDir(), "/remote"})
+	assert.EqualError(t, err, "yaml output is not supported by sync; use text, json or ndjson")
+}
+
 func TestSyncShorthandFlags(t *testing.T) {
 	cmd := newTestSyncCommand(t)
 	require.NoError(t, cmd.ParseFlags([]string{"-o", "json", "-p", "myprofile"}))
@@ -134,137 +134,144 @@ import (
 	users "github.com/databricks/cli/cmd/workspace/users"
 )
 
-func All() []*cobra.Command {
-	var out []*cobra.Command
-
-	out = append(out, access_control.New())
-	out = append(out, agent_bricks.New())
-	out = append(out, ai_gateway.New())
-	out = append(out, ai_search.New())
-	out = append(out, alerts.New())
-	out = append(out, alerts_legacy.New())
-	out = append(out, alerts_v2.New())
-	out = append(out, apps.New())
-	out = append(out, apps_settings.New())
-	out = append(out, artifact_allowlists.New())
-	out = append(out, bundle_deployments.New())
-	out = append(out, catalogs.New())
-	out = append(out, clean_room_asset_revisions.New())
-	out = append(out, clean_room_assets.New())
-	out = append(out, clean_room_auto_approval_rules.New())
-	out = append(out, clean_room_task_runs.New())
-	out = append(out, clean_rooms.New())
-	out = append(out, cluster_policies.New())
-	out = append(out, clusters.New())
-	out = append(out, connections.New())
-	out = append(out, consumer_fulfillments.New())
-	out = append(out, consumer_installations.New())
-	out = append(out, consumer_listings.New())
-	out = append(out, consumer_personalization_requests.New())
-	out = append(out, consumer_providers.New())
-	out = append(out, credentials.New())
-	out = append(out, credentials_manager.New())
-	out = append(out, current_user.New())
-	out = append(out, dashboard_widgets.New())
-	out = append(out, dashboards.New())
-	out = append(out, data_classification.New())
-	out = append(out, data_quality.New())
-	out = append(out, data_sources.New())
-	out = append(out, database.New())
-	out = append(out, entity_tag_assignments.New())
-	out = append(out, environments.New())
-	out = append(out, experiments.New())
-	out = append(out, external_lineage.New())
-	out = append(out, external_locations.New())
-	out = append(out, external_metadata.New())
-	out = append(out, feature_engineering.New())
-	out = append(out, feature_store.New())
-	out = append(out, forecasting.New())
-	out = append(out, functions.New())
-	out = append(out, genie.New())
-	out = append(out, git_credentials.New())
-	out = append(out, global_init_scripts.New())
-	out = append(out, grants.New())
-	out = append(out, groups_v2.New())
-	out = append(out, instance_pools.New())
-	out = append(out, instance_profiles.New())
-	out = append(out, ip_access_lists.New())
-	out = append(out, jobs.New())
-	out = append(out, knowledge_assistants.New())
-	out = append(out, lakeview.New())
-	out = append(out, lakeview_embedded.New())
-	out = append(out, libraries.New())
-	out = append(out, materialized_features.New())
-	out = append(out, metastores.New())
-	out = append(out, model_registry.New())
-	out = append(out, model_versions.New())
-	out = append(out, notification_destinations.New())
-	out = append(out, online_tables.New())
-	out = append(out, permission_migration.New())
-	out = append(out, permissions.New())
-	out = append(out, pipelines.New())
-	out = append(out, policies.New())
-	out = append(out, policy_compliance_for_clusters.New())
-	out = append(out, policy_compliance_for_jobs.New())
-	out = append(out, policy_families.New())
-	out = append(out, postgres.New())
-	out = append(out, provider_exchange_filters.New())
-	out = append(out, provider_exchanges.New())
-	out = append(out, provider_files.New())
-	out = append(out, provider_listings.New())
-	out = append(out, provider_personalization_requests.New())
-	out = append(out, provider_provider_analytics_dashboards.New())
-	out = append(out, provider_providers.New())
-	out = append(out, providers.New())
-	out = append(out, quality_monitor_v2.New())
-	out = append(out, quality_monitors.New())
-	out = append(out, queries.New())
-	out = append(out, queries_legacy.New())
-	out = append(out, query_history.New())
-	out = append(out, query_visualizations.New())
-	out = append(out, query_visualizations_legacy.New())
-	out = append(out, recipient_activation.New())
-	out = append(out, recipient_federation_policies.New())
-	out = append(out, recipients.New())
-	out = append(out, redash_config.New())
-	out = append(out, registered_models.New())
-	out = append(out, repos.New())
-	out = append(out, resource_quotas.New())
-	out = append(out, rfa.New())
-	out = append(out, schemas.New())
-	out = append(out, secrets.New())
-	out = append(out, secrets_uc.New())
-	out = append(out, service_principal_secrets_proxy.New())
-	out = append(out, service_principals_v2.New())
-	out = append(out, serving_endpoints.New())
-	out = append(out, settings.New())
-	out = append(out, shares.New())
-	out = append(out, storage_credentials.New())
-	out = append(out, supervisor_agents.New())
-	out = append(out, system_schemas.New())
-	out = append(out, table_constraints.New())
-	out = append(out, tables.New())
-	out = append(out, tag_policies.New())
-	out = append(out, temporary_path_credentials.New())
-	out = append(out, temporary_table_credentials.New())
-	out = append(out, temporary_volume_credentials.New())
-	out = append(out, token_management.New())
-	out = append(out, tokens.New())
-	out = append(out, users_v2.New())
-	out = append(out, vector_search_endpoints.New())
-	out = append(out, vector_search_indexes.New())
-	out = append(out, volumes.New())
-	out = append(out, warehouses.New())
-	out = append(out, workspace.New())
-	out = append(out, workspace_bindings.New())
-	out = append(out, workspace_conf.New())
-	out = append(out, workspace_entity_tag_assignments.New())
-	out = append(out, workspace_iam_v2.New())
-	out = append(out, workspace_settings_v2.New())
+// Constructors returns the constructors of the workspace command groups.
+func Constructors() []func() *cobra.Command {
+	return []func() *cobra.Command{
+		access_control.New,
+		agent_bricks.New,
+		ai_gateway.New,
+		ai_search.New,
+		alerts.New,
+		alerts_legacy.New,
+		alerts_v2.New,
+		apps.New,
+		apps_settings.New,
+		artifact_allowlists.New,
+		bundle_deployments.New,
+		catalogs.New,
+		clean_room_asset_revisions.New,
+		clean_room_assets.New,
+		clean_room_auto_approval_rules.New,
+		clean_room_task_runs.New,
+		clean_rooms.New,
+		cluster_policies.New,
+		clusters.New,
+		connections.New,
+		consumer_fulfillments.New,
+		consumer_installations.New,
+		consumer_listings.New,
+		consumer_personalization_requests.New,
+		consumer_providers.New,
+		credentials.New,
+		credentials_manager.New,
+		current_user.New,
+		dashboard_widgets.New,
+		dashboards.New,
+		data_classification.New,
+		data_quality.New,
+		data_sources.New,
+		database.New,
+		entity_tag_assignments.New,
+		environments.New,
+		experiments.New,
+		external_lineage.New,
+		external_locations.New,
+		external_metadata.New,
+		feature_engineering.New,
+		feature_store.New,
+		forecasting.New,
+		functions.New,
+		genie.New,
+		git_credentials.New,
+		global_init_scripts.New,
+		grants.New,
+		groups_v2.New,
+		instance_pools.New,
+		instance_profiles.New,
+		ip_access_lists.New,
+		jobs.New,
+		knowledge_assistants.New,
+		lakeview.New,
+		lakeview_embedded.New,
+		libraries.New,
+		materialized_features.New,
+		metastores.New,
+		model_registry.New,
+		model_versions.New,
+		notification_destinations.New,
+		online_tables.New,
+		permission_migration.New,
+		permissions.New,
+		pipelines.New,
+		policies.New,
+		policy_compliance_for_clusters.New,
+		policy_compliance_for_jobs.New,
+		policy_families.New,
+		postgres.New,
+		provider_exchange_filters.New,
+		provider_exchanges.New,
+		provider_files.New,
+		provider_listings.New,
+		provider_personalization_requests.New,
+		provider_provider_analytics_dashboards.New,
+		provider_providers.New,
+		providers.New,
+		quality_monitor_v2.New,
+		quality_monitors.New,
+		queries.New,
+		queries_legacy.New,
+		query_history.New,
+		query_visualizations.New,
+		query_visualizations_legacy.New,
+		recipient_activation.New,
+		recipient_federation_policies.New,
+		recipients.New,
+		redash_config.New,
+		registered_models.New,
+		repos.New,
+		resource_quotas.New,
+		rfa.New,
+		schemas.New,
+		secrets.New,
+		secrets_uc.New,
+		service_principal_secrets_proxy.New,
+		service_principals_v2.New,
+		serving_endpoints.New,
+		settings.New,
+		shares.New,
+		storage_credentials.New,
+		supervisor_agents.New,
+		system_schemas.New,
+		table_constraints.New,
+		tables.New,
+		tag_policies.New,
+		temporary_path_credentials.New,
+		temporary_table_credentials.New,
+		temporary_volume_credentials.New,
+		token_management.New,
+		tokens.New,
+		users_v2.New,
+		vector_search_endpoints.New,
+		vector_search_indexes.New,
+		volumes.New,
+		warehouses.New,
+		workspace.New,
+		workspace_bindings.New,
+		workspace_conf.New,
+		workspace_entity_tag_assignments.New,
+		workspace_iam_v2.New,
+		workspace_settings_v2.New,
 
-	out = append(out, groups.New())
-	out = append(out, service_principals.New())
-	out = append(out, users.New())
+		groups.New,
+		service_principals.New,
+		users.New,
+	}
+}
 
+func All() []*cobra.Command {
+	var out []*cobra.Command
+	for _, newGroup := range Constructors() {
+		out = append(out, newGroup())
+	}
 	return out
 }
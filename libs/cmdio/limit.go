@@ -5,7 +5,8 @@ import "context"
 type limitKeyType struct{}
 
 // WithLimit attaches a result limit to the context.
-// Iterator renderers will stop after emitting this many items.
+// Iterator renderers will stop after emitting this many items, without
+// checking the iterator for more (which may fetch another page).
 func WithLimit(ctx context.Context, limit int) context.Context {
 	return context.WithValue(ctx, limitKeyType{}, limit)
 }
@@ -0,0 +1,140 @@
+package cmdio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/databricks/cli/libs/flags"
+	"github.com/jmespath/go-jmespath"
+)
+
+type queryKeyType struct{}
+
+// query is the JMESPath expression of the --query flag.
+type query struct {
+	expr *jmespath.JMESPath
+
+	// applied is set once the query has been applied to the output.
+	applied atomic.Bool
+}
+
+// WithQuery attaches a JMESPath expression (https://jmespath.org) to the context.
+// Renderers apply it to the output before formatting it.
+func WithQuery(ctx context.Context, expr string) (context.Context, error) {
+	compiled, err := jmespath.Compile(expr)
+	if err != nil {
+		return ctx, fmt.Errorf("invalid --query: %w", err)
+	}
+	return context.WithValue(ctx, queryKeyType{}, &query{expr: compiled}), nil
+}
+
+// WithoutQuery removes the query from the context, for output it does not apply to.
+func WithoutQuery(ctx context.Context) context.Context {
+	return context.WithValue(ctx, queryKeyType{}, (*query)(nil))
+}
+
+// queryFromContext returns the query from the context, or nil if none is set.
+func queryFromContext(ctx context.Context) *query {
+	q, ok := ctx.Value(queryKeyType{}).(*query)
+	if !ok {
+		return nil
+	}
+	return q
+}
+
+// CheckQueryApplied returns an error if the context has a query that was not
+// applied, because the command did not render its output with [Render] or
+// [RenderIterator], or not in the selected output format.
+func CheckQueryApplied(ctx context.Context) error {
+	q := queryFromContext(ctx)
+	if q == nil || q.applied.Load() {
+		return nil
+	}
+	return errors.New("--query was not applied: this command does not support it with the selected output format")
+}
+
+type valueRenderer interface {
+	// Return the value to render in the generic representation of [toGeneric].
+	value(context.Context) (any, error)
+}
+
+func (d defaultRenderer) value(context.Context) (any, error) {
+	return toGeneric(d.t)
+}
+
+func (ir iteratorRenderer[T]) value(ctx context.Context) (any, error) {
+	// The query applies to the list as a whole, so all items are collected first.
+	var items []T
+	limit := limitFromContext(ctx)
+	for i := 0; (limit <= 0 || i < limit) && ir.t.HasNext(ctx); i++ {
+		n, err := ir.t.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, n)
+	}
+	if items == nil {
+		return []any{}, nil
+	}
+	return toGeneric(items)
+}
+
+// applyQuery evaluates q against the value of r and returns a renderer for the result.
+func applyQuery(ctx context.Context, r any, q *query) (any, error) {
+	vr, ok := r.(valueRenderer)
+	if !ok {
+		return nil, errors.New("--query is not supported for this command")
+	}
+	v, err := vr.value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The query is evaluated against plain JSON values, which jmespath expects.
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var in any
+	err = json.Unmarshal(data, &in)
+	if err != nil {
+		return nil, err
+	}
+	out, err := q.expr.Search(in)
+	if err != nil {
+		return nil, fmt.Errorf("--query: %w", err)
+	}
+	q.applied.Store(true)
+
+	v, err = toGeneric(out)
+	if err != nil {
+		return nil, err
+	}
+	return queryResult{v: v}, nil
+}
+
+// queryResult renders the result of a query. In text output, strings are printed
+// as is and all other values as JSON.
+type queryResult struct {
+	v any
+}
+
+func (qr queryResult) renderText(ctx context.Context, w io.Writer) error {
+	if s, ok := qr.v.(string); ok {
+		_, err := io.WriteString(w, s+"\n")
+		return err
+	}
+	return qr.renderJson(ctx, newBufferedFlusher(w))
+}
+
+func (qr queryResult) renderJson(ctx context.Context, w writeFlusher) error {
+	return defaultRenderer{t: qr.v}.renderJson(ctx, w)
+}
+
+func (qr queryResult) renderStructured(_ context.Context, format flags.Output, w writeFlusher) error {
+	return writeStructured(format, w, qr.v)
+}
@@ -103,10 +103,7 @@ func (ir iteratorRenderer[T]) renderJson(ctx context.Context, w writeFlusher) er
 		return err
 	}
 	limit := limitFromContext(ctx)
-	for i := 0; ir.t.HasNext(ctx); i++ {
-		if limit > 0 && i >= limit {
-			break
-		}
+	for i := 0; (limit <= 0 || i < limit) && ir.t.HasNext(ctx); i++ {
 		if i != 0 {
 			_, err = w.Write([]byte(",\n  "))
 			if err != nil {
@@ -142,10 +139,7 @@ func (ir iteratorRenderer[T]) renderJson(ctx context.Context, w writeFlusher) er
 func (ir iteratorRenderer[T]) renderTemplate(ctx context.Context, t *template.Template, w *tabwriter.Writer) error {
 	buf := make([]any, 0, ir.getBufferSize())
 	limit := limitFromContext(ctx)
-	for i := 0; ir.t.HasNext(ctx); i++ {
-		if limit > 0 && i >= limit {
-			break
-		}
+	for i := 0; (limit <= 0 || i < limit) && ir.t.HasNext(ctx); i++ {
 		n, err := ir.t.Next(ctx)
 		if err != nil {
 			return err
@@ -201,6 +195,7 @@ func (d defaultRenderer) renderTemplate(_ context.Context, t *template.Template,
 //   - jsonRenderer
 //   - textRenderer
 //   - templateRenderer
+//   - structuredRenderer
 func newRenderer(t any) any {
 	if r, ok := t.(io.Reader); ok {
 		return readerRenderer{reader: r}
@@ -238,6 +233,16 @@ func newBufferedFlusher(w io.Writer) writeFlusher {
 }
 
 func renderWithTemplate(ctx context.Context, r any, outputFormat flags.Output, w io.Writer, headerTemplate, template string) error {
+	if q := queryFromContext(ctx); q != nil {
+		var err error
+		r, err = applyQuery(ctx, r, q)
+		if err != nil {
+			return err
+		}
+		// Templates describe the unqueried value; the result is rendered as is.
+		headerTemplate, template = "", ""
+	}
+
 	// TODO: add terminal width & white/dark theme detection
 	switch outputFormat {
 	case flags.OutputJSON:
@@ -245,6 +250,11 @@ func renderWithTemplate(ctx context.Context, r any, outputFormat flags.Output, w
 			return jr.renderJson(ctx, newBufferedFlusher(w))
 		}
 		return errors.New("json output not supported")
+	case flags.OutputYAML, flags.OutputCSV, flags.OutputTSV, flags.OutputNDJSON:
+		if sr, ok := r.(structuredRenderer); ok {
+			return sr.renderStructured(ctx, outputFormat, newBufferedFlusher(w))
+		}
+		return fmt.Errorf("%s output not supported", outputFormat)
 	case flags.OutputText:
 		if tr, ok := r.(templateRenderer); ok && template != "" {
 			return renderUsingTemplate(ctx, tr, w, headerTemplate, template)
@@ -281,7 +291,7 @@ func Render(ctx context.Context, v any) error {
 // Piped output and JSON output keep the existing non-paged behavior.
 func RenderIterator[T any](ctx context.Context, i listing.Iterator[T]) error {
 	c := fromContext(ctx)
-	if c.capabilities.SupportsPager() && c.outputFormat == flags.OutputText && c.template != "" {
+	if c.capabilities.SupportsPager() && c.outputFormat == flags.OutputText && c.template != "" && queryFromContext(ctx) == nil {
 		return renderIteratorPagedTemplate(ctx, i, c.in, c.out, c.headerTemplate, c.template)
 	}
 	return renderWithTemplate(ctx, newIteratorRenderer(i), c.outputFormat, c.out, c.headerTemplate, c.template)
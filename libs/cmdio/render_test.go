@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 
@@ -80,6 +81,14 @@ func makeBigOutput(count int) string {
 	return res.String()
 }
 
+func makeBigNDJSON(count int) string {
+	res := bytes.Buffer{}
+	for _, ws := range makeWorkspaces(count) {
+		fmt.Fprintf(&res, "{\"workspace_id\":%d,\"workspace_name\":%q}\n", ws.WorkspaceId, ws.WorkspaceName)
+	}
+	return res.String()
+}
+
 func must[T any](a T, e error) T {
 	if e != nil {
 		panic(e)
@@ -171,6 +180,49 @@ func makeTestCases() []testCase {
 			outputFormat: flags.OutputJSON,
 			errMessage:   "json output not supported",
 		},
+		{
+			name:         "Workspace as YAML",
+			v:            dummyWorkspace1,
+			outputFormat: flags.OutputYAML,
+			expected:     "workspace_id: 123\nworkspace_name: abc\n",
+		},
+		{
+			name:         "Workspace Iterator as YAML",
+			v:            makeIterator(2),
+			outputFormat: flags.OutputYAML,
+			expected:     "- workspace_id: 123\n  workspace_name: abc\n- workspace_id: 456\n  workspace_name: def\n",
+		},
+		{
+			name:         "Empty Workspace Iterator as YAML",
+			v:            makeIterator(0),
+			outputFormat: flags.OutputYAML,
+			expected:     "[]\n",
+		},
+		{
+			name:         "Workspace Iterator as CSV",
+			v:            makeIterator(2),
+			outputFormat: flags.OutputCSV,
+			template:     "{{range .}}{{.WorkspaceId}}\n{{end}}",
+			expected:     "workspace_id,workspace_name\n123,abc\n456,def\n",
+		},
+		{
+			name:         "Workspace Iterator as TSV",
+			v:            makeIterator(2),
+			outputFormat: flags.OutputTSV,
+			expected:     "workspace_id\tworkspace_name\n123\tabc\n456\tdef\n",
+		},
+		{
+			name:         "Big Workspace Iterator as NDJSON",
+			v:            makeIterator(234),
+			outputFormat: flags.OutputNDJSON,
+			expected:     makeBigNDJSON(234),
+		},
+		{
+			name:         "io.Reader as YAML",
+			v:            strings.NewReader("a test"),
+			outputFormat: flags.OutputYAML,
+			errMessage:   "yaml output not supported",
+		},
 	}
 }
 
@@ -236,3 +288,130 @@ func TestRender(t *testing.T) {
 		})
 	}
 }
+
+func TestStructuredOutputValues(t *testing.T) {
+	v := map[string]any{
+		"id":      json.Number("1234567890123456789"),
+		"enabled": true,
+		"name":    "123",
+		"tags":    map[string]string{"team": "x"},
+		"empty":   nil,
+	}
+
+	tcs := []struct {
+		format   flags.Output
+		expected string
+	}{
+		{flags.OutputYAML, "empty: null\nenabled: true\nid: 1234567890123456789\nname: \"123\"\ntags:\n  team: x\n"},
+		{flags.OutputCSV, "empty,enabled,id,name,tags\n,true,1234567890123456789,123,\"{\"\"team\"\":\"\"x\"\"}\"\n"},
+		{flags.OutputNDJSON, `{"empty":null,"enabled":true,"id":1234567890123456789,"name":"123","tags":{"team":"x"}}` + "\n"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(string(tc.format), func(t *testing.T) {
+			output := &bytes.Buffer{}
+			ctx := InContext(t.Context(), NewIO(t.Context(), tc.format, nil, output, output, "", ""))
+			require.NoError(t, Render(ctx, v))
+			assert.Equal(t, tc.expected, output.String())
+		})
+	}
+}
+
+func TestRenderWithQuery(t *testing.T) {
+	tcs := []struct {
+		name         string
+		v            any
+		query        string
+		outputFormat flags.Output
+		limit        int
+		expected     string
+		errMessage   string
+	}{
+		{
+			name:         "string result as text",
+			v:            dummyWorkspace1,
+			query:        "workspace_name",
+			outputFormat: flags.OutputText,
+			expected:     "abc\n",
+		},
+		{
+			name:         "number result as JSON",
+			v:            dummyWorkspace1,
+			query:        "workspace_id",
+			outputFormat: flags.OutputJSON,
+			expected:     "123\n",
+		},
+		{
+			name:         "iterator projection as text",
+			v:            makeIterator(3),
+			query:        "[*].workspace_name",
+			outputFormat: flags.OutputText,
+			expected:     "[\n  \"abc\",\n  \"def\",\n  \"abc\"\n]\n",
+		},
+		{
+			name:         "iterator filter with limit as CSV",
+			v:            makeIterator(3),
+			query:        "[?workspace_name=='abc'].{id: workspace_id}",
+			outputFormat: flags.OutputCSV,
+			limit:        2,
+			expected:     "id\n123\n",
+		},
+		{
+			name:         "io.Reader",
+			v:            strings.NewReader("a test"),
+			query:        "@",
+			outputFormat: flags.OutputText,
+			errMessage:   "--query is not supported for this command",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			ctx := t.Context()
+			ctx = InContext(ctx, NewIO(ctx, tc.outputFormat, nil, output, output, "", "{{.WorkspaceId}}"))
+			ctx = must(WithQuery(ctx, tc.query))
+			if tc.limit > 0 {
+				ctx = WithLimit(ctx, tc.limit)
+			}
+			var err error
+			if vv, ok := tc.v.(listing.Iterator[*provisioning.Workspace]); ok {
+				err = RenderIterator(ctx, vv)
+			} else {
+				err = Render(ctx, tc.v)
+			}
+			if tc.errMessage != "" {
+				assert.EqualError(t, err, tc.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, output.String())
+			assert.NoError(t, CheckQueryApplied(ctx))
+		})
+	}
+}
+
+func TestQueryNotApplied(t *testing.T) {
+	ctx := t.Context()
+	ctx = InContext(ctx, NewIO(ctx, flags.OutputJSON, nil, io.Discard, io.Discard, "", ""))
+	assert.NoError(t, CheckQueryApplied(ctx))
+
+	ctx = must(WithQuery(ctx, "workspace_name"))
+	assert.EqualError(t, CheckQueryApplied(ctx), "--query was not applied: this command does not support it with the selected output format")
+
+	_, err := WithQuery(ctx, "[?")
+	assert.ErrorContains(t, err, "invalid --query: ")
+}
+
+func TestToGenericPreservesOrder(t *testing.T) {
+	v, err := toGeneric(struct {
+		B int    `json:"b"`
+		A string `json:"a"`
+	}{B: 1, A: "x"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, v.(*object).Keys)
+
+	out, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"b":1,"a":"x"}`, string(out))
+}
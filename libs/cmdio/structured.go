@@ -0,0 +1,231 @@
+package cmdio
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/cli/libs/flags"
+	"go.yaml.in/yaml/v3"
+)
+
+type structuredRenderer interface {
+	// Render an object as YAML, CSV, TSV or NDJSON to the provided writeFlusher.
+	renderStructured(context.Context, flags.Output, writeFlusher) error
+}
+
+func (d defaultRenderer) renderStructured(_ context.Context, format flags.Output, w writeFlusher) error {
+	v, err := toGeneric(d.t)
+	if err != nil {
+		return err
+	}
+	return writeStructured(format, w, v)
+}
+
+func (ir iteratorRenderer[T]) renderStructured(ctx context.Context, format flags.Output, w writeFlusher) error {
+	sw := newStructuredWriter(format, w)
+	limit := limitFromContext(ctx)
+	for i := 0; (limit <= 0 || i < limit) && ir.t.HasNext(ctx); i++ {
+		n, err := ir.t.Next(ctx)
+		if err != nil {
+			return err
+		}
+		v, err := toGeneric(n)
+		if err != nil {
+			return err
+		}
+		err = sw.writeItem(v)
+		if err != nil {
+			return err
+		}
+		if (i+1)%ir.getBufferSize() == 0 {
+			err = sw.flush()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return sw.close()
+}
+
+// writeStructured writes v in the given format. Arrays are written item by item,
+// like the output of an iterator.
+func writeStructured(format flags.Output, w writeFlusher, v any) error {
+	sw := newStructuredWriter(format, w)
+	if arr, ok := v.([]any); ok {
+		for _, item := range arr {
+			err := sw.writeItem(item)
+			if err != nil {
+				return err
+			}
+		}
+		return sw.close()
+	}
+	err := sw.writeValue(v)
+	if err != nil {
+		return err
+	}
+	return sw.flush()
+}
+
+// structuredWriter writes values in the generic representation of [toGeneric] as
+// YAML, CSV, TSV or NDJSON. Items of a list are written one at a time so that
+// iterators can be streamed.
+type structuredWriter struct {
+	format flags.Output
+	w      writeFlusher
+	csv    *csv.Writer
+
+	// header holds the CSV columns, taken from the keys of the first object.
+	header []string
+	items  int
+}
+
+func newStructuredWriter(format flags.Output, w writeFlusher) *structuredWriter {
+	sw := &structuredWriter{format: format, w: w}
+	if format == flags.OutputCSV || format == flags.OutputTSV {
+		sw.csv = csv.NewWriter(w)
+		if format == flags.OutputTSV {
+			sw.csv.Comma = '\t'
+		}
+	}
+	return sw
+}
+
+// writeItem writes v as an item of the top-level list.
+func (sw *structuredWriter) writeItem(v any) error {
+	sw.items++
+	if sw.format == flags.OutputYAML {
+		return encodeYAML(sw.w, &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{yamlNode(v)}})
+	}
+	return sw.writeValue(v)
+}
+
+// writeValue writes v as a standalone value.
+func (sw *structuredWriter) writeValue(v any) error {
+	switch sw.format {
+	case flags.OutputYAML:
+		return encodeYAML(sw.w, yamlNode(v))
+	case flags.OutputNDJSON:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = sw.w.Write(append(b, '\n'))
+		return err
+	case flags.OutputCSV, flags.OutputTSV:
+		return sw.writeRow(v)
+	default:
+		return fmt.Errorf("invalid output format: %s", sw.format)
+	}
+}
+
+func (sw *structuredWriter) writeRow(v any) error {
+	obj, ok := v.(*object)
+	if !ok {
+		return sw.csv.Write([]string{csvCell(v)})
+	}
+	if sw.header == nil {
+		sw.header = obj.Keys
+		err := sw.csv.Write(sw.header)
+		if err != nil {
+			return err
+		}
+	}
+	row := make([]string, len(sw.header))
+	for i, k := range sw.header {
+		row[i] = csvCell(obj.Get(k))
+	}
+	return sw.csv.Write(row)
+}
+
+func (sw *structuredWriter) flush() error {
+	if sw.csv != nil {
+		sw.csv.Flush()
+		if err := sw.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return sw.w.Flush()
+}
+
+// close finishes the top-level list and flushes the output.
+func (sw *structuredWriter) close() error {
+	if sw.items == 0 && sw.format == flags.OutputYAML {
+		_, err := sw.w.Write([]byte("[]\n"))
+		if err != nil {
+			return err
+		}
+	}
+	return sw.flush()
+}
+
+// csvCell formats a value for a single CSV cell. Nested values are written as
+// compact JSON.
+func csvCell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+}
+
+func encodeYAML(w writeFlusher, node *yaml.Node) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	err := enc.Encode(node)
+	if err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// yamlNode converts a value in the generic representation of [toGeneric] to a
+// YAML node, preserving the order of object keys.
+func yamlNode(v any) *yaml.Node {
+	switch v := v.(type) {
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+	case []any:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range v {
+			node.Content = append(node.Content, yamlNode(item))
+		}
+		return node
+	case *object:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, k := range v.Keys {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k},
+				yamlNode(v.Values[k]),
+			)
+		}
+		return node
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprint(v)}
+	}
+}
@@ -0,0 +1,130 @@
+package cmdio
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// object is a JSON object that preserves the order of its keys, so that YAML and
+// CSV output lists fields in the same order as JSON output.
+type object struct {
+	Keys   []string
+	Values map[string]any
+}
+
+func newObject() *object {
+	return &object{Values: make(map[string]any)}
+}
+
+// Set sets the value of key, appending key if it is not present yet.
+func (o *object) Set(key string, v any) {
+	if _, ok := o.Values[key]; !ok {
+		o.Keys = append(o.Keys, key)
+	}
+	o.Values[key] = v
+}
+
+// Get returns the value of key, or nil if it is not present.
+func (o *object) Get(key string) any {
+	return o.Values[key]
+}
+
+// MarshalJSON encodes the object with its keys in order.
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.Keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(o.Values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// toGeneric converts v to a generic representation by encoding it as JSON.
+// Objects become *object, arrays []any and numbers json.Number, so that large
+// integers such as IDs are not rounded.
+func toGeneric(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeGeneric(data)
+}
+
+// decodeGeneric parses a single JSON value into the generic representation.
+func decodeGeneric(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := decodeValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+func decodeValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok := tok.(type) {
+	case json.Delim:
+		switch tok {
+		case '{':
+			obj := newObject()
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := keyTok.(string)
+				if !ok {
+					return nil, fmt.Errorf("unexpected object key %v", keyTok)
+				}
+				value, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				obj.Set(key, value)
+			}
+			_, err := dec.Token()
+			return obj, err
+		case '[':
+			arr := []any{}
+			for dec.More() {
+				value, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, value)
+			}
+			_, err := dec.Token()
+			return arr, err
+		default:
+			return nil, fmt.Errorf("unexpected delimiter %v", tok)
+		}
+	default:
+		// string, bool, json.Number or nil.
+		return tok, nil
+	}
+}
@@ -75,6 +75,7 @@ type Executor struct {
 	shell         shell
 	dir           string
 	inheritOutput bool
+	env           []string
 }
 
 // NewCommandExecutor creates an Executor with default output behavior (no inheritance)
@@ -94,6 +95,12 @@ func (e *Executor) WithInheritOutput() {
 	e.inheritOutput = true
 }
 
+// WithEnv sets additional environment variables ("name=value") for commands,
+// on top of the environment of the current process.
+func (e *Executor) WithEnv(env []string) {
+	e.env = env
+}
+
 func NewCommandExecutorWithExecutable(dir string, execType ExecutableType) (*Executor, error) {
 	f, ok := finders[execType]
 	if !ok {
@@ -117,6 +124,9 @@ func (e *Executor) prepareCommand(ctx context.Context, command string) (*osexec.
 	}
 	cmd := osexec.CommandContext(ctx, ec.executable, ec.args...)
 	cmd.Dir = e.dir
+	if len(e.env) > 0 {
+		cmd.Env = append(os.Environ(), e.env...)
+	}
 	return cmd, ec, nil
 }
 
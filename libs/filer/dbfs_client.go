@@ -240,6 +240,42 @@ func (w *DbfsClient) Delete(ctx context.Context, name string, mode ...DeleteMode
 	return err
 }
 
+func (w *DbfsClient) Move(ctx context.Context, source, target string) error {
+	sourcePath, err := w.root.Join(source)
+	if err != nil {
+		return err
+	}
+	targetPath, err := w.root.Join(target)
+	if err != nil {
+		return err
+	}
+
+	err = w.workspaceClient.Dbfs.Move(ctx, files.Move{
+		SourcePath:      sourcePath,
+		DestinationPath: targetPath,
+	})
+
+	// Return early on success.
+	if err == nil {
+		return nil
+	}
+
+	// Special handling of this error only if it is an API error.
+	aerr, ok := errors.AsType[*apierr.APIError](err)
+	if !ok {
+		return err
+	}
+
+	switch aerr.ErrorCode {
+	case "RESOURCE_DOES_NOT_EXIST":
+		return fileDoesNotExistError{sourcePath}
+	case "RESOURCE_ALREADY_EXISTS":
+		return fileAlreadyExistsError{targetPath}
+	}
+
+	return err
+}
+
 func (w *DbfsClient) ReadDir(ctx context.Context, name string) ([]fs.DirEntry, error) {
 	absPath, err := w.root.Join(name)
 	if err != nil {
@@ -52,3 +52,11 @@ type Filer interface {
 	// Stat returns information about the file at `path`.
 	Stat(ctx context.Context, name string) (fs.FileInfo, error)
 }
+
+// Mover is implemented by filers that can move a file or directory to
+// another path on the same backend without copying its contents.
+type Mover interface {
+	// Move the file or directory at `source` to `target`.
+	// It fails if a file or directory already exists at `target`.
+	Move(ctx context.Context, source, target string) error
+}
@@ -152,6 +152,10 @@ type FilesClient struct {
 	// (e.g. fs cp -r) draw from one bounded budget and one connection pool.
 	limiter        files.Limiter
 	transferClient *http.Client
+
+	// Resumable uploads call the upload control plane directly, as the engine
+	// does not expose the upload session.
+	workspaceClient *databricks.WorkspaceClient
 }
 
 func NewFilesClient(ctx context.Context, w *databricks.WorkspaceClient, root string) (Filer, error) {
@@ -167,6 +171,8 @@ func NewFilesClient(ctx context.Context, w *databricks.WorkspaceClient, root str
 
 		limiter:        files.NewLimiter(uploadConcurrency),
 		transferClient: newTransferClient(uploadConcurrency),
+
+		workspaceClient: w,
 	}, nil
 }
 
@@ -232,6 +238,17 @@ func (w *FilesClient) Write(ctx context.Context, name string, reader io.Reader,
 	// recovers an io.ReaderAt for concurrent positioned reads when the source
 	// provides one (a local file).
 	if MultipartUploadEnabled(ctx) && isSeekable(reader) {
+		// A caller (fs cp) can attach a store via the context to make large
+		// uploads resumable across processes.
+		if store := uploadSessionStoreFromContext(ctx); store != nil {
+			if src, info, ok := resumableInfo(reader); ok {
+				err = w.writeResumable(ctx, store, absPath, src, info, overwrite)
+				if !errors.Is(err, errResumableUnsupported) {
+					return err
+				}
+			}
+		}
+
 		opts := []files.UploadOption{
 			files.WithOverwrite(overwrite),
 			files.WithLimiter(w.limiter),
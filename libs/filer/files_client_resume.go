@@ -0,0 +1,440 @@
+package filer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/databricks/cli/libs/auth"
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/databricks-sdk-go"
+	sdkapierr "github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/client"
+	files "github.com/databricks/sdk-go/files/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+// resumablePartSize is the part size of resumable uploads. Files smaller than
+// one part are not worth resuming and are uploaded like any other file.
+const resumablePartSize = 64 * 1024 * 1024
+
+// maxUploadParts is the maximum number of parts of a multipart upload.
+const maxUploadParts = 10000
+
+// resumableUploadConcurrency bounds the concurrent part uploads of a resumable upload.
+const resumableUploadConcurrency = 8
+
+// partUploadAttempts is the number of times a part is attempted before the
+// upload fails. Every attempt uses a freshly minted part URL.
+const partUploadAttempts = 3
+
+// partURLExpiry is how long the presigned URL of a part remains valid.
+const partURLExpiry = time.Hour
+
+// fingerprintBlockSize is the size of the blocks at the start and the end of
+// a file that identify its content in an upload session.
+const fingerprintBlockSize = 1024 * 1024
+
+// errResumableUnsupported is returned if the workspace does not offer a
+// multipart upload for a file, for example because it uses the GCP resumable
+// upload protocol instead.
+var errResumableUnsupported = errors.New("multipart upload is not supported")
+
+// UploadSession records the progress of a multipart upload to a UC Volume, so
+// that an upload interrupted by the process exiting can be resumed later.
+type UploadSession struct {
+	// Path is the absolute path of the file being uploaded.
+	Path string `json:"path"`
+
+	// SessionToken identifies the upload session on the server.
+	SessionToken string `json:"session_token"`
+
+	// Size is the size of the file being uploaded.
+	Size int64 `json:"size"`
+
+	// ModTime is the modification time of the file being uploaded.
+	ModTime time.Time `json:"mod_time"`
+
+	// Fingerprint is a hash of the size and of the first and last blocks of
+	// the file being uploaded. Together with the modification time, it
+	// identifies the content that the uploaded parts were read from.
+	Fingerprint string `json:"fingerprint"`
+
+	// PartSize is the size of each part except the last.
+	PartSize int64 `json:"part_size"`
+
+	// Parts maps the number of each uploaded part to its ETag.
+	Parts map[int]string `json:"parts"`
+}
+
+// UploadSessionStore persists upload sessions across processes.
+type UploadSessionStore interface {
+	// LoadUploadSession returns the session for the file at path, or nil if there is none.
+	LoadUploadSession(path string) *UploadSession
+
+	// SaveUploadSession records the session. It is called after every uploaded part.
+	SaveUploadSession(s *UploadSession) error
+
+	// DeleteUploadSession forgets the session for the file at path.
+	DeleteUploadSession(path string) error
+}
+
+// uploadSessionStoreKey is the context key for an optional upload session store.
+type uploadSessionStoreKey struct{}
+
+// WithUploadSessionStore returns a context carrying a store for upload sessions.
+// FilesClient.Write uploads large local files as resumable multipart uploads
+// if the context carries a store, and resumes the upload recorded in the store
+// for the same file if there is one.
+func WithUploadSessionStore(ctx context.Context, store UploadSessionStore) context.Context {
+	return context.WithValue(ctx, uploadSessionStoreKey{}, store)
+}
+
+func uploadSessionStoreFromContext(ctx context.Context) UploadSessionStore {
+	store, _ := ctx.Value(uploadSessionStoreKey{}).(UploadSessionStore)
+	return store
+}
+
+// resumableSource is a source that can be read at any offset, such as a local file.
+type resumableSource interface {
+	io.ReaderAt
+	Stat() (fs.FileInfo, error)
+}
+
+// resumableInfo returns the source as a [resumableSource] and its file info
+// if it can be uploaded with a resumable upload.
+func resumableInfo(r io.Reader) (resumableSource, fs.FileInfo, bool) {
+	src, ok := r.(resumableSource)
+	if !ok {
+		return nil, nil, false
+	}
+	info, err := src.Stat()
+	if err != nil || !info.Mode().IsRegular() || info.Size() < resumablePartSize {
+		return nil, nil, false
+	}
+	return src, info, true
+}
+
+// fingerprint hashes the size and the first and last blocks of src. Reading
+// the whole file would defeat the purpose of resuming a large upload.
+func fingerprint(src io.ReaderAt, size int64) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\n", size)
+	for _, offset := range []int64{0, max(0, size-fingerprintBlockSize)} {
+		_, err := io.Copy(h, io.NewSectionReader(src, offset, min(fingerprintBlockSize, size)))
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// partRejectedError is returned if the storage service rejects the upload of
+// a part, for example because the presigned URL or the session expired.
+type partRejectedError struct {
+	part   int
+	status string
+}
+
+func (e partRejectedError) Error() string {
+	return fmt.Sprintf("uploading part %d: %s", e.part, e.status)
+}
+
+// sessionRejected returns true if err means that the upload session can no
+// longer be used and a new session has to be started.
+func sessionRejected(err error) bool {
+	if errors.As(err, new(partRejectedError)) {
+		return true
+	}
+	var aerr *sdkapierr.APIError
+	return errors.As(err, &aerr) && aerr.StatusCode/100 == 4
+}
+
+type initiateUploadResponse struct {
+	MultipartUpload *struct {
+		SessionToken string `json:"session_token"`
+	} `json:"multipart_upload"`
+}
+
+type nameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type createPartURLsRequest struct {
+	Path            string `json:"path"`
+	SessionToken    string `json:"session_token"`
+	StartPartNumber int    `json:"start_part_number"`
+	Count           int    `json:"count"`
+	ExpireTime      string `json:"expire_time"`
+}
+
+type createPartURLsResponse struct {
+	UploadPartURLs []struct {
+		URL        string      `json:"url"`
+		PartNumber int         `json:"part_number"`
+		Headers    []nameValue `json:"headers"`
+	} `json:"upload_part_urls"`
+}
+
+type completePart struct {
+	PartNumber int    `json:"part_number"`
+	ETag       string `json:"etag"`
+}
+
+type completeUploadRequest struct {
+	Parts []completePart `json:"parts"`
+}
+
+// resumableUploader uploads a file as a multipart upload whose progress is
+// recorded in an [UploadSessionStore].
+type resumableUploader struct {
+	apiClient       *client.DatabricksClient
+	workspaceClient *databricks.WorkspaceClient
+	transferClient  *http.Client
+	limiter         files.Limiter
+	store           UploadSessionStore
+	progress        files.ProgressFunc
+
+	// partSize is the minimum part size of new sessions.
+	partSize int64
+}
+
+func (u *resumableUploader) initiate(ctx context.Context, absPath string, overwrite bool) (string, error) {
+	var out initiateUploadResponse
+	err := u.apiClient.Do(ctx, http.MethodPost, "/api/2.0/fs/files"+absPath,
+		auth.WorkspaceIDHeaders(u.workspaceClient.Config),
+		map[string]any{"action": "initiate-upload", "overwrite": strconv.FormatBool(overwrite)},
+		nil, &out)
+	if err != nil {
+		return "", err
+	}
+	if out.MultipartUpload == nil || out.MultipartUpload.SessionToken == "" {
+		return "", errResumableUnsupported
+	}
+	return out.MultipartUpload.SessionToken, nil
+}
+
+func (u *resumableUploader) uploadPart(ctx context.Context, s *UploadSession, src io.ReaderAt, part int) (string, error) {
+	var out createPartURLsResponse
+	err := u.apiClient.Do(ctx, http.MethodPost, "/api/2.0/fs/create-upload-part-urls",
+		auth.WorkspaceIDHeaders(u.workspaceClient.Config), nil,
+		createPartURLsRequest{
+			Path:            s.Path,
+			SessionToken:    s.SessionToken,
+			StartPartNumber: part,
+			Count:           1,
+			ExpireTime:      time.Now().UTC().Add(partURLExpiry).Format("2006-01-02T15:04:05Z"),
+		}, &out)
+	if err != nil {
+		return "", err
+	}
+	if len(out.UploadPartURLs) != 1 {
+		return "", fmt.Errorf("expected 1 upload URL for part %d, got %d", part, len(out.UploadPartURLs))
+	}
+
+	offset := int64(part-1) * s.PartSize
+	length := min(s.PartSize, s.Size-offset)
+	body := io.NewSectionReader(src, offset, length)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, out.UploadPartURLs[0].URL, body)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = length
+	for _, h := range out.UploadPartURLs[0].Headers {
+		req.Header.Set(h.Name, h.Value)
+	}
+
+	resp, err := u.transferClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 == 4 {
+		return "", partRejectedError{part: part, status: resp.Status}
+	}
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("uploading part %d: %s", part, resp.Status)
+	}
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		return "", fmt.Errorf("uploading part %d: no ETag in response", part)
+	}
+	return etag, nil
+}
+
+func (u *resumableUploader) complete(ctx context.Context, s *UploadSession) error {
+	var req completeUploadRequest
+	for _, n := range slices.Sorted(maps.Keys(s.Parts)) {
+		req.Parts = append(req.Parts, completePart{PartNumber: n, ETag: s.Parts[n]})
+	}
+	return u.apiClient.Do(ctx, http.MethodPost, "/api/2.0/fs/files"+s.Path,
+		auth.WorkspaceIDHeaders(u.workspaceClient.Config),
+		map[string]any{"action": "complete-upload", "upload_type": "multipart", "session_token": s.SessionToken},
+		req, nil)
+}
+
+// upload uploads the parts of the session that were not uploaded yet and
+// completes the upload.
+func (u *resumableUploader) upload(ctx context.Context, s *UploadSession, src io.ReaderAt) error {
+	numParts := int((s.Size + s.PartSize - 1) / s.PartSize)
+
+	var mu sync.Mutex
+	var transferred int64
+	report := func(n int64) {
+		transferred += n
+		if u.progress != nil {
+			u.progress(files.Progress{Transferred: transferred, Total: s.Size})
+		}
+	}
+
+	var pending []int
+	for part := 1; part <= numParts; part++ {
+		if _, ok := s.Parts[part]; ok {
+			report(min(s.PartSize, s.Size-int64(part-1)*s.PartSize))
+		} else {
+			pending = append(pending, part)
+		}
+	}
+	if len(pending) < numParts {
+		log.Infof(ctx, "Resuming upload of %s with %d of %d parts uploaded", s.Path, numParts-len(pending), numParts)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(resumableUploadConcurrency)
+	for _, part := range pending {
+		g.Go(func() error {
+			// Share the concurrency budget of the filer with other uploads.
+			err := u.limiter.Acquire(gctx)
+			if err != nil {
+				return err
+			}
+			defer u.limiter.Release()
+
+			var etag string
+			for attempt := 1; attempt <= partUploadAttempts; attempt++ {
+				etag, err = u.uploadPart(gctx, s, src, part)
+				if err == nil || gctx.Err() != nil {
+					break
+				}
+				log.Debugf(gctx, "Attempt %d to upload part %d of %s failed: %s", attempt, part, s.Path, err)
+			}
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			s.Parts[part] = etag
+			report(min(s.PartSize, s.Size-int64(part-1)*s.PartSize))
+			return u.store.SaveUploadSession(s)
+		})
+	}
+	err := g.Wait()
+	if err != nil {
+		return err
+	}
+
+	return u.complete(ctx, s)
+}
+
+// write uploads src to absPath, resuming the upload recorded in the store if
+// it was started for the same content.
+func (u *resumableUploader) write(ctx context.Context, absPath string, src io.ReaderAt, info fs.FileInfo, overwrite bool) error {
+	size := info.Size()
+	modTime := info.ModTime().UTC()
+	fp, err := fingerprint(src, size)
+	if err != nil {
+		return err
+	}
+
+	s := u.store.LoadUploadSession(absPath)
+	if s != nil && (s.Size != size || !s.ModTime.Equal(modTime) || s.Fingerprint != fp || s.PartSize <= 0 || s.SessionToken == "") {
+		// The parts of the session may have been read from different content.
+		log.Infof(ctx, "Discarding upload session of %s, the file changed since it was started", absPath)
+		err := u.store.DeleteUploadSession(absPath)
+		if err != nil {
+			return err
+		}
+		s = nil
+	}
+
+	if s != nil {
+		err := u.upload(ctx, s, src)
+		if err == nil {
+			return u.store.DeleteUploadSession(absPath)
+		}
+
+		// The server forgets sessions and part URLs expire after some time.
+		// Start over if the session is rejected.
+		if !sessionRejected(err) {
+			return err
+		}
+		log.Infof(ctx, "Unable to resume upload of %s, starting over: %s", absPath, err)
+	}
+
+	token, err := u.initiate(ctx, absPath, overwrite)
+	if err != nil {
+		return err
+	}
+
+	s = &UploadSession{
+		Path:         absPath,
+		SessionToken: token,
+		Size:         size,
+		ModTime:      modTime,
+		Fingerprint:  fp,
+		PartSize:     max(u.partSize, (size+maxUploadParts-1)/maxUploadParts),
+		Parts:        make(map[int]string),
+	}
+	err = u.store.SaveUploadSession(s)
+	if err != nil {
+		return err
+	}
+
+	err = u.upload(ctx, s, src)
+	if err != nil {
+		return err
+	}
+	return u.store.DeleteUploadSession(absPath)
+}
+
+// writeResumable uploads src as a multipart upload recorded in store. It
+// returns [errResumableUnsupported] if the workspace does not offer a
+// multipart upload, in which case the caller falls back to the upload engine.
+func (w *FilesClient) writeResumable(ctx context.Context, store UploadSessionStore, absPath string, src io.ReaderAt, info fs.FileInfo, overwrite bool) error {
+	apiClient, err := client.New(w.workspaceClient.Config)
+	if err != nil {
+		return err
+	}
+
+	u := &resumableUploader{
+		apiClient:       apiClient,
+		workspaceClient: w.workspaceClient,
+		transferClient:  w.transferClient,
+		limiter:         w.limiter,
+		store:           store,
+		progress:        uploadProgressFromContext(ctx),
+		partSize:        resumablePartSize,
+	}
+	err = u.write(ctx, absPath, src, info, overwrite)
+
+	// The API returns 409 if a file already exists at the path.
+	var aerr *sdkapierr.APIError
+	if errors.As(err, &aerr) && aerr.StatusCode == http.StatusConflict {
+		return fileAlreadyExistsError{absPath}
+	}
+	return err
+}
@@ -0,0 +1,314 @@
+package filer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/databricks/cli/libs/testserver"
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/client"
+	files "github.com/databricks/sdk-go/files/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMultipart implements the multipart upload endpoints of the Files API.
+type fakeMultipart struct {
+	mu sync.Mutex
+
+	// sessions maps a session token to the uploaded parts by part number.
+	sessions map[string]map[int][]byte
+	next     int
+
+	// failPart makes uploads of this part number fail.
+	failPart int
+
+	// expiredToken makes uploads of parts of this session fail as if
+	// their presigned URLs expired.
+	expiredToken string
+
+	uploads []int
+	files   map[string][]byte
+}
+
+func newFakeMultipart(t *testing.T) (*fakeMultipart, *databricks.WorkspaceClient) {
+	f := &fakeMultipart{
+		sessions: make(map[string]map[int][]byte),
+		files:    make(map[string][]byte),
+	}
+
+	server := testserver.New(t)
+	server.Handle("POST", "/api/2.0/fs/files/{path...}", func(req testserver.Request) any {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		q := req.URL.Query()
+		switch q.Get("action") {
+		case "initiate-upload":
+			f.next++
+			token := fmt.Sprintf("token-%d", f.next)
+			f.sessions[token] = make(map[int][]byte)
+			return map[string]any{"multipart_upload": map[string]any{"session_token": token}}
+		case "complete-upload":
+			parts, ok := f.sessions[q.Get("session_token")]
+			if !ok {
+				return testserver.Response{StatusCode: http.StatusNotFound}
+			}
+			var body completeUploadRequest
+			require.NoError(t, json.Unmarshal(req.Body, &body))
+			var content []byte
+			for i, p := range body.Parts {
+				require.Equal(t, i+1, p.PartNumber)
+				require.Equal(t, fmt.Sprintf("etag-%d", p.PartNumber), p.ETag)
+				content = append(content, parts[p.PartNumber]...)
+			}
+			f.files["/"+req.Vars["path"]] = content
+			delete(f.sessions, q.Get("session_token"))
+			return ""
+		}
+		return testserver.Response{StatusCode: http.StatusBadRequest}
+	})
+	server.Handle("POST", "/api/2.0/fs/create-upload-part-urls", func(req testserver.Request) any {
+		var body createPartURLsRequest
+		require.NoError(t, json.Unmarshal(req.Body, &body))
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.sessions[body.SessionToken]; !ok {
+			return testserver.Response{StatusCode: http.StatusNotFound}
+		}
+		return map[string]any{
+			"upload_part_urls": []map[string]any{{
+				"url":         fmt.Sprintf("%s/upload/%s/%d", server.URL, body.SessionToken, body.StartPartNumber),
+				"part_number": body.StartPartNumber,
+				"headers":     []map[string]any{{"name": "Content-Type", "value": "application/octet-stream"}},
+			}},
+		}
+	})
+	server.Handle("PUT", "/upload/{token}/{part}", func(req testserver.Request) any {
+		part, err := strconv.Atoi(req.Vars["part"])
+		require.NoError(t, err)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if part == f.failPart {
+			return testserver.Response{StatusCode: http.StatusInternalServerError}
+		}
+		if req.Vars["token"] == f.expiredToken {
+			return testserver.Response{StatusCode: http.StatusForbidden}
+		}
+		f.uploads = append(f.uploads, part)
+		f.sessions[req.Vars["token"]][part] = req.Body
+		return testserver.Response{
+			Headers: http.Header{"ETag": []string{fmt.Sprintf("etag-%d", part)}},
+		}
+	})
+
+	w, err := databricks.NewWorkspaceClient(&databricks.Config{
+		Host:  server.URL,
+		Token: "testtoken",
+	})
+	require.NoError(t, err)
+	return f, w
+}
+
+// memorySessionStore is an in-memory [UploadSessionStore].
+type memorySessionStore struct {
+	sessions map[string]*UploadSession
+}
+
+func (m *memorySessionStore) LoadUploadSession(path string) *UploadSession {
+	return m.sessions[path]
+}
+
+func (m *memorySessionStore) SaveUploadSession(s *UploadSession) error {
+	m.sessions[s.Path] = s
+	return nil
+}
+
+func (m *memorySessionStore) DeleteUploadSession(path string) error {
+	delete(m.sessions, path)
+	return nil
+}
+
+func newTestUploader(t *testing.T, w *databricks.WorkspaceClient, store UploadSessionStore) *resumableUploader {
+	apiClient, err := client.New(w.Config)
+	require.NoError(t, err)
+	return &resumableUploader{
+		apiClient:       apiClient,
+		workspaceClient: w,
+		transferClient:  http.DefaultClient,
+		limiter:         files.NewLimiter(resumableUploadConcurrency),
+		store:           store,
+		partSize:        4,
+	}
+}
+
+// openTestFile writes content to a file and returns it opened with its info.
+func openTestFile(t *testing.T, content []byte) (*os.File, fs.FileInfo) {
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	src, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { src.Close() })
+	info, err := src.Stat()
+	require.NoError(t, err)
+	return src, info
+}
+
+// testSession returns a session for src with the first part uploaded.
+func testSession(t *testing.T, src *os.File, info fs.FileInfo, token string) *UploadSession {
+	fp, err := fingerprint(src, info.Size())
+	require.NoError(t, err)
+	return &UploadSession{
+		Path:         "/Volumes/a/b/c/file",
+		SessionToken: token,
+		Size:         info.Size(),
+		ModTime:      info.ModTime().UTC(),
+		Fingerprint:  fp,
+		PartSize:     4,
+		Parts:        map[int]string{1: "etag-1"},
+	}
+}
+
+func TestResumableUploadResumesFromStore(t *testing.T) {
+	fake, w := newFakeMultipart(t)
+	store := &memorySessionStore{sessions: make(map[string]*UploadSession)}
+	u := newTestUploader(t, w, store)
+
+	content := []byte(strings.Repeat("abcd", 4) + "ef")
+	src, info := openTestFile(t, content)
+
+	// The upload is interrupted at the last part.
+	fake.failPart = 5
+	err := u.write(t.Context(), "/Volumes/a/b/c/file", src, info, false)
+	require.Error(t, err)
+
+	s := store.LoadUploadSession("/Volumes/a/b/c/file")
+	require.NotNil(t, s)
+	assert.Len(t, s.Parts, 4)
+	assert.Equal(t, int64(4), s.PartSize)
+
+	// Resuming uploads only the missing part and completes the session.
+	fake.failPart = 0
+	fake.uploads = nil
+	err = u.write(t.Context(), "/Volumes/a/b/c/file", src, info, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{5}, fake.uploads)
+	assert.Equal(t, content, fake.files["/Volumes/a/b/c/file"])
+	assert.Nil(t, store.LoadUploadSession("/Volumes/a/b/c/file"))
+}
+
+func TestResumableUploadRestartsStaleSession(t *testing.T) {
+	fake, w := newFakeMultipart(t)
+	store := &memorySessionStore{sessions: make(map[string]*UploadSession)}
+	u := newTestUploader(t, w, store)
+
+	content := []byte("abcdefgh")
+	src, info := openTestFile(t, content)
+
+	// The server no longer knows this session.
+	store.sessions["/Volumes/a/b/c/file"] = testSession(t, src, info, "expired")
+
+	err := u.write(t.Context(), "/Volumes/a/b/c/file", src, info, false)
+	require.NoError(t, err)
+
+	slices.Sort(fake.uploads)
+	assert.Equal(t, []int{1, 2}, fake.uploads)
+	assert.Equal(t, content, fake.files["/Volumes/a/b/c/file"])
+	assert.Empty(t, store.sessions)
+}
+
+func TestResumableUploadRestartsOnExpiredPartURL(t *testing.T) {
+	fake, w := newFakeMultipart(t)
+	store := &memorySessionStore{sessions: make(map[string]*UploadSession)}
+	u := newTestUploader(t, w, store)
+
+	content := []byte("abcdefgh")
+	src, info := openTestFile(t, content)
+
+	// The server knows the session, but rejects its part URLs.
+	fake.sessions["token-old"] = map[int][]byte{1: []byte("abcd")}
+	fake.expiredToken = "token-old"
+	store.sessions["/Volumes/a/b/c/file"] = testSession(t, src, info, "token-old")
+
+	err := u.write(t.Context(), "/Volumes/a/b/c/file", src, info, false)
+	require.NoError(t, err)
+
+	slices.Sort(fake.uploads)
+	assert.Equal(t, []int{1, 2}, fake.uploads)
+	assert.Equal(t, content, fake.files["/Volumes/a/b/c/file"])
+	assert.Empty(t, store.sessions)
+}
+
+func TestResumableUploadDiscardsSessionOfChangedFile(t *testing.T) {
+	fake, w := newFakeMultipart(t)
+	store := &memorySessionStore{sessions: make(map[string]*UploadSession)}
+	u := newTestUploader(t, w, store)
+
+	src, info := openTestFile(t, []byte("abcdefgh"))
+	s := testSession(t, src, info, "token-old")
+	fake.sessions["token-old"] = map[int][]byte{1: []byte("abcd")}
+
+	// The file has the same size and modification time, but different content.
+	content := []byte("ABCDefgh")
+	require.NoError(t, os.WriteFile(src.Name(), content, 0o600))
+	require.NoError(t, os.Chtimes(src.Name(), info.ModTime(), info.ModTime()))
+	info, err := src.Stat()
+	require.NoError(t, err)
+	store.sessions["/Volumes/a/b/c/file"] = s
+
+	err = u.write(t.Context(), "/Volumes/a/b/c/file", src, info, false)
+	require.NoError(t, err)
+
+	slices.Sort(fake.uploads)
+	assert.Equal(t, []int{1, 2}, fake.uploads)
+	assert.Equal(t, content, fake.files["/Volumes/a/b/c/file"])
+	assert.Empty(t, store.sessions)
+}
+
+// countingLimiter is a [files.Limiter] that records the parts it admitted.
+type countingLimiter struct {
+	mu       sync.Mutex
+	acquired int
+}
+
+func (l *countingLimiter) Acquire(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.acquired++
+	return nil
+}
+
+func (l *countingLimiter) Release() {}
+
+func TestResumableUploadUsesLimiter(t *testing.T) {
+	fake, w := newFakeMultipart(t)
+	store := &memorySessionStore{sessions: make(map[string]*UploadSession)}
+	u := newTestUploader(t, w, store)
+	limiter := &countingLimiter{}
+	u.limiter = limiter
+
+	content := []byte("abcdefgh")
+	src, info := openTestFile(t, content)
+	fake.sessions["token-old"] = map[int][]byte{1: []byte("abcd")}
+	store.sessions["/Volumes/a/b/c/file"] = testSession(t, src, info, "token-old")
+
+	err := u.write(t.Context(), "/Volumes/a/b/c/file", src, info, false)
+	require.NoError(t, err)
+
+	// Only the missing part of the resumed session is uploaded.
+	assert.Equal(t, []int{2}, fake.uploads)
+	assert.Equal(t, 1, limiter.acquired)
+}
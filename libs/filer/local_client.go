@@ -131,6 +131,29 @@ func (w *LocalClient) Delete(ctx context.Context, name string, mode ...DeleteMod
 	return err
 }
 
+func (w *LocalClient) Move(ctx context.Context, source, target string) error {
+	sourcePath, err := w.root.Join(source)
+	if err != nil {
+		return err
+	}
+	targetPath, err := w.root.Join(target)
+	if err != nil {
+		return err
+	}
+
+	// Unlike [os.Rename], fail if the target exists.
+	_, err = os.Lstat(targetPath)
+	if err == nil {
+		return fileAlreadyExistsError{path: targetPath}
+	}
+
+	err = os.Rename(sourcePath, targetPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fileDoesNotExistError{path: sourcePath}
+	}
+	return err
+}
+
 func (w *LocalClient) ReadDir(ctx context.Context, name string) ([]fs.DirEntry, error) {
 	absPath, err := w.root.Join(name)
 	if err != nil {
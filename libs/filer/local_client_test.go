@@ -0,0 +1,33 @@
+package filer
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalClientMove(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b"), []byte("b"), 0o644))
+
+	f, err := NewLocalClient(dir)
+	require.NoError(t, err)
+	m := f.(Mover)
+
+	err = m.Move(ctx, "a", "c")
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "a"))
+	assert.FileExists(t, filepath.Join(dir, "c"))
+
+	err = m.Move(ctx, "c", "b")
+	assert.ErrorIs(t, err, fs.ErrExist)
+
+	err = m.Move(ctx, "a", "d")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -1,6 +1,9 @@
 package fileset
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"io/fs"
 	"time"
 
@@ -77,6 +80,22 @@ func (f File) Size() (size int64, ok bool) {
 	return info.Size(), true
 }
 
+// Checksum returns the hex-encoded SHA-256 digest of the file contents.
+func (f File) Checksum() (string, error) {
+	r, err := f.root.Open(f.Relative)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, r)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func (f *File) IsNotebook() (bool, error) {
 	if f.fileType != Unknown {
 		return f.fileType == Notebook, nil
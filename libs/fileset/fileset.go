@@ -1,11 +1,13 @@
 package fileset
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	pathlib "path"
 	"path/filepath"
 	"slices"
+	"strings"
 
 	"github.com/databricks/cli/libs/vfs"
 )
@@ -126,3 +128,91 @@ func (w *FileSet) recursiveListFiles(path string, seen map[string]struct{}) (out
 	})
 	return out, err
 }
+
+// Match returns the [File] at the specified path (relative to the root) if it
+// is part of this fileset. Unlike [FileSet.Files], it does not traverse the
+// tree; it only evaluates the ignore rules for the path and its parent directories.
+// It returns false if the path does not exist, is not a regular file,
+// is not contained in one of the configured paths, or is ignored.
+func (w *FileSet) Match(name string) (File, bool, error) {
+	name = pathlib.Clean(filepath.ToSlash(name))
+	ok, err := w.Selects(name)
+	if err != nil || !ok {
+		return File{}, false, err
+	}
+
+	info, err := fs.Stat(w.root, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return File{}, false, nil
+	}
+	if err != nil {
+		return File{}, false, err
+	}
+
+	// Skip non-regular files (e.g. symlinks), consistent with [FileSet.Files].
+	if !info.Mode().IsRegular() {
+		return File{}, false, nil
+	}
+
+	return NewFile(w.root, fs.FileInfoToDirEntry(info), name), true, nil
+}
+
+// Selects returns true if a file at the specified path (relative to the root)
+// would be part of this fileset. Unlike [FileSet.Match], it does not require
+// the file to exist, so it can be used for files that are yet to be created.
+func (w *FileSet) Selects(name string) (bool, error) {
+	if w.root == nil {
+		return false, nil
+	}
+
+	name = pathlib.Clean(filepath.ToSlash(name))
+	for _, p := range w.paths {
+		dirs, ok := parentDirs(p, name)
+		if !ok {
+			continue
+		}
+
+		for _, dir := range dirs {
+			ign, err := w.ignore.IgnoreDirectory(dir)
+			if err != nil {
+				return false, fmt.Errorf("cannot check if %s should be ignored: %w", dir, err)
+			}
+			if ign {
+				return false, nil
+			}
+		}
+
+		ign, err := w.ignore.IgnoreFile(name)
+		if err != nil {
+			return false, fmt.Errorf("cannot check if %s should be ignored: %w", name, err)
+		}
+		return !ign, nil
+	}
+
+	return false, nil
+}
+
+// parentDirs returns the directories that a traversal of root visits
+// before reaching name, in order. It returns false if name is not
+// contained in root.
+func parentDirs(root, name string) ([]string, bool) {
+	if root == name {
+		return nil, true
+	}
+
+	rel := name
+	if root != "." {
+		var ok bool
+		rel, ok = strings.CutPrefix(name, root+"/")
+		if !ok {
+			return nil, false
+		}
+	}
+
+	dirs := []string{root}
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		dirs = append(dirs, pathlib.Join(root, pathlib.Join(parts[:i]...)))
+	}
+	return dirs, true
+}
@@ -98,6 +98,80 @@ func TestFileSet_IgnoreFile(t *testing.T) {
 	assert.Equal(t, "dir2/b", files[2].Relative)
 }
 
+func TestFileSet_Match(t *testing.T) {
+	fs := New(vfs.MustNew("testdata"), []string{"dir1", "dir2"})
+	fs.SetIgnorer(testIgnorer{file: []string{"dir2/b"}})
+
+	f, ok, err := fs.Match("dir1/a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "dir1/a", f.Relative)
+
+	// Ignored file.
+	_, ok, err = fs.Match("dir2/b")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Outside of the configured paths.
+	_, ok, err = fs.Match("dir3/a")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Does not exist.
+	_, ok, err = fs.Match("dir1/c")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Directories are not files.
+	_, ok, err = fs.Match("dir1")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestFileSet_MatchIgnoreDir(t *testing.T) {
+	fs := New(vfs.MustNew("testdata"))
+	fs.SetIgnorer(testIgnorer{dir: []string{"dir1"}})
+
+	_, ok, err := fs.Match("dir1/a")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = fs.Match("dir2/a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestFileSet_Selects(t *testing.T) {
+	fs := New(vfs.MustNew("testdata"), []string{"dir1", "dir2"})
+	fs.SetIgnorer(testIgnorer{file: []string{"dir2/b"}, dir: []string{"dir1/sub"}})
+
+	// Files do not have to exist.
+	ok, err := fs.Selects("dir1/c")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Ignored file.
+	ok, err = fs.Selects("dir2/b")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// In an ignored directory.
+	ok, err = fs.Selects("dir1/sub/c")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Outside of the configured paths.
+	ok, err = fs.Selects("dir3/a")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestFileSet_MatchEmpty(t *testing.T) {
+	_, ok, err := Empty().Match("dir1/a")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
 type testIgnorer struct {
 	// dir is a list of directories to ignore. Strings are compared verbatim.
 	dir []string
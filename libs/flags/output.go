@@ -3,6 +3,7 @@ package flags
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -12,22 +13,27 @@ import (
 type Output string
 
 const (
-	OutputText Output = "text"
-	OutputJSON Output = "json"
+	OutputText   Output = "text"
+	OutputJSON   Output = "json"
+	OutputYAML   Output = "yaml"
+	OutputCSV    Output = "csv"
+	OutputTSV    Output = "tsv"
+	OutputNDJSON Output = "ndjson"
 )
 
+// outputTypes lists the accepted output types in the order they are documented.
+var outputTypes = []Output{OutputText, OutputJSON, OutputYAML, OutputCSV, OutputTSV, OutputNDJSON}
+
 func (f *Output) String() string {
 	return string(*f)
 }
 
 func (f *Output) Set(s string) error {
-	lower := strings.ToLower(s)
-	switch lower {
-	case `json`, `text`:
-		*f = Output(lower)
-	default:
-		return errors.New("accepted arguments are json and text")
+	lower := Output(strings.ToLower(s))
+	if !slices.Contains(outputTypes, lower) {
+		return errors.New("accepted arguments are text, json, yaml, csv, tsv and ndjson")
 	}
+	*f = lower
 	return nil
 }
 
@@ -37,8 +43,9 @@ func (f *Output) Type() string {
 
 // Complete is the Cobra compatible completion function for this flag.
 func (f *Output) Complete(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	return []string{
-		fmt.Sprint(OutputText),
-		fmt.Sprint(OutputJSON),
-	}, cobra.ShellCompDirectiveNoFileComp
+	var completions []string
+	for _, o := range outputTypes {
+		completions = append(completions, fmt.Sprint(o))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
 }
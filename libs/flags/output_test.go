@@ -12,7 +12,7 @@ func TestOutputFlag(t *testing.T) {
 
 	// Invalid
 	err = f.Set("foo")
-	assert.EqualError(t, err, "accepted arguments are json and text")
+	assert.EqualError(t, err, "accepted arguments are text, json, yaml, csv, tsv and ndjson")
 
 	// Lowercase
 	err = f.Set("text")
@@ -33,4 +33,10 @@ func TestOutputFlag(t *testing.T) {
 	err = f.Set("JSON")
 	assert.NoError(t, err)
 	assert.Equal(t, "json", f.String())
+
+	for _, v := range []string{"yaml", "csv", "tsv", "ndjson"} {
+		err = f.Set(v)
+		assert.NoError(t, err)
+		assert.Equal(t, v, f.String())
+	}
 }
@@ -37,3 +37,22 @@ func (f *FileSet) Files() ([]fileset.File, error) {
 	f.view.repo.taintIgnoreRules()
 	return f.fileset.Files()
 }
+
+// Match returns the [fileset.File] at the specified path if it is part of this
+// fileset. Like [FileSet.Files], it checks if gitignore files have been modified.
+func (f *FileSet) Match(name string) (fileset.File, bool, error) {
+	f.view.repo.taintIgnoreRules()
+	return f.fileset.Match(name)
+}
+
+// Selects returns true if a file at the specified path would be part of this
+// fileset, whether or not it exists.
+func (f *FileSet) Selects(name string) (bool, error) {
+	f.view.repo.taintIgnoreRules()
+	return f.fileset.Selects(name)
+}
+
+// IgnoreDirectory returns if the gitignore rules apply to the specified directory.
+func (f *FileSet) IgnoreDirectory(dir string) (bool, error) {
+	return f.view.IgnoreDirectory(dir)
+}
@@ -49,3 +49,25 @@ func TestFileSetNonCleanRoot(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, files, 3)
 }
+
+func TestFileSetMatch(t *testing.T) {
+	fileSet, err := NewFileSetAtRoot(t.Context(), vfs.MustNew("./testdata"))
+	require.NoError(t, err)
+
+	f, ok, err := fileSet.Match("a/b/world.txt")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "a/b/world.txt", f.Relative)
+
+	_, ok, err = fileSet.Match("a/a.ignore")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = fileSet.Match("ignorethis/ignored.txt")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ign, err := fileSet.IgnoreDirectory("ignorethis")
+	require.NoError(t, err)
+	assert.True(t, ign)
+}
@@ -0,0 +1,143 @@
+package git
+
+import (
+	"context"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/databricks/cli/libs/auth"
+	"github.com/databricks/cli/libs/filer"
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+)
+
+// GitFolderInfo describes the workspace Git folder (Repo) that contains a path.
+type GitFolderInfo struct {
+	// ID is the ID of the Git folder in the Repos API.
+	ID int64
+
+	// Path is the workspace path of the root of the Git folder, starting with /Workspace/.
+	Path string
+
+	// URL is the URL of the remote Git repository.
+	URL string
+
+	// Branch is the branch checked out in the Git folder.
+	Branch string
+
+	// Commit is the commit checked out in the Git folder.
+	Commit string
+}
+
+type gitInfo struct {
+	Branch       string `json:"branch"`
+	HeadCommitID string `json:"head_commit_id"`
+	ID           int64  `json:"id"`
+	Path         string `json:"path"`
+	URL          string `json:"url"`
+}
+
+type gitInfoResponse struct {
+	GitInfo *gitInfo `json:"git_info,omitempty"`
+}
+
+// FetchGitFolderInfo returns the Git folder that contains the workspace path p,
+// or nil if p is not in a Git folder. It returns an error wrapping
+// fs.ErrNotExist if p does not exist.
+func FetchGitFolderInfo(ctx context.Context, w *databricks.WorkspaceClient, p string) (*GitFolderInfo, error) {
+	apiClient, err := client.New(w.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	var response gitInfoResponse
+	err = apiClient.Do(
+		ctx,
+		http.MethodGet,
+		"/api/2.0/workspace/get-status",
+		auth.WorkspaceIDHeaders(w.Config),
+		nil,
+		map[string]string{
+			"path":            p,
+			"return_git_info": "true",
+		},
+		&response,
+	)
+	if err != nil {
+		if apierr.IsMissing(err) {
+			return nil, fs.ErrNotExist
+		}
+		return nil, err
+	}
+
+	gi := response.GitInfo
+	if gi == nil {
+		return nil, nil
+	}
+
+	info := &GitFolderInfo{
+		ID:     gi.ID,
+		Path:   gi.Path,
+		URL:    gi.URL,
+		Branch: gi.Branch,
+		Commit: gi.HeadCommitID,
+	}
+	if !strings.HasPrefix(info.Path, "/Workspace/") {
+		info.Path = path.Join("/Workspace", info.Path)
+	}
+
+	// A Git folder with Git CLI access does not store the git metadata on the
+	// workspace object, so get-status returns only the id and path for it. The
+	// Repos API still has the metadata, and git_info.id identifies the Git
+	// folder root even when the queried path is a subdirectory of it.
+	if gi.ID != 0 && gi.Branch == "" && gi.HeadCommitID == "" && gi.URL == "" {
+		repo, err := w.Repos.Get(ctx, workspace.GetRepoRequest{RepoId: gi.ID})
+		if err != nil {
+			log.Warnf(ctx, "failed to load git info for repo %d: %s", gi.ID, err)
+		} else {
+			info.URL = repo.Url
+			info.Commit = repo.HeadCommitId
+			info.Branch = repo.Branch
+		}
+	}
+
+	return info, nil
+}
+
+// GitFolderClient implements the [filer.Filer] interface for a root path
+// inside a workspace Git folder. Files are read and written like with the
+// client returned by [filer.NewWorkspaceFilesClient]; in addition, the client
+// reports the branch and commit checked out in the Git folder.
+type GitFolderClient struct {
+	filer.Filer
+
+	workspaceClient *databricks.WorkspaceClient
+	root            string
+}
+
+// NewGitFolderClient returns a [GitFolderClient] for root. It does not check
+// that root is in a Git folder; use [GitFolderClient.Info] for that.
+func NewGitFolderClient(w *databricks.WorkspaceClient, root string) (*GitFolderClient, error) {
+	f, err := filer.NewWorkspaceFilesClient(w, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitFolderClient{
+		Filer:           f,
+		workspaceClient: w,
+		root:            root,
+	}, nil
+}
+
+// Info returns the Git folder that contains the root path of the client, or
+// nil if it is not in a Git folder. The branch is looked up on every call, as
+// it can be switched in the workspace at any time.
+func (c *GitFolderClient) Info(ctx context.Context) (*GitFolderInfo, error) {
+	return FetchGitFolderInfo(ctx, c.workspaceClient, c.root)
+}
@@ -0,0 +1,52 @@
+package git
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/databricks/cli/libs/testserver"
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitFolderClientInfo(t *testing.T) {
+	server := testserver.New(t)
+	testserver.AddDefaultHandlers(server)
+
+	w, err := databricks.NewWorkspaceClient(&databricks.Config{
+		Host:  server.URL,
+		Token: "testtoken",
+	})
+	require.NoError(t, err)
+
+	repo, err := w.Repos.Create(t.Context(), workspace.CreateRepoRequest{
+		Path:     "/Repos/someone@example.com/repo",
+		Provider: "gitHub",
+		Url:      "https://github.com/databricks/cli.git",
+	})
+	require.NoError(t, err)
+
+	f, err := NewGitFolderClient(w, "/Repos/someone@example.com/repo")
+	require.NoError(t, err)
+
+	info, err := f.Info(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, &GitFolderInfo{
+		ID:     repo.Id,
+		Path:   "/Workspace/Repos/someone@example.com/repo",
+		URL:    "https://github.com/databricks/cli.git",
+		Branch: "main",
+		Commit: repo.HeadCommitId,
+	}, info)
+
+	// A path outside of a Git folder.
+	require.NoError(t, w.Workspace.MkdirsByPath(t.Context(), "/Users/someone@example.com/project"))
+	info, err = FetchGitFolderInfo(t.Context(), w, "/Users/someone@example.com/project")
+	require.NoError(t, err)
+	assert.Nil(t, info)
+
+	_, err = FetchGitFolderInfo(t.Context(), w, "/Users/someone@example.com/missing")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -4,19 +4,13 @@ import (
 	"context"
 	"errors"
 	"io/fs"
-	"net/http"
-	"path"
 	"strings"
 
-	"github.com/databricks/cli/libs/auth"
 	"github.com/databricks/cli/libs/dbr"
 	"github.com/databricks/cli/libs/folders"
 	"github.com/databricks/cli/libs/log"
 	"github.com/databricks/cli/libs/vfs"
 	"github.com/databricks/databricks-sdk-go"
-	"github.com/databricks/databricks-sdk-go/apierr"
-	"github.com/databricks/databricks-sdk-go/client"
-	"github.com/databricks/databricks-sdk-go/service/workspace"
 )
 
 type RepositoryInfo struct {
@@ -29,18 +23,6 @@ type RepositoryInfo struct {
 	WorktreeRoot string
 }
 
-type gitInfo struct {
-	Branch       string `json:"branch"`
-	HeadCommitID string `json:"head_commit_id"`
-	ID           int64  `json:"id"`
-	Path         string `json:"path"`
-	URL          string `json:"url"`
-}
-
-type response struct {
-	GitInfo *gitInfo `json:"git_info,omitempty"`
-}
-
 // Fetch repository information either by quering .git or by fetching it from API (for dabs-in-workspace case).
 //   - In case we could not find git repository (including when the path does not exist), all string fields of RepositoryInfo will be "" and err will be nil.
 //   - If there were any errors when trying to determine git root (e.g. API call returned an error or there were permission issues
@@ -77,75 +59,24 @@ func FetchRepositoryInfo(ctx context.Context, path string, w *databricks.Workspa
 func FetchRepositoryInfoAPI(ctx context.Context, path string, w *databricks.WorkspaceClient) (RepositoryInfo, error) {
 	result := RepositoryInfo{}
 
-	apiClient, err := client.New(w.Config)
+	gi, err := FetchGitFolderInfo(ctx, w, path)
 	if err != nil {
 		return result, err
 	}
 
-	var response response
-	const apiEndpoint = "/api/2.0/workspace/get-status"
-
-	err = apiClient.Do(
-		ctx,
-		http.MethodGet,
-		apiEndpoint,
-		auth.WorkspaceIDHeaders(w.Config),
-		nil,
-		map[string]string{
-			"path":            path,
-			"return_git_info": "true",
-		},
-		&response,
-	)
-	if err != nil {
-		// The workspace API returns 404 when the path is not a workspace object
-		// (for example, an ephemeral directory that is not part of a Repo).
-		// Normalize it to fs.ErrNotExist, the same signal fetchRepositoryInfoDotGit
-		// produces for a missing local path, so FetchRepositoryInfo can treat
-		// "no path" as "no repository" uniformly.
-		if apierr.IsMissing(err) {
-			return result, fs.ErrNotExist
-		}
-		return result, err
+	if gi == nil {
+		log.Infof(ctx, "No git info for %s: the path is not in a Git folder", path)
+		return result, nil
 	}
 
-	// Check if GitInfo is present and extract relevant fields
-	gi := response.GitInfo
-	if gi != nil {
-		fixedPath := ensureWorkspacePrefix(gi.Path)
-		result.OriginURL = gi.URL
-		result.LatestCommit = gi.HeadCommitID
-		result.CurrentBranch = gi.Branch
-		result.WorktreeRoot = fixedPath
-
-		// A Git folder with Git CLI access does not store the git metadata on the
-		// workspace object, so get-status returns only the id and path for it. The
-		// Repos API still has the metadata, and git_info.id identifies the Git
-		// folder root even when the queried path is a subdirectory of it.
-		if gi.ID != 0 && gi.Branch == "" && gi.HeadCommitID == "" && gi.URL == "" {
-			repo, err := w.Repos.Get(ctx, workspace.GetRepoRequest{RepoId: gi.ID})
-			if err != nil {
-				log.Warnf(ctx, "failed to load git info for repo %d: %s", gi.ID, err)
-			} else {
-				result.OriginURL = repo.Url
-				result.LatestCommit = repo.HeadCommitId
-				result.CurrentBranch = repo.Branch
-			}
-		}
-	} else {
-		log.Infof(ctx, "Failed to load git info from %s", apiEndpoint)
-	}
+	result.OriginURL = gi.URL
+	result.LatestCommit = gi.Commit
+	result.CurrentBranch = gi.Branch
+	result.WorktreeRoot = gi.Path
 
 	return result, nil
 }
 
-func ensureWorkspacePrefix(p string) string {
-	if !strings.HasPrefix(p, "/Workspace/") {
-		return path.Join("/Workspace", p)
-	}
-	return p
-}
-
 func fetchRepositoryInfoDotGit(ctx context.Context, path string) (RepositoryInfo, error) {
 	result := RepositoryInfo{}
 
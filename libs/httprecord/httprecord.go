@@ -0,0 +1,91 @@
+// Package httprecord records the HTTP exchanges of the Databricks SDK to a file
+// and replays them from it.
+//
+// A recording is an NDJSON file with one [Exchange] per line. Secrets in headers,
+// query parameters and bodies are redacted before they are written, and the host
+// is left out, so that a recording can be attached to a bug report and replayed
+// against any host.
+package httprecord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/databricks/databricks-sdk-go/config"
+)
+
+// Exchange is a single HTTP request and the response to it.
+type Exchange struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+
+	// URL is the path and query of the request.
+	URL string `json:"url"`
+
+	Headers http.Header `json:"headers,omitempty"`
+	Body
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body
+}
+
+// Body is the body of a request or response. At most one of its fields is set.
+type Body struct {
+	JSON json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+
+	// OmittedBytes is the size of a body that cannot be scrubbed, such as a
+	// binary body. Such bodies are left out of recordings and replay as empty.
+	OmittedBytes int `json:"omitted_bytes,omitempty"`
+}
+
+func (b Body) bytes() []byte {
+	switch {
+	case b.JSON != nil:
+		return b.JSON
+	case b.Text != "":
+		return []byte(b.Text)
+	default:
+		return nil
+	}
+}
+
+// transport is implemented by [Recorder] and [Replayer].
+type transport interface {
+	forConfig(cfg *config.Config) http.RoundTripper
+}
+
+type transportKeyType struct{}
+
+// WithRecorder makes [Configure] record the exchanges of SDK clients with r.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, transportKeyType{}, r)
+}
+
+// WithReplayer makes [Configure] answer the requests of SDK clients from r.
+func WithReplayer(ctx context.Context, r *Replayer) context.Context {
+	return context.WithValue(ctx, transportKeyType{}, r)
+}
+
+// Configure sets the HTTP transport of cfg to record or replay its exchanges,
+// if the context asks for it. It must be called before cfg is resolved, because
+// resolving the configuration can make requests too. A nil context leaves cfg
+// unchanged.
+func Configure(ctx context.Context, cfg *config.Config) {
+	if ctx == nil {
+		return
+	}
+	t, ok := ctx.Value(transportKeyType{}).(transport)
+	if !ok {
+		return
+	}
+	cfg.HTTPTransport = t.forConfig(cfg)
+}
@@ -0,0 +1,139 @@
+package httprecord
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/databricks/databricks-sdk-go/config"
+)
+
+// Recorder appends the exchanges of SDK clients to a file.
+type Recorder struct {
+	path string
+
+	mu sync.Mutex
+
+	// Underlying transports, created on first use. The SDK does not apply
+	// insecure_skip_verify to a custom transport, so the recorder does.
+	once     sync.Once
+	secure   http.RoundTripper
+	insecure http.RoundTripper
+}
+
+// NewRecorder returns a recorder that appends to the file at path. Every
+// exchange is written as soon as it completes, so that several processes can
+// record to the same file and a recording survives a crash.
+func NewRecorder(path string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	err = f.Close()
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{path: path}, nil
+}
+
+func (r *Recorder) forConfig(cfg *config.Config) http.RoundTripper {
+	return &recordingTransport{recorder: r, cfg: cfg}
+}
+
+func (r *Recorder) base(cfg *config.Config) http.RoundTripper {
+	r.once.Do(func() {
+		secure := http.DefaultTransport.(*http.Transport).Clone()
+		insecure := secure.Clone()
+		insecure.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		r.secure, r.insecure = secure, insecure
+	})
+	if cfg.InsecureSkipVerify {
+		return r.insecure
+	}
+	return r.secure
+}
+
+func (r *Recorder) write(e Exchange) error {
+	// Keep characters like & in URLs readable.
+	var line bytes.Buffer
+	enc := json.NewEncoder(&line)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(e)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(line.Bytes())
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type recordingTransport struct {
+	recorder *Recorder
+	cfg      *config.Config
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.recorder.base(t.cfg).RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	path := req.URL.Path
+	err = t.recorder.write(Exchange{
+		Request: Request{
+			Method:  req.Method,
+			URL:     scrubURL(req.URL),
+			Headers: scrubHeaders(req.Header),
+			Body:    newBody(path, reqBody, req.Header.Get("Content-Type")),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    scrubHeaders(resp.Header),
+			Body:       newBody(path, respBody, resp.Header.Get("Content-Type")),
+		},
+	})
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// readBody reads *body and replaces it with a reader over the same bytes.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
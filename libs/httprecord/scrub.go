@@ -0,0 +1,205 @@
+package httprecord
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// redacted replaces every secret in a recording.
+const redacted = "[REDACTED]"
+
+var (
+	// Matches Databricks personal access tokens (e.g. dapi0123...cdef-2).
+	patRegexp = regexp.MustCompile(`dapi[0-9a-f]{32}(-\d+)?`)
+
+	// Matches JSON Web Tokens, as used for OAuth access and ID tokens.
+	jwtRegexp = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+)
+
+// Keys of JSON fields, query parameters and form values that hold secrets.
+var secretKeys = map[string]bool{
+	"token":                 true,
+	"secret":                true,
+	"password":              true,
+	"authorization":         true,
+	"assertion":             true,
+	"code_verifier":         true,
+	"private_key":           true,
+	"personal_access_token": true,
+	"token_value":           true,
+
+	// Values of secrets in secret scopes and Unity Catalog.
+	"string_value": true,
+	"bytes_value":  true,
+}
+
+// Keys of JSON fields that hold secrets in the bodies of specific endpoints,
+// where the key alone does not say so.
+var secretEndpointKeys = map[string][]string{
+	// Returns the base64 encoded value of a secret.
+	"/api/2.0/secrets/get": {"value"},
+}
+
+// Suffixes of keys that hold secrets, e.g. access_token and client_secret.
+var secretKeySuffixes = []string{
+	"_token",
+	"_secret",
+	"_password",
+	"_private_key",
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	// Pagination tokens are not secret, and replay needs them to match requests.
+	if strings.HasSuffix(key, "page_token") {
+		return false
+	}
+	if secretKeys[key] {
+		return true
+	}
+	for _, suffix := range secretKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSecretHeader(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "authorization", "proxy-authorization", "cookie", "set-cookie":
+		return true
+	}
+	return strings.Contains(name, "token") || strings.Contains(name, "secret") || strings.Contains(name, "password")
+}
+
+// scrubText redacts the tokens that can be recognized by their shape alone.
+func scrubText(s string) string {
+	s = patRegexp.ReplaceAllString(s, redacted)
+	return jwtRegexp.ReplaceAllString(s, redacted)
+}
+
+func scrubHeaders(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(http.Header, len(h))
+	for name, values := range h {
+		scrubbed := make([]string, len(values))
+		for i, v := range values {
+			if isSecretHeader(name) {
+				scrubbed[i] = redacted
+			} else {
+				scrubbed[i] = scrubText(v)
+			}
+		}
+		out[name] = scrubbed
+	}
+	return out
+}
+
+func scrubValues(values url.Values) url.Values {
+	for key, vs := range values {
+		for i, v := range vs {
+			if isSecretKey(key) {
+				vs[i] = redacted
+			} else {
+				vs[i] = scrubText(v)
+			}
+		}
+	}
+	return values
+}
+
+// scrubURL returns the path and query of u with secrets redacted. Recordings
+// leave out the host, so that they replay against any workspace.
+func scrubURL(u *url.URL) string {
+	uri := u.EscapedPath()
+	if u.RawQuery == "" {
+		return uri
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return uri + "?" + scrubText(u.RawQuery)
+	}
+	return uri + "?" + scrubValues(query).Encode()
+}
+
+// scrubJSON redacts the values of secret fields anywhere in v, including the
+// fields in keys that are secret for the endpoint that v belongs to.
+func scrubJSON(v any, keys []string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, elem := range v {
+			if (isSecretKey(key) || slices.Contains(keys, key)) && elem != nil {
+				v[key] = redacted
+			} else {
+				v[key] = scrubJSON(elem, keys)
+			}
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = scrubJSON(elem, keys)
+		}
+	case string:
+		return scrubText(v)
+	}
+	return v
+}
+
+// newBody encodes a request or response body of the endpoint at path with its
+// secrets redacted. JSON documents stay JSON so that recordings are easy to read
+// and form values and other text are kept as text. Binary bodies and bodies of
+// endpoints with secrets that are not JSON are left out.
+func newBody(path string, data []byte, contentType string) Body {
+	if len(data) == 0 {
+		return Body{}
+	}
+
+	keys, isSecretEndpoint := secretEndpointKeys[path]
+
+	switch {
+	case strings.HasPrefix(contentType, "application/json") && json.Valid(data):
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var v any
+		err := dec.Decode(&v)
+		if err == nil {
+			scrubbed, err := json.Marshal(scrubJSON(v, keys))
+			if err == nil {
+				return Body{JSON: scrubbed}
+			}
+		}
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		values, err := url.ParseQuery(string(data))
+		if err == nil {
+			return Body{Text: scrubValues(values).Encode()}
+		}
+	}
+
+	if isText(data) && !isSecretEndpoint {
+		return Body{Text: scrubText(string(data))}
+	}
+	return Body{OmittedBytes: len(data)}
+}
+
+// isText reports whether data is valid UTF-8 without control characters other
+// than whitespace.
+func isText(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if r < 0x20 && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
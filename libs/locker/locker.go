@@ -8,10 +8,14 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"os"
 	"slices"
+	"sync"
 	"time"
 
+	"github.com/databricks/cli/internal/build"
 	"github.com/databricks/cli/libs/filer"
+	"github.com/databricks/cli/libs/log"
 	"github.com/databricks/databricks-sdk-go"
 	"github.com/google/uuid"
 )
@@ -24,6 +28,11 @@ const (
 
 const LockFileName = "deploy.lock"
 
+// DefaultLeaseDuration is how long a lock remains valid after it was last
+// refreshed. The holder refreshes it every third of this duration, so a lock
+// expires only if its holder stopped running (e.g. a killed CI runner).
+const DefaultLeaseDuration = 15 * time.Minute
+
 // Locker object enables exclusive access to TargetDir's scope for a client. This
 // enables multiple clients to deploy to the same scope (ie TargetDir) in an atomic
 // manner
@@ -47,6 +56,12 @@ const LockFileName = "deploy.lock"
 //     b.  forcefully acquiring a lock(s) on TargetDir can break the assumption
 //     of exclusive access that other clients with non forcefully acquired
 //     locks might have
+//
+//  4. A lock is a lease: its holder refreshes the lock file periodically while
+//     it holds the lock (see [Locker.LeaseDuration]). A lock that has not been
+//     refreshed for longer than its lease duration was abandoned, and is taken
+//     over by the next client that acquires the lock, without --force-lock.
+//     Lock files written without a lease duration never expire.
 type Locker struct {
 	filer filer.Filer
 
@@ -58,6 +73,18 @@ type Locker struct {
 	// if locker is active, this information about the locker is uploaded onto
 	// the workspace so as to let other clients details about the active locker
 	State *LockState
+
+	// LeaseDuration is recorded in the lock file on acquisition. The lock is
+	// refreshed every third of this duration while it is held.
+	LeaseDuration time.Duration
+
+	// stopHeartbeat stops the goroutine that refreshes the lock, and
+	// heartbeatDone is closed once it has stopped.
+	stopHeartbeat context.CancelFunc
+	heartbeatDone chan struct{}
+
+	// mu serializes refreshes of the lock file with its removal.
+	mu sync.Mutex
 }
 
 type LockState struct {
@@ -70,6 +97,34 @@ type LockState struct {
 	IsForced bool
 	// creator of this locker
 	User string
+	// host the locker runs on
+	Host string
+	// version of the CLI that acquired the lock
+	CLIVersion string
+	// duration after the last heartbeat for which the lock is valid.
+	// Zero for lock files written by versions without leases; these never expire.
+	LeaseDuration time.Duration
+	// last time the holder refreshed the lock
+	HeartbeatTime time.Time
+}
+
+// ExpiresAt returns the time the lock expires if it is not refreshed, and
+// false if the lock does not expire.
+func (s *LockState) ExpiresAt() (time.Time, bool) {
+	if s.LeaseDuration <= 0 {
+		return time.Time{}, false
+	}
+	last := s.HeartbeatTime
+	if last.IsZero() {
+		last = s.AcquisitionTime
+	}
+	return last.Add(s.LeaseDuration), true
+}
+
+// IsExpired returns true if the lease of the lock expired at the specified time.
+func (s *LockState) IsExpired(now time.Time) bool {
+	expiresAt, ok := s.ExpiresAt()
+	return ok && now.After(expiresAt)
 }
 
 // GetActiveLockState returns current lock state, irrespective of us holding it.
@@ -135,11 +190,16 @@ func (locker *Locker) Read(ctx context.Context, path string) (io.ReadCloser, err
 }
 
 func (locker *Locker) Lock(ctx context.Context, isForced bool) error {
+	now := time.Now()
 	newLockerState := LockState{
 		ID:              locker.State.ID,
-		AcquisitionTime: time.Now(),
+		AcquisitionTime: now,
 		IsForced:        isForced,
 		User:            locker.State.User,
+		Host:            locker.State.Host,
+		CLIVersion:      locker.State.CLIVersion,
+		LeaseDuration:   locker.LeaseDuration,
+		HeartbeatTime:   now,
 	}
 	buf, err := json.Marshal(newLockerState)
 	if err != nil {
@@ -165,6 +225,11 @@ func (locker *Locker) Lock(ctx context.Context, isForced bool) error {
 		if !errors.Is(err, fs.ErrExist) {
 			return err
 		}
+
+		err = locker.takeOverExpired(ctx, buf)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = locker.assertLockHeld(ctx)
@@ -174,14 +239,108 @@ func (locker *Locker) Lock(ctx context.Context, isForced bool) error {
 
 	locker.State = &newLockerState
 	locker.Active = true
+	locker.startHeartbeat(ctx)
+	return nil
+}
+
+// takeOverExpired overwrites the lock file with buf if the lock it holds expired.
+// Like a forced acquisition, this is not atomic: if several clients take over
+// the same lock, [Locker.assertLockHeld] lets only the last writer proceed.
+func (locker *Locker) takeOverExpired(ctx context.Context, buf []byte) error {
+	activeLockState, err := locker.GetActiveLockState(ctx)
+	if errors.Is(err, fs.ErrNotExist) {
+		// The lock was released in the meantime.
+		return locker.filer.Write(ctx, LockFileName, bytes.NewReader(buf), filer.CreateParentDirectories)
+	}
+	if err != nil {
+		return err
+	}
+	if !activeLockState.IsExpired(time.Now()) {
+		return nil
+	}
+
+	expiresAt, _ := activeLockState.ExpiresAt()
+	log.Warnf(ctx, "Taking over deploy lock acquired by %s at %v; its lease expired at %v",
+		activeLockState.User, activeLockState.AcquisitionTime, expiresAt)
+	return locker.filer.Write(ctx, LockFileName, bytes.NewReader(buf), filer.OverwriteIfExists)
+}
+
+// Refresh records a heartbeat in the lock file, extending the lease.
+func (locker *Locker) Refresh(ctx context.Context) error {
+	locker.mu.Lock()
+	defer locker.mu.Unlock()
+
+	if !locker.Active {
+		return errors.New("refresh called when lock is not held")
+	}
+
+	err := locker.assertLockHeld(ctx)
+	if err != nil {
+		return err
+	}
+
+	state := *locker.State
+	state.HeartbeatTime = time.Now()
+	buf, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	err = locker.filer.Write(ctx, LockFileName, bytes.NewReader(buf), filer.OverwriteIfExists)
+	if err != nil {
+		return err
+	}
+
+	locker.State = &state
 	return nil
 }
 
+// startHeartbeat refreshes the lock every third of the lease duration until
+// [Locker.stopHeartbeatAndWait] is called or the context is cancelled.
+func (locker *Locker) startHeartbeat(ctx context.Context) {
+	if locker.LeaseDuration <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	locker.stopHeartbeat = cancel
+	locker.heartbeatDone = done
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(locker.LeaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := locker.Refresh(ctx)
+				if err != nil && ctx.Err() == nil {
+					log.Warnf(ctx, "Failed to refresh deploy lock: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (locker *Locker) stopHeartbeatAndWait() {
+	if locker.stopHeartbeat == nil {
+		return
+	}
+	locker.stopHeartbeat()
+	<-locker.heartbeatDone
+	locker.stopHeartbeat = nil
+	locker.heartbeatDone = nil
+}
+
 func (locker *Locker) Unlock(ctx context.Context, opts ...UnlockOption) error {
 	if !locker.Active {
 		return errors.New("unlock called when lock is not held")
 	}
 
+	locker.stopHeartbeatAndWait()
+
 	// if allowLockFileNotExist is set, do not throw an error if the lock file does
 	// not exist. This is helpful when destroying a bundle in which case the lock
 	// file will be deleted before we have a chance to unlock
@@ -202,22 +361,40 @@ func (locker *Locker) Unlock(ctx context.Context, opts ...UnlockOption) error {
 	return nil
 }
 
+// Release deletes the lock file regardless of who holds the lock.
+// It is meant for releasing a lock abandoned by another client.
+func (locker *Locker) Release(ctx context.Context) error {
+	locker.stopHeartbeatAndWait()
+	err := locker.filer.Delete(ctx, LockFileName)
+	if err != nil {
+		return err
+	}
+	locker.Active = false
+	return nil
+}
+
 func CreateLocker(user, targetDir string, w *databricks.WorkspaceClient) (*Locker, error) {
 	filer, err := filer.NewWorkspaceFilesClient(w, targetDir)
 	if err != nil {
 		return nil, err
 	}
 
-	locker := &Locker{
-		filer: filer,
+	return newLocker(filer, user, targetDir), nil
+}
+
+func newLocker(f filer.Filer, user, targetDir string) *Locker {
+	host, _ := os.Hostname()
+	return &Locker{
+		filer: f,
 
 		TargetDir: targetDir,
 		Active:    false,
 		State: &LockState{
-			ID:   uuid.New(),
-			User: user,
+			ID:         uuid.New(),
+			User:       user,
+			Host:       host,
+			CLIVersion: build.GetInfo().Version,
 		},
+		LeaseDuration: DefaultLeaseDuration,
 	}
-
-	return locker, nil
 }
@@ -0,0 +1,118 @@
+package locker
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/databricks/cli/libs/filer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLockers(t *testing.T) (*Locker, *Locker, string) {
+	dir := t.TempDir()
+	f, err := filer.NewLocalClient(dir)
+	require.NoError(t, err)
+	return newLocker(f, "alice", dir), newLocker(f, "bob", dir), dir
+}
+
+func writeLockState(t *testing.T, dir string, state LockState) {
+	buf, err := json.Marshal(state)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, LockFileName), buf, 0o644))
+}
+
+func TestLockRecordsLease(t *testing.T) {
+	ctx := t.Context()
+	a, _, _ := newTestLockers(t)
+
+	require.NoError(t, a.Lock(ctx, false))
+
+	state, err := a.GetActiveLockState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", state.User)
+	assert.Equal(t, DefaultLeaseDuration, state.LeaseDuration)
+	assert.NotEmpty(t, state.CLIVersion)
+	assert.False(t, state.IsExpired(time.Now()))
+	assert.True(t, state.IsExpired(time.Now().Add(DefaultLeaseDuration+time.Minute)))
+	require.NoError(t, a.Unlock(ctx))
+}
+
+func TestLockHeldByOther(t *testing.T) {
+	ctx := t.Context()
+	a, b, _ := newTestLockers(t)
+
+	require.NoError(t, a.Lock(ctx, false))
+	err := b.Lock(ctx, false)
+	assert.ErrorContains(t, err, "deploy lock acquired by alice")
+	assert.False(t, b.Active)
+
+	require.NoError(t, a.Unlock(ctx))
+	require.NoError(t, b.Lock(ctx, false))
+	require.NoError(t, b.Unlock(ctx))
+}
+
+func TestLockTakesOverExpiredLease(t *testing.T) {
+	ctx := t.Context()
+	_, b, dir := newTestLockers(t)
+
+	writeLockState(t, dir, LockState{
+		User:            "alice",
+		AcquisitionTime: time.Now().Add(-time.Hour),
+		HeartbeatTime:   time.Now().Add(-time.Hour),
+		LeaseDuration:   time.Minute,
+	})
+
+	require.NoError(t, b.Lock(ctx, false))
+	state, err := b.GetActiveLockState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", state.User)
+	assert.False(t, state.IsForced)
+	require.NoError(t, b.Unlock(ctx))
+}
+
+func TestLockWithoutLeaseDoesNotExpire(t *testing.T) {
+	ctx := t.Context()
+	_, b, dir := newTestLockers(t)
+
+	// Lock files written by older versions have no lease.
+	writeLockState(t, dir, LockState{
+		User:            "alice",
+		AcquisitionTime: time.Now().Add(-24 * time.Hour),
+	})
+
+	err := b.Lock(ctx, false)
+	assert.ErrorContains(t, err, "deploy lock acquired by alice")
+}
+
+func TestLockHeartbeat(t *testing.T) {
+	ctx := t.Context()
+	a, _, _ := newTestLockers(t)
+	a.LeaseDuration = 30 * time.Millisecond
+
+	require.NoError(t, a.Lock(ctx, false))
+	acquired := a.State.HeartbeatTime
+
+	assert.Eventually(t, func() bool {
+		state, err := a.GetActiveLockState(ctx)
+		return err == nil && state.HeartbeatTime.After(acquired)
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, a.Unlock(ctx))
+	_, err := a.GetActiveLockState(ctx)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestRelease(t *testing.T) {
+	ctx := t.Context()
+	a, b, _ := newTestLockers(t)
+
+	require.NoError(t, a.Lock(ctx, false))
+	require.NoError(t, b.Release(ctx))
+	require.NoError(t, b.Lock(ctx, false))
+	require.NoError(t, b.Unlock(ctx))
+}
@@ -12,10 +12,14 @@ type diff struct { //nolint:recvcheck // value receivers for read-only methods,
 	rmdir  []string
 	mkdir  []string
 	put    []string
+
+	// pull lists the local names of files downloaded from the remote path.
+	// It is only reported in events; [Sync.applyDiff] does not act on it.
+	pull []string
 }
 
 func (d diff) IsEmpty() bool {
-	return len(d.put) == 0 && len(d.delete) == 0
+	return len(d.put) == 0 && len(d.delete) == 0 && len(d.pull) == 0
 }
 
 // Compute operations required to make files in WSFS reflect current local files.
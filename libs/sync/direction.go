@@ -0,0 +1,84 @@
+package sync
+
+import (
+	"fmt"
+)
+
+// Direction controls which way files are synchronized.
+type Direction string
+
+const (
+	// DirectionPush uploads local changes to the workspace. This is the default.
+	DirectionPush = Direction("push")
+
+	// DirectionPull downloads remote changes to the local file system.
+	DirectionPull = Direction("pull")
+
+	// DirectionBoth uploads local changes and downloads remote changes.
+	DirectionBoth = Direction("both")
+)
+
+func (d *Direction) String() string {
+	return string(*d)
+}
+
+func (d *Direction) Set(s string) error {
+	switch Direction(s) {
+	case DirectionPush, DirectionPull, DirectionBoth:
+		*d = Direction(s)
+	default:
+		return fmt.Errorf("accepted arguments are %s, %s, and %s", DirectionPush, DirectionPull, DirectionBoth)
+	}
+	return nil
+}
+
+func (d *Direction) Type() string {
+	return "direction"
+}
+
+// pull returns true if remote changes are downloaded.
+func (d Direction) pull() bool {
+	return d == DirectionPull || d == DirectionBoth
+}
+
+// push returns true if local changes are uploaded.
+func (d Direction) push() bool {
+	return d == "" || d == DirectionPush || d == DirectionBoth
+}
+
+// ConflictPolicy controls how a file that changed both locally
+// and remotely since the last synchronization is resolved.
+type ConflictPolicy string
+
+const (
+	// ConflictKeepLocal keeps the local version and overwrites the remote version.
+	ConflictKeepLocal = ConflictPolicy("keep-local")
+
+	// ConflictKeepRemote keeps the remote version and overwrites the local version.
+	ConflictKeepRemote = ConflictPolicy("keep-remote")
+
+	// ConflictCopy keeps the local version and writes the remote
+	// version next to it, with the [conflictSuffix] appended to its name.
+	ConflictCopy = ConflictPolicy("copy")
+)
+
+// conflictSuffix is appended to the name of the local copy of a conflicting remote file.
+const conflictSuffix = ".conflict"
+
+func (p *ConflictPolicy) String() string {
+	return string(*p)
+}
+
+func (p *ConflictPolicy) Set(s string) error {
+	switch ConflictPolicy(s) {
+	case ConflictKeepLocal, ConflictKeepRemote, ConflictCopy:
+		*p = ConflictPolicy(s)
+	default:
+		return fmt.Errorf("accepted arguments are %s, %s, and %s", ConflictKeepLocal, ConflictKeepRemote, ConflictCopy)
+	}
+	return nil
+}
+
+func (p *ConflictPolicy) Type() string {
+	return "policy"
+}
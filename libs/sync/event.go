@@ -14,6 +14,7 @@ const (
 	EventTypeStart    = EventType("start")
 	EventTypeProgress = EventType("progress")
 	EventTypeComplete = EventType("complete")
+	EventTypeConflict = EventType("conflict")
 )
 
 type EventAction string
@@ -21,6 +22,7 @@ type EventAction string
 const (
 	EventActionPut    = EventAction("put")
 	EventActionDelete = EventAction("delete")
+	EventActionPull   = EventAction("pull")
 )
 
 type Event interface {
@@ -46,15 +48,22 @@ func newEventBase(seq int, typ EventType, dryRun bool) *EventBase {
 type EventChanges struct {
 	Put    []string `json:"put,omitempty"`
 	Delete []string `json:"delete,omitempty"`
+
+	// Pull lists the local names of files downloaded from the remote path.
+	Pull []string `json:"pull,omitempty"`
 }
 
-// creates EventChanges with filenames in Put and Delete sorted alphabetically
-func newEventChanges(put, delete []string) *EventChanges {
-	return &EventChanges{Put: slices.Sorted(slices.Values(put)), Delete: slices.Sorted(slices.Values(delete))}
+// creates EventChanges with filenames in Put, Delete and Pull sorted alphabetically
+func newEventChanges(put, delete, pull []string) *EventChanges {
+	return &EventChanges{
+		Put:    slices.Sorted(slices.Values(put)),
+		Delete: slices.Sorted(slices.Values(delete)),
+		Pull:   slices.Sorted(slices.Values(pull)),
+	}
 }
 
 func (e *EventChanges) IsEmpty() bool {
-	return len(e.Put) == 0 && len(e.Delete) == 0
+	return len(e.Put) == 0 && len(e.Delete) == 0 && len(e.Pull) == 0
 }
 
 func (e *EventChanges) String() string {
@@ -65,6 +74,9 @@ func (e *EventChanges) String() string {
 	if len(e.Delete) > 0 {
 		changes = append(changes, "DELETE: "+strings.Join(e.Delete, ", "))
 	}
+	if len(e.Pull) > 0 {
+		changes = append(changes, "PULL: "+strings.Join(e.Pull, ", "))
+	}
 	return strings.Join(changes, ", ")
 }
 
@@ -81,10 +93,10 @@ func (e *EventStart) String() string {
 	return "Action: " + e.EventChanges.String()
 }
 
-func newEventStart(seq int, put, delete []string, dryRun bool) Event {
+func newEventStart(seq int, put, delete, pull []string, dryRun bool) Event {
 	return &EventStart{
 		EventBase:    newEventBase(seq, EventTypeStart, dryRun),
-		EventChanges: newEventChanges(put, delete),
+		EventChanges: newEventChanges(put, delete, pull),
 	}
 }
 
@@ -109,6 +121,8 @@ func (e *EventSyncProgress) String() string {
 		return "Uploaded " + e.Path
 	case EventActionDelete:
 		return "Deleted " + e.Path
+	case EventActionPull:
+		return "Downloaded " + e.Path
 	default:
 		panic("invalid action")
 	}
@@ -141,10 +155,41 @@ func (e *EventSyncComplete) String() string {
 	return "Complete"
 }
 
-func newEventComplete(seq int, put, delete []string, dryRun bool) Event {
+func newEventComplete(seq int, put, delete, pull []string, dryRun bool) Event {
 	return &EventSyncComplete{
 		EventBase:    newEventBase(seq, EventTypeComplete, dryRun),
-		EventChanges: newEventChanges(put, delete),
+		EventChanges: newEventChanges(put, delete, pull),
+	}
+}
+
+// EventConflict is emitted if a file was changed both locally and remotely
+// since the last synchronization.
+type EventConflict struct {
+	*EventBase
+
+	Path       string         `json:"path"`
+	Resolution ConflictPolicy `json:"resolution"`
+}
+
+func (e *EventConflict) String() string {
+	switch e.Resolution {
+	case ConflictKeepLocal:
+		return fmt.Sprintf("Conflict: %s changed locally and remotely; keeping local version", e.Path)
+	case ConflictKeepRemote:
+		return fmt.Sprintf("Conflict: %s changed locally and remotely; keeping remote version", e.Path)
+	case ConflictCopy:
+		return fmt.Sprintf("Conflict: %s changed locally and remotely; remote version written to %s%s", e.Path, e.Path, conflictSuffix)
+	default:
+		panic("invalid resolution")
+	}
+}
+
+func newEventConflict(seq int, path string, resolution ConflictPolicy, dryRun bool) Event {
+	return &EventConflict{
+		EventBase: newEventBase(seq, EventTypeConflict, dryRun),
+
+		Path:       path,
+		Resolution: resolution,
 	}
 }
 
@@ -102,7 +102,7 @@ func TestEventStart(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			e := newEventStart(tt.seq, tt.put, tt.delete, tt.dryRun)
+			e := newEventStart(tt.seq, tt.put, tt.delete, nil, tt.dryRun)
 			assert.Equal(t, tt.expected, e.String())
 		})
 	}
@@ -185,7 +185,7 @@ func TestEventStartJSON(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			e := newEventStart(tt.seq, tt.put, tt.delete, tt.dryRun)
+			e := newEventStart(tt.seq, tt.put, tt.delete, nil, tt.dryRun)
 			jsonEqual(t, tt.expected, e)
 		})
 	}
@@ -488,7 +488,7 @@ func TestEventComplete(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			e := newEventComplete(tt.seq, tt.put, tt.delete, tt.dryRun)
+			e := newEventComplete(tt.seq, tt.put, tt.delete, nil, tt.dryRun)
 			assert.Equal(t, tt.expected, e.String())
 		})
 	}
@@ -571,8 +571,14 @@ func TestEventCompleteJSON(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			e := newEventComplete(tt.seq, tt.put, tt.delete, tt.dryRun)
+			e := newEventComplete(tt.seq, tt.put, tt.delete, nil, tt.dryRun)
 			jsonEqual(t, tt.expected, e)
 		})
 	}
 }
+
+func TestEventConflict(t *testing.T) {
+	e := newEventConflict(1, "a.py", ConflictCopy, false)
+	assert.Equal(t, "Conflict: a.py changed locally and remotely; remote version written to a.py.conflict", e.String())
+	jsonEqual(t, `{"seq": 1, "type": "conflict", "path": "a.py", "resolution": "copy"}`, e)
+}
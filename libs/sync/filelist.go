@@ -17,6 +17,10 @@ type FileList struct {
 	fileSet        *git.FileSet
 	includeFileSet *fileset.FileSet
 	excludeFileSet *fileset.FileSet
+
+	// hasIncludes is set if include patterns can select files
+	// from directories that are ignored by git.
+	hasIncludes bool
 }
 
 // NewFileList builds a FileList for the directory tree at root, located within
@@ -43,6 +47,7 @@ func NewFileList(ctx context.Context, worktreeRoot, root vfs.Path, paths, includ
 		fileSet:        fileSet,
 		includeFileSet: includeFileSet,
 		excludeFileSet: excludeFileSet,
+		hasIncludes:    len(include) > 0,
 	}, nil
 }
 
@@ -81,3 +86,68 @@ func (l *FileList) Files(ctx context.Context) ([]fileset.File, error) {
 
 	return all.Iter(), nil
 }
+
+// Match returns the file at the specified path if it is selected for sync.
+// It applies the same rules as [FileList.Files] to a single path, without
+// listing the full tree, so that callers that learn about individual changes
+// (e.g. from a file system watcher) do not have to rescan everything.
+func (l *FileList) Match(ctx context.Context, name string) (fileset.File, bool, error) {
+	f, ok, err := l.fileSet.Match(name)
+	if err != nil {
+		return fileset.File{}, false, err
+	}
+
+	if !ok {
+		f, ok, err = l.includeFileSet.Match(name)
+		if err != nil {
+			return fileset.File{}, false, err
+		}
+		if !ok {
+			return fileset.File{}, false, nil
+		}
+	}
+
+	_, excluded, err := l.excludeFileSet.Match(name)
+	if err != nil {
+		return fileset.File{}, false, err
+	}
+	if excluded {
+		return fileset.File{}, false, nil
+	}
+
+	return f, true, nil
+}
+
+// Selects returns true if a file at the specified path would be selected for
+// sync. Unlike [FileList.Match], the file does not have to exist locally, so
+// it can be used to decide whether to download a remote file.
+func (l *FileList) Selects(ctx context.Context, name string) (bool, error) {
+	ok, err := l.fileSet.Selects(name)
+	if err != nil {
+		return false, err
+	}
+
+	if !ok {
+		ok, err = l.includeFileSet.Selects(name)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	excluded, err := l.excludeFileSet.Selects(name)
+	if err != nil {
+		return false, err
+	}
+	return !excluded, nil
+}
+
+// SkipDirectory returns true if no file in the specified directory
+// can be selected for sync. Include patterns may select files from
+// directories that are ignored by git, so they are never skipped
+// if include patterns are configured.
+func (l *FileList) SkipDirectory(dir string) (bool, error) {
+	if l.hasIncludes {
+		return false, nil
+	}
+	return l.fileSet.IgnoreDirectory(dir)
+}
@@ -135,3 +135,32 @@ func TestFileListEmptyPathsIsNop(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, files)
 }
+
+func TestFileListMatch(t *testing.T) {
+	ctx := t.Context()
+
+	dir := setupFiles(t)
+	root := vfs.MustNew(dir)
+
+	l, err := NewFileList(ctx, root, root, []string{"."}, []string{"./.databricks/*.go"}, []string{"test/**"})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		expected bool
+	}{
+		{"a.go", true},
+		{".databricks/e.go", true},
+		{"test/sub1/f.go", false},
+		{"missing.go", false},
+	} {
+		_, ok, err := l.Match(ctx, tc.name)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, ok, tc.name)
+	}
+
+	// Directories are never skipped if include patterns are configured.
+	skip, err := l.SkipDirectory(".databricks")
+	require.NoError(t, err)
+	require.False(t, skip)
+}
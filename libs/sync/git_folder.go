@@ -0,0 +1,89 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/cli/libs/git"
+	"github.com/databricks/cli/libs/log"
+)
+
+// BranchCheck controls what happens if the remote path is located in a
+// workspace Git folder that has a different branch checked out than the local
+// repository.
+type BranchCheck string
+
+const (
+	// BranchCheckOff skips looking up the remote Git folder. This is the default,
+	// as the lookup costs an additional API call on every synchronization.
+	BranchCheckOff = BranchCheck("off")
+
+	// BranchCheckWarn logs a warning if the branches differ.
+	BranchCheckWarn = BranchCheck("warn")
+
+	// BranchCheckError refuses to synchronize if the branches differ.
+	BranchCheckError = BranchCheck("error")
+)
+
+func (c *BranchCheck) String() string {
+	return string(*c)
+}
+
+func (c *BranchCheck) Set(s string) error {
+	switch BranchCheck(s) {
+	case BranchCheckOff, BranchCheckWarn, BranchCheckError:
+		*c = BranchCheck(s)
+	default:
+		return fmt.Errorf("accepted arguments are %s, %s, and %s", BranchCheckOff, BranchCheckWarn, BranchCheckError)
+	}
+	return nil
+}
+
+func (c *BranchCheck) Type() string {
+	return "mode"
+}
+
+// openGitFolder returns a filer for the remote path if it is located in a
+// workspace Git folder, and nil otherwise or if the branch check is off.
+//
+// It compares the branch checked out in the Git folder with the branch of the
+// local repository. Synchronizing into a Git folder overwrites the files of its
+// checked out branch, so doing this from a different local branch is almost
+// certainly a mistake.
+func openGitFolder(ctx context.Context, opts *SyncOptions) (*git.GitFolderClient, error) {
+	if opts.BranchCheck == "" || opts.BranchCheck == BranchCheckOff {
+		return nil, nil
+	}
+
+	f, err := git.NewGitFolderClient(opts.WorkspaceClient, opts.RemotePath)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := f.Info(ctx)
+	if err != nil {
+		// The remote path may not exist yet (e.g. on a dry run); it cannot be in a Git folder then.
+		log.Debugf(ctx, "Unable to fetch Git folder information for %s: %s", opts.RemotePath, err)
+		return nil, nil
+	}
+	if remote == nil {
+		return nil, nil
+	}
+
+	log.Infof(ctx, "Remote path %s is in Git folder %s at branch %q (commit %s)", opts.RemotePath, remote.Path, remote.Branch, remote.Commit)
+
+	local, err := git.FetchRepositoryInfo(ctx, opts.LocalRoot.Native(), opts.WorkspaceClient)
+	if err != nil {
+		return nil, err
+	}
+	if local.CurrentBranch == "" || remote.Branch == "" || local.CurrentBranch == remote.Branch {
+		return f, nil
+	}
+
+	err = fmt.Errorf("local branch %q does not match branch %q checked out in Git folder %s", local.CurrentBranch, remote.Branch, remote.Path)
+	if opts.BranchCheck == BranchCheckError {
+		return nil, err
+	}
+	log.Warnf(ctx, "%s", err)
+	return f, nil
+}
@@ -0,0 +1,75 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/libs/testserver"
+	"github.com/databricks/cli/libs/vfs"
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenGitFolder(t *testing.T) {
+	server := testserver.New(t)
+	testserver.AddDefaultHandlers(server)
+
+	w, err := databricks.NewWorkspaceClient(&databricks.Config{
+		Host:  server.URL,
+		Token: "testtoken",
+	})
+	require.NoError(t, err)
+
+	// The fake Git folder has branch "main" checked out.
+	_, err = w.Repos.Create(t.Context(), workspace.CreateRepoRequest{
+		Path:     "/Repos/someone@example.com/repo",
+		Provider: "gitHub",
+		Url:      "https://github.com/databricks/cli.git",
+	})
+	require.NoError(t, err)
+
+	localRepo := func(branch string) vfs.Path {
+		dir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref: refs/heads/"+branch+"\n"), 0o644))
+		return vfs.MustNew(dir)
+	}
+
+	opts := &SyncOptions{
+		LocalRoot:       localRepo("feature"),
+		RemotePath:      "/Repos/someone@example.com/repo",
+		WorkspaceClient: w,
+	}
+
+	// The Git folder is not looked up by default.
+	f, err := openGitFolder(t.Context(), opts)
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	opts.BranchCheck = BranchCheckWarn
+	f, err = openGitFolder(t.Context(), opts)
+	require.NoError(t, err)
+	require.NotNil(t, f)
+	info, err := f.Info(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "main", info.Branch)
+
+	opts.BranchCheck = BranchCheckError
+	_, err = openGitFolder(t.Context(), opts)
+	assert.ErrorContains(t, err, `local branch "feature" does not match branch "main" checked out in Git folder /Workspace/Repos/someone@example.com/repo`)
+
+	opts.LocalRoot = localRepo("main")
+	f, err = openGitFolder(t.Context(), opts)
+	require.NoError(t, err)
+	assert.NotNil(t, f)
+
+	// A path that is not in a Git folder is not checked.
+	opts.LocalRoot = localRepo("feature")
+	opts.RemotePath = "/Users/someone@example.com/project"
+	f, err = openGitFolder(t.Context(), opts)
+	require.NoError(t, err)
+	assert.Nil(t, f)
+}
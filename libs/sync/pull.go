@@ -0,0 +1,422 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/databricks/cli/libs/fileset"
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/cli/libs/notebook"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+)
+
+// remoteFile describes a file in the remote path.
+type remoteFile struct {
+	// Name of the file, relative to the remote path.
+	name string
+
+	// Last modified time as recorded by the workspace.
+	modTime time.Time
+
+	// Workspace object info, if available.
+	objectInfo *workspace.ObjectInfo
+}
+
+// localName returns the name a remote file that is not yet tracked gets locally.
+// Notebooks are stored without an extension in the workspace, so the extension
+// is derived from the notebook language.
+func (f remoteFile) localName() string {
+	if f.objectInfo == nil {
+		return f.name
+	}
+	return f.name + notebook.GetExtensionByLanguage(f.objectInfo)
+}
+
+// listRemote recursively lists all files in the remote path.
+func (s *Sync) listRemote(ctx context.Context) (map[string]remoteFile, error) {
+	out := make(map[string]remoteFile)
+	queue := []string{"."}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+
+		entries, err := s.filer.ReadDir(ctx, dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			// Nothing has been synchronized yet.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			name := path.Join(dir, entry.Name())
+			if entry.IsDir() {
+				queue = append(queue, name)
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+
+			f := remoteFile{
+				name:    name,
+				modTime: info.ModTime(),
+			}
+			if oi, ok := info.Sys().(workspace.ObjectInfo); ok && oi.ObjectType == workspace.ObjectTypeNotebook {
+				f.objectInfo = &oi
+			}
+			out[name] = f
+		}
+	}
+	return out, nil
+}
+
+// pullAction is a remote file to download.
+type pullAction struct {
+	// Name of the file, relative to the remote path.
+	remoteName string
+
+	// Local name of the file.
+	localName string
+
+	// Local path to write to. It differs from localName for conflict copies.
+	dest string
+}
+
+// isConflictCopy returns true if the local file is a copy of a conflicting remote
+// file. Conflict copies are named after the file they conflict with, so a file
+// only counts as one if that file exists too.
+func isConflictCopy(f fileset.File, names map[string]bool) bool {
+	original, ok := strings.CutSuffix(f.Relative, conflictSuffix)
+	return ok && names[original]
+}
+
+// sameContent returns true if the local file and the remote file have the same content.
+func (s *Sync) sameContent(ctx context.Context, localName, remoteName string) (bool, error) {
+	local, err := os.ReadFile(filepath.Join(s.LocalRoot.Native(), filepath.FromSlash(localName)))
+	if err != nil {
+		return false, err
+	}
+
+	r, err := s.filer.Read(ctx, remoteName)
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+	remote, err := io.ReadAll(r)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(local, remote), nil
+}
+
+// runPull synchronizes remote changes to the local file system and, if the
+// direction is [DirectionBoth], local changes to the remote path.
+//
+// Remote changes are detected by comparing the remote modified times to the ones
+// recorded in the snapshot. Files that changed on both sides since the last
+// synchronization are conflicts and are resolved according to [SyncOptions.OnConflict].
+// Remote files that are not selected for sync locally are not downloaded, and
+// conflict copies are never uploaded.
+func (s *Sync) runPull(ctx context.Context, files []fileset.File) error {
+	remote, err := s.listRemote(ctx)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]bool, len(files))
+	for _, f := range files {
+		names[f.Relative] = true
+	}
+	files = slices.DeleteFunc(slices.Clone(files), func(f fileset.File) bool {
+		return isConflictCopy(f, names)
+	})
+
+	// The baseline is the state as of the last synchronization. Pulled files are
+	// recorded in both the baseline and the target state so they are not pushed back.
+	baseline := s.snapshot.SnapshotState.clone()
+	change, err := s.snapshot.diff(ctx, files)
+	if err != nil {
+		return err
+	}
+	target := s.snapshot.SnapshotState
+
+	localChanged := make(map[string]bool)
+	for _, localName := range change.put {
+		localChanged[localName] = true
+	}
+	for localName := range baseline.LocalToRemoteNames {
+		if _, ok := target.LocalToRemoteNames[localName]; !ok {
+			localChanged[localName] = true
+		}
+	}
+
+	// Determine the local name of a remote file.
+	localNameOf := func(f remoteFile) string {
+		if localName, ok := baseline.RemoteToLocalNames[f.name]; ok {
+			return localName
+		}
+		if localName, ok := target.RemoteToLocalNames[f.name]; ok {
+			return localName
+		}
+		return f.localName()
+	}
+
+	// Determine the remote files to download.
+	var pulls []pullAction
+	for _, name := range slices.Sorted(maps.Keys(remote)) {
+		f := remote[name]
+		prevModTime, tracked := baseline.RemoteModifiedTimes[name]
+		if tracked && prevModTime.Equal(f.modTime) {
+			continue
+		}
+
+		localName := localNameOf(f)
+		if strings.HasSuffix(localName, notebook.ExtensionJupyter) {
+			log.Warnf(ctx, "Not pulling remote changes to Jupyter notebook %s", localName)
+			continue
+		}
+
+		selected, err := s.fileList.Selects(ctx, localName)
+		if err != nil {
+			return err
+		}
+		if !selected {
+			log.Debugf(ctx, "Not pulling %s because it is not selected for sync locally", localName)
+			continue
+		}
+
+		dest := localName
+		if localChanged[localName] {
+			// A file with the same content on both sides is in sync, e.g. on
+			// the first run, when no file has been synchronized yet.
+			if _, exists := target.LocalToRemoteNames[localName]; exists {
+				same, err := s.sameContent(ctx, localName, name)
+				if err != nil {
+					return err
+				}
+				if same {
+					err = trackPulledFile(ctx, s.fileList, localName, baseline, target)
+					if err != nil {
+						return err
+					}
+					continue
+				}
+			}
+
+			policy := s.OnConflict
+			if policy == "" {
+				policy = ConflictKeepLocal
+			}
+			s.notifier.Notify(ctx, newEventConflict(s.seq, localName, policy, s.DryRun))
+			switch policy {
+			case ConflictKeepLocal:
+				continue
+			case ConflictCopy:
+				dest = localName + conflictSuffix
+			case ConflictKeepRemote:
+				// Overwrite the local file.
+			}
+		}
+
+		pulls = append(pulls, pullAction{remoteName: name, localName: localName, dest: dest})
+	}
+
+	// Determine the local files that were removed remotely, unless they changed locally.
+	var removals []string
+	for remoteName := range baseline.RemoteModifiedTimes {
+		if _, ok := remote[remoteName]; ok {
+			continue
+		}
+		localName, ok := baseline.RemoteToLocalNames[remoteName]
+		if !ok || localChanged[localName] {
+			continue
+		}
+		removals = append(removals, localName)
+	}
+
+	// Report the pulled files and the local changes to push before pulling.
+	// Local changes are not pushed when only pulling, and files that are
+	// overwritten or removed by the pull are not pushed.
+	events := diff{}
+	for _, p := range pulls {
+		events.pull = append(events.pull, p.dest)
+	}
+	if s.Direction.push() {
+		overwritten := make(map[string]bool)
+		for _, p := range pulls {
+			if p.dest == p.localName {
+				overwritten[p.localName] = true
+			}
+		}
+		for _, localName := range removals {
+			overwritten[localName] = true
+		}
+		pending := computeDiff(target, baseline)
+		events.put = slices.DeleteFunc(pending.put, func(localName string) bool {
+			return overwritten[localName]
+		})
+		events.delete = slices.DeleteFunc(pending.delete, func(remoteName string) bool {
+			return overwritten[baseline.RemoteToLocalNames[remoteName]]
+		})
+	}
+
+	s.fileCounts = FileCounts{Uploaded: len(events.put), Deleted: len(events.delete), Downloaded: len(pulls)}
+	s.notifyStart(ctx, events)
+
+	for _, p := range pulls {
+		err = s.pullFile(ctx, p.remoteName, p.dest)
+		if err != nil {
+			return err
+		}
+
+		// A conflict copy is never tracked, so it is not uploaded.
+		if p.dest != p.localName || s.DryRun {
+			continue
+		}
+
+		err = trackPulledFile(ctx, s.fileList, p.localName, baseline, target)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, localName := range removals {
+		err = s.removeLocalFile(ctx, localName)
+		if err != nil {
+			return err
+		}
+		baseline.removeFile(localName)
+		target.removeFile(localName)
+	}
+
+	// Keeping the baseline when only pulling means local changes are
+	// detected (and pushed) the next time changes are pushed.
+	final := baseline
+	var push diff
+	if s.Direction.push() {
+		final = target
+		push = computeDiff(target, baseline)
+	}
+	s.snapshot.SnapshotState = final
+
+	if !push.IsEmpty() {
+		err = s.applyDiff(ctx, push)
+		if err != nil {
+			return err
+		}
+
+		// Record the remote modified times of the files we just wrote.
+		if !s.DryRun {
+			remote, err = s.listRemote(ctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	final.RemoteModifiedTimes = make(map[string]time.Time)
+	for remoteName := range final.RemoteToLocalNames {
+		if f, ok := remote[remoteName]; ok {
+			final.RemoteModifiedTimes[remoteName] = f.modTime
+		}
+	}
+
+	if !s.DryRun {
+		err = s.snapshot.Save(ctx)
+		if err != nil {
+			log.Errorf(ctx, "cannot store snapshot: %s", err)
+			return err
+		}
+	}
+
+	s.notifyComplete(ctx, events)
+	return nil
+}
+
+// trackPulledFile records the pulled file in both the baseline and the target state.
+// Files that are not selected for sync locally (e.g. because they are gitignored)
+// are not tracked.
+func trackPulledFile(ctx context.Context, fileList *FileList, localName string, states ...*SnapshotState) error {
+	f, ok, err := fileList.Match(ctx, localName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		log.Warnf(ctx, "Pulled %s but it is not selected for sync locally", localName)
+	}
+
+	for _, state := range states {
+		state.removeFile(localName)
+		if !ok {
+			continue
+		}
+		err = state.addFile(&f)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pullFile downloads the remote file to the specified local path.
+func (s *Sync) pullFile(ctx context.Context, remoteName, localName string) error {
+	s.notifyProgress(ctx, EventActionPull, localName, 0.0)
+
+	if !s.DryRun {
+		r, err := s.filer.Read(ctx, remoteName)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		localPath := filepath.Join(s.LocalRoot.Native(), filepath.FromSlash(localName))
+		err = os.MkdirAll(filepath.Dir(localPath), 0o755)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(localPath)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(f, r)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	s.notifyProgress(ctx, EventActionPull, localName, 1.0)
+	return nil
+}
+
+// removeLocalFile removes a local file that was removed remotely.
+func (s *Sync) removeLocalFile(ctx context.Context, localName string) error {
+	s.notifyProgress(ctx, EventActionDelete, localName, 0.0)
+
+	if !s.DryRun {
+		err := os.Remove(filepath.Join(s.LocalRoot.Native(), filepath.FromSlash(localName)))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	s.notifyProgress(ctx, EventActionDelete, localName, 1.0)
+	return nil
+}
@@ -0,0 +1,239 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/databricks/cli/libs/filer"
+	"github.com/databricks/cli/libs/vfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPullSync(t *testing.T, direction Direction, policy ConflictPolicy) (*Sync, string, string) {
+	ctx := t.Context()
+	localDir := t.TempDir()
+	remoteDir := t.TempDir()
+
+	root := vfs.MustNew(localDir)
+	fileList, err := NewFileList(ctx, root, root, []string{"."}, nil, nil)
+	require.NoError(t, err)
+
+	remote, err := filer.NewLocalClient(remoteDir)
+	require.NoError(t, err)
+
+	snapshot, err := newSnapshot(ctx, &SyncOptions{SnapshotBasePath: t.TempDir()})
+	require.NoError(t, err)
+
+	s := &Sync{
+		SyncOptions: &SyncOptions{
+			LocalRoot:  root,
+			Direction:  direction,
+			OnConflict: policy,
+		},
+		fileList: fileList,
+		snapshot: snapshot,
+		filer:    remote,
+		notifier: &NopNotifier{},
+	}
+	return s, localDir, remoteDir
+}
+
+// writeFile writes a file and moves its mtime forward so that changes
+// are detected regardless of the file system's timestamp resolution.
+func writeFile(t *testing.T, name, content string, offset time.Duration) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+	require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+	ts := time.Now().Add(offset)
+	require.NoError(t, os.Chtimes(name, ts, ts))
+}
+
+func readFile(t *testing.T, name string) string {
+	b, err := os.ReadFile(name)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestRunPullBoth(t *testing.T) {
+	ctx := t.Context()
+	s, localDir, remoteDir := newTestPullSync(t, DirectionBoth, ConflictCopy)
+
+	writeFile(t, filepath.Join(localDir, "a.txt"), "local a", 0)
+	writeFile(t, filepath.Join(remoteDir, "dir", "b.txt"), "remote b", 0)
+
+	_, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, FileCounts{Uploaded: 1, Downloaded: 1}, s.FileCounts())
+	assert.Equal(t, "local a", readFile(t, filepath.Join(remoteDir, "a.txt")))
+	assert.Equal(t, "remote b", readFile(t, filepath.Join(localDir, "dir", "b.txt")))
+	assertKeysOfMap(t, s.snapshot.RemoteModifiedTimes, []string{"a.txt", "dir/b.txt"})
+
+	// Nothing changed.
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, FileCounts{}, s.FileCounts())
+
+	// Remote change is pulled.
+	writeFile(t, filepath.Join(remoteDir, "dir", "b.txt"), "remote b v2", time.Minute)
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, FileCounts{Downloaded: 1}, s.FileCounts())
+	assert.Equal(t, "remote b v2", readFile(t, filepath.Join(localDir, "dir", "b.txt")))
+
+	// Conflicting change is written to a copy.
+	writeFile(t, filepath.Join(localDir, "a.txt"), "local a v2", 2*time.Minute)
+	writeFile(t, filepath.Join(remoteDir, "a.txt"), "remote a v2", 2*time.Minute)
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "local a v2", readFile(t, filepath.Join(remoteDir, "a.txt")))
+	assert.Equal(t, "remote a v2", readFile(t, filepath.Join(localDir, "a.txt.conflict")))
+
+	// The conflict copy is not pushed.
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, FileCounts{}, s.FileCounts())
+	assert.NoFileExists(t, filepath.Join(remoteDir, "a.txt.conflict"))
+
+	// Remote removal is pulled.
+	require.NoError(t, os.Remove(filepath.Join(remoteDir, "dir", "b.txt")))
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(localDir, "dir", "b.txt"))
+	assert.NotContains(t, s.snapshot.LastModifiedTimes, "dir/b.txt")
+}
+
+func TestRunPullKeepRemote(t *testing.T) {
+	ctx := t.Context()
+	s, localDir, remoteDir := newTestPullSync(t, DirectionBoth, ConflictKeepRemote)
+
+	writeFile(t, filepath.Join(localDir, "a.txt"), "local a", 0)
+	_, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+
+	writeFile(t, filepath.Join(localDir, "a.txt"), "local a v2", time.Minute)
+	writeFile(t, filepath.Join(remoteDir, "a.txt"), "remote a v2", time.Minute)
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, FileCounts{Downloaded: 1}, s.FileCounts())
+	assert.Equal(t, "remote a v2", readFile(t, filepath.Join(localDir, "a.txt")))
+	assert.Equal(t, "remote a v2", readFile(t, filepath.Join(remoteDir, "a.txt")))
+}
+
+func TestRunPullSkipsUnselectedFiles(t *testing.T) {
+	ctx := t.Context()
+	s, localDir, remoteDir := newTestPullSync(t, DirectionBoth, "")
+
+	writeFile(t, filepath.Join(localDir, ".gitignore"), "ignored.txt\n", 0)
+	writeFile(t, filepath.Join(remoteDir, "ignored.txt"), "remote", 0)
+
+	_, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(localDir, "ignored.txt"))
+
+	// The file is not downloaded on every run.
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, FileCounts{}, s.FileCounts())
+	assert.NoFileExists(t, filepath.Join(localDir, "ignored.txt"))
+}
+
+type recordingNotifier struct {
+	events []Event
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, e Event) {
+	n.events = append(n.events, e)
+}
+
+func (n *recordingNotifier) Close() {}
+
+func TestRunPullEvents(t *testing.T) {
+	ctx := t.Context()
+	s, localDir, remoteDir := newTestPullSync(t, DirectionBoth, "")
+	notifier := &recordingNotifier{}
+	s.notifier = notifier
+
+	writeFile(t, filepath.Join(localDir, "a.txt"), "local a", 0)
+	writeFile(t, filepath.Join(remoteDir, "b.txt"), "remote b", 0)
+
+	_, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, notifier.events)
+
+	// The start event comes first and lists the pulled files.
+	start, ok := notifier.events[0].(*EventStart)
+	require.True(t, ok)
+	assert.Equal(t, []string{"a.txt"}, start.Put)
+	assert.Equal(t, []string{"b.txt"}, start.Pull)
+
+	complete, ok := notifier.events[len(notifier.events)-1].(*EventSyncComplete)
+	require.True(t, ok)
+	assert.Equal(t, []string{"a.txt"}, complete.Put)
+	assert.Equal(t, []string{"b.txt"}, complete.Pull)
+}
+
+func TestRunPullOnlyDoesNotPush(t *testing.T) {
+	ctx := t.Context()
+	s, localDir, remoteDir := newTestPullSync(t, DirectionPull, "")
+
+	writeFile(t, filepath.Join(localDir, "a.txt"), "local a", 0)
+	writeFile(t, filepath.Join(remoteDir, "b.txt"), "remote b", 0)
+
+	_, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, FileCounts{Downloaded: 1}, s.FileCounts())
+	assert.NoFileExists(t, filepath.Join(remoteDir, "a.txt"))
+	assert.Equal(t, "remote b", readFile(t, filepath.Join(localDir, "b.txt")))
+
+	// The local file is still pushed once changes are pushed.
+	s.Direction = DirectionPush
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, FileCounts{Uploaded: 1}, s.FileCounts())
+}
+
+func TestRunPullFirstRunWithSameContent(t *testing.T) {
+	ctx := t.Context()
+	s, localDir, remoteDir := newTestPullSync(t, DirectionBoth, ConflictCopy)
+	notifier := &recordingNotifier{}
+	s.notifier = notifier
+
+	writeFile(t, filepath.Join(localDir, "a.txt"), "same", 0)
+	writeFile(t, filepath.Join(remoteDir, "a.txt"), "same", 0)
+	writeFile(t, filepath.Join(localDir, "b.txt"), "local b", 0)
+	writeFile(t, filepath.Join(remoteDir, "b.txt"), "remote b", 0)
+
+	_, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+
+	// Only the file with different content is a conflict.
+	var conflicts []string
+	for _, e := range notifier.events {
+		if c, ok := e.(*EventConflict); ok {
+			conflicts = append(conflicts, c.Path)
+		}
+	}
+	assert.Equal(t, []string{"b.txt"}, conflicts)
+	assert.NoFileExists(t, filepath.Join(localDir, "a.txt.conflict"))
+	assert.Equal(t, "remote b", readFile(t, filepath.Join(localDir, "b.txt.conflict")))
+
+	// Both files are in sync afterwards.
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, FileCounts{}, s.FileCounts())
+}
+
+func TestRunPullPushesFilesWithConflictSuffix(t *testing.T) {
+	ctx := t.Context()
+	s, localDir, remoteDir := newTestPullSync(t, DirectionBoth, "")
+
+	// There is no notes file, so this is not a conflict copy.
+	writeFile(t, filepath.Join(localDir, "notes.conflict"), "user file", 0)
+
+	_, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "user file", readFile(t, filepath.Join(remoteDir, "notes.conflict")))
+}
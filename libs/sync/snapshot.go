@@ -10,6 +10,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/databricks/cli/libs/fileset"
@@ -39,6 +40,10 @@ type Snapshot struct {
 	// New indicates if this is a fresh snapshot or if it was loaded from disk.
 	New bool `json:"-"`
 
+	// checksum indicates if file contents are compared to detect changes,
+	// in addition to the last modified times. See [SyncOptions.Checksum].
+	checksum bool
+
 	// version for snapshot schema. Only snapshots matching the latest snapshot
 	// schema version are used and older ones are invalidated (by deleting them)
 	Version string `json:"version"`
@@ -71,6 +76,7 @@ func NewSnapshot(localFiles []fileset.File, opts *SyncOptions) (*Snapshot, error
 	return &Snapshot{
 		snapshotPath:  snapshotPath,
 		New:           true,
+		checksum:      opts.Checksum,
 		Version:       LatestSnapshotVersion,
 		Host:          opts.Host,
 		RemotePath:    opts.RemotePath,
@@ -108,6 +114,7 @@ func newSnapshot(ctx context.Context, opts *SyncOptions) (*Snapshot, error) {
 	return &Snapshot{
 		snapshotPath: path,
 		New:          true,
+		checksum:     opts.Checksum,
 
 		Version:    LatestSnapshotVersion,
 		Host:       opts.Host,
@@ -193,11 +200,116 @@ func (s *Snapshot) diff(ctx context.Context, all []fileset.File) (diff, error) {
 		return diff{}, fmt.Errorf("error parsing existing sync state. Please delete your existing sync snapshot file (%s) and retry: %w", s.snapshotPath, err)
 	}
 
+	// Carry over the remote modified times of files that are still tracked.
+	for remoteName, modTime := range currentState.RemoteModifiedTimes {
+		if _, ok := targetState.RemoteToLocalNames[remoteName]; !ok {
+			continue
+		}
+		if targetState.RemoteModifiedTimes == nil {
+			targetState.RemoteModifiedTimes = make(map[string]time.Time)
+		}
+		targetState.RemoteModifiedTimes[remoteName] = modTime
+	}
+
 	// Compute diff to apply to get from current state to new target state.
 	diff := computeDiff(targetState, currentState)
+	err = s.applyChecksums(&diff, targetState, currentState, all)
+	if err != nil {
+		return diff, err
+	}
 
 	// Update state to new value. This is not persisted to the file system before
 	// the diff is applied successfully.
 	s.SnapshotState = targetState
 	return diff, nil
 }
+
+// diffPaths is like [Snapshot.diff] but limits the comparison to the specified
+// local paths. The files argument holds the subset of these paths that are
+// currently selected for sync; all other paths are considered removed.
+func (s *Snapshot) diffPaths(ctx context.Context, paths []string, files []fileset.File) (diff, error) {
+	currentState := s.SnapshotState
+	if err := currentState.validate(); err != nil {
+		return diff{}, fmt.Errorf("error parsing existing sync state. Please delete your existing sync snapshot file (%s) and retry: %w", s.snapshotPath, err)
+	}
+
+	targetState := currentState.clone()
+	for _, path := range paths {
+		targetState.removeFile(path)
+	}
+	for k := range files {
+		err := targetState.addFile(&files[k])
+		if err != nil {
+			return diff{}, fmt.Errorf("error while computing new sync state: %w", err)
+		}
+	}
+
+	// Compute diff to apply to get from current state to new target state.
+	diff := computeDiff(targetState, currentState)
+	err := s.applyChecksums(&diff, targetState, currentState, files)
+	if err != nil {
+		return diff, err
+	}
+
+	// Update state to new value. This is not persisted to the file system before
+	// the diff is applied successfully.
+	s.SnapshotState = targetState
+	return diff, nil
+}
+
+// applyChecksums records the checksums of the specified files in the target state
+// and removes files with unchanged contents from the diff, so that they are not
+// uploaded only because their last modified time changed (e.g. after a git checkout).
+//
+// Checksums are only computed for files that are new or have a newer last modified time,
+// and for files without a recorded checksum. The checksums field is optional, so a snapshot
+// written without checksums (by an older version, or without --checksum) is migrated by
+// computing the checksums of all its files once, on the first sync with checksums enabled.
+// If checksums are disabled, they are dropped from the target state, because they would not
+// be kept up to date.
+func (s *Snapshot) applyChecksums(d *diff, target, current *SnapshotState, files []fileset.File) error {
+	if !s.checksum {
+		target.Checksums = nil
+		return nil
+	}
+
+	if target.Checksums == nil {
+		target.Checksums = make(map[string]string)
+	}
+
+	unchanged := make(map[string]bool)
+	for k := range files {
+		f := &files[k]
+		name := f.Relative
+		modTime, ok := target.LastModifiedTimes[name]
+		if !ok {
+			continue
+		}
+
+		prevModTime, tracked := current.LastModifiedTimes[name]
+		prevChecksum, hasChecksum := current.Checksums[name]
+		if tracked && hasChecksum && !modTime.After(prevModTime) {
+			target.Checksums[name] = prevChecksum
+			continue
+		}
+
+		checksum, err := f.Checksum()
+		if errors.Is(err, fs.ErrNotExist) {
+			// The file was removed after it was listed.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to compute checksum of %s: %w", name, err)
+		}
+
+		target.Checksums[name] = checksum
+		if tracked && hasChecksum && checksum == prevChecksum && target.LocalToRemoteNames[name] == current.LocalToRemoteNames[name] {
+			unchanged[name] = true
+		}
+	}
+
+	d.put = slices.DeleteFunc(d.put, func(name string) bool {
+		return unchanged[name]
+	})
+	return nil
+}
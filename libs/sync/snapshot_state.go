@@ -2,6 +2,7 @@ package sync
 
 import (
 	"fmt"
+	"maps"
 	"path/filepath"
 	"time"
 
@@ -24,6 +25,16 @@ type SnapshotState struct { //nolint:recvcheck // value receiver for ToSlash() c
 	// Inverse of LocalToRemoteNames. Together they form a 1:1 mapping where all
 	// the remote names and local names are unique.
 	RemoteToLocalNames map[string]string `json:"remote_to_local_names"`
+
+	// Map of remote file names to their last recorded modified time in the workspace.
+	// Only populated when remote changes are pulled (see [DirectionPull]).
+	// Files found to have a different remote mtime were changed remotely.
+	RemoteModifiedTimes map[string]time.Time `json:"remote_modified_times,omitempty"`
+
+	// Map of local file names to the checksum of their contents as of the last sync.
+	// Only populated if checksums are enabled (see [SyncOptions.Checksum]).
+	// Files with a newer mtime but the same checksum are not synced.
+	Checksums map[string]string `json:"checksums,omitempty"`
 }
 
 // Convert an array of files on the local file system to a SnapshotState representation.
@@ -46,29 +57,62 @@ func NewSnapshotState(localFiles []fileset.File) (*SnapshotState, error) {
 
 	// Compute the new state.
 	for k := range localFiles {
-		f := &localFiles[k]
-		// Compute the remote name the file will have in WSFS
-		remoteName := f.Relative
-		isNotebook, err := f.IsNotebook()
+		err := fs.addFile(&localFiles[k])
 		if err != nil {
-			// Ignore this file if we're unable to determine the notebook type.
-			// Trying to upload such a file to the workspace would fail anyway.
-			continue
-		}
-		if isNotebook {
-			remoteName = notebook.StripExtension(remoteName)
+			return nil, err
 		}
+	}
+	return fs, nil
+}
 
-		// Add the file to snapshot state
-		fs.LastModifiedTimes[f.Relative] = f.Modified()
-		if existingLocalName, ok := fs.RemoteToLocalNames[remoteName]; ok {
-			return nil, fmt.Errorf("both %s and %s point to the same remote file location %s. Please remove one of them from your local project", existingLocalName, f.Relative, remoteName)
-		}
+// addFile adds the specified file to the snapshot state.
+func (fs *SnapshotState) addFile(f *fileset.File) error {
+	// Compute the remote name the file will have in WSFS
+	remoteName := f.Relative
+	isNotebook, err := f.IsNotebook()
+	if err != nil {
+		// Ignore this file if we're unable to determine the notebook type.
+		// Trying to upload such a file to the workspace would fail anyway.
+		return nil
+	}
+	if isNotebook {
+		remoteName = notebook.StripExtension(remoteName)
+	}
 
-		fs.LocalToRemoteNames[f.Relative] = remoteName
-		fs.RemoteToLocalNames[remoteName] = f.Relative
+	// Add the file to snapshot state
+	fs.LastModifiedTimes[f.Relative] = f.Modified()
+	if existingLocalName, ok := fs.RemoteToLocalNames[remoteName]; ok {
+		return fmt.Errorf("both %s and %s point to the same remote file location %s. Please remove one of them from your local project", existingLocalName, f.Relative, remoteName)
+	}
+
+	fs.LocalToRemoteNames[f.Relative] = remoteName
+	fs.RemoteToLocalNames[remoteName] = f.Relative
+	return nil
+}
+
+// removeFile removes the file with the specified local name from the snapshot state.
+func (fs *SnapshotState) removeFile(localName string) {
+	remoteName, ok := fs.LocalToRemoteNames[localName]
+	if !ok {
+		return
+	}
+	delete(fs.LastModifiedTimes, localName)
+	delete(fs.LocalToRemoteNames, localName)
+	delete(fs.RemoteToLocalNames, remoteName)
+	delete(fs.RemoteModifiedTimes, remoteName)
+	delete(fs.Checksums, localName)
+}
+
+// clone returns a deep copy of the snapshot state.
+func (fs *SnapshotState) clone() *SnapshotState {
+	return &SnapshotState{
+		LastModifiedTimes:  maps.Clone(fs.LastModifiedTimes),
+		LocalToRemoteNames: maps.Clone(fs.LocalToRemoteNames),
+		RemoteToLocalNames: maps.Clone(fs.RemoteToLocalNames),
+
+		RemoteModifiedTimes: maps.Clone(fs.RemoteModifiedTimes),
+		Checksums:           maps.Clone(fs.Checksums),
 	}
-	return fs, nil
 }
 
 func (fs *SnapshotState) ResetLastModifiedTimes() {
@@ -142,5 +186,18 @@ func (fs SnapshotState) ToSlash() *SnapshotState {
 		new.RemoteToLocalNames[k] = filepath.ToSlash(v)
 	}
 
+	// Keys are remote paths.
+	if fs.RemoteModifiedTimes != nil {
+		new.RemoteModifiedTimes = maps.Clone(fs.RemoteModifiedTimes)
+	}
+
+	// Keys are local paths.
+	if fs.Checksums != nil {
+		new.Checksums = make(map[string]string)
+		for k, v := range fs.Checksums {
+			new.Checksums[filepath.ToSlash(k)] = v
+		}
+	}
+
 	return &new
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/databricks/cli/libs/fileset"
 	"github.com/databricks/cli/libs/git"
 	"github.com/databricks/cli/libs/testfile"
 	"github.com/databricks/cli/libs/vfs"
@@ -408,3 +409,137 @@ func TestLatestVersionSnapshotGetsLoaded(t *testing.T) {
 	assert.Equal(t, "www.foobar.test", snapshot.Host)
 	assert.Equal(t, "/Repos/foo/bar", snapshot.RemotePath)
 }
+
+func TestDiffPaths(t *testing.T) {
+	ctx := t.Context()
+
+	projectDir := t.TempDir()
+	fileSet, err := git.NewFileSetAtRoot(ctx, vfs.MustNew(projectDir))
+	require.NoError(t, err)
+	state := Snapshot{
+		SnapshotState: &SnapshotState{
+			LastModifiedTimes:  make(map[string]time.Time),
+			LocalToRemoteNames: make(map[string]string),
+			RemoteToLocalNames: make(map[string]string),
+		},
+	}
+
+	f1 := testfile.CreateFile(t, filepath.Join(projectDir, "hello.py"))
+	defer f1.Close(t)
+	f2 := testfile.CreateFile(t, filepath.Join(projectDir, "world.txt"))
+	defer f2.Close(t)
+
+	files, err := fileSet.Files()
+	require.NoError(t, err)
+	_, err = state.diff(ctx, files)
+	require.NoError(t, err)
+
+	// Only the specified paths are considered.
+	f1.Overwrite(t, "# Databricks notebook source")
+	f2.Overwrite(t, "bunnies are cute.")
+	match, ok, err := fileSet.Match("hello.py")
+	require.NoError(t, err)
+	require.True(t, ok)
+	change, err := state.diffPaths(ctx, []string{"hello.py"}, []fileset.File{match})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello.py"}, change.delete)
+	assert.Equal(t, []string{"hello.py"}, change.put)
+	assert.Equal(t, map[string]string{"hello": "hello.py", "world.txt": "world.txt"}, state.RemoteToLocalNames)
+
+	// Paths that are not matched are removed.
+	change, err = state.diffPaths(ctx, []string{"world.txt"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"world.txt"}, change.delete)
+	assert.Empty(t, change.put)
+	assertKeysOfMap(t, state.LastModifiedTimes, []string{"hello.py"})
+}
+
+func TestDiffChecksum(t *testing.T) {
+	ctx := t.Context()
+
+	projectDir := t.TempDir()
+	fileSet, err := git.NewFileSetAtRoot(ctx, vfs.MustNew(projectDir))
+	require.NoError(t, err)
+	opts := defaultOptions(t)
+	opts.Checksum = true
+	state, err := newSnapshot(ctx, opts)
+	require.NoError(t, err)
+
+	helloPath := filepath.Join(projectDir, "hello.txt")
+	require.NoError(t, os.WriteFile(helloPath, []byte("hello"), 0o644))
+
+	files, err := fileSet.Files()
+	require.NoError(t, err)
+	change, err := state.diff(ctx, files)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello.txt"}, change.put)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", state.Checksums["hello.txt"])
+
+	// A newer mtime with the same contents is not synced.
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(helloPath, later, later))
+	files, err = fileSet.Files()
+	require.NoError(t, err)
+	change, err = state.diff(ctx, files)
+	require.NoError(t, err)
+	assert.Empty(t, change.put)
+
+	// Changed contents are synced.
+	require.NoError(t, os.WriteFile(helloPath, []byte("world"), 0o644))
+	later = later.Add(time.Hour)
+	require.NoError(t, os.Chtimes(helloPath, later, later))
+	files, err = fileSet.Files()
+	require.NoError(t, err)
+	change, err = state.diff(ctx, files)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello.txt"}, change.put)
+
+	// Checksums are dropped if disabled.
+	state.checksum = false
+	_, err = state.diff(ctx, files)
+	require.NoError(t, err)
+	assert.Nil(t, state.Checksums)
+}
+
+func TestSnapshotChecksumsOnFirstRun(t *testing.T) {
+	ctx := t.Context()
+
+	projectDir := t.TempDir()
+	helloPath := filepath.Join(projectDir, "hello.txt")
+	require.NoError(t, os.WriteFile(helloPath, []byte("hello"), 0o644))
+	info, err := os.Stat(helloPath)
+	require.NoError(t, err)
+	modTime, err := info.ModTime().MarshalJSON()
+	require.NoError(t, err)
+
+	// A snapshot written without checksums.
+	v1Snapshot := fmt.Sprintf(`{
+		"version": "v1",
+		"host": "www.foobar.test",
+		"remote_path": "/Repos/foo/bar",
+		"last_modified_times": {"hello.txt": %s},
+		"local_to_remote_names": {"hello.txt": "hello.txt"},
+		"remote_to_local_names": {"hello.txt": "hello.txt"}
+	}`, modTime)
+
+	opts := defaultOptions(t)
+	opts.Checksum = true
+	snapshotPath, err := SnapshotPath(opts)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(snapshotPath, []byte(v1Snapshot), 0o644))
+
+	snapshot, err := loadOrNewSnapshot(ctx, opts)
+	require.NoError(t, err)
+	assert.False(t, snapshot.New)
+	assert.Nil(t, snapshot.Checksums)
+
+	// Checksums are computed for files that did not change since the last sync.
+	fileSet, err := git.NewFileSetAtRoot(ctx, vfs.MustNew(projectDir))
+	require.NoError(t, err)
+	files, err := fileSet.Files()
+	require.NoError(t, err)
+	change, err := snapshot.diff(ctx, files)
+	require.NoError(t, err)
+	assert.Empty(t, change.put)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", snapshot.Checksums["hello.txt"])
+}
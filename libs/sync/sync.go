@@ -17,12 +17,13 @@ import (
 
 type OutputHandler func(context.Context, <-chan Event)
 
-// FileCounts reports how many files a sync run uploaded and deleted. A file
-// whose remote name changed (e.g. a script converted to a notebook) is counted
-// as both an upload and a delete, because that is what the sync performs.
+// FileCounts reports how many files a sync run uploaded, deleted and downloaded.
+// A file whose remote name changed (e.g. a script converted to a notebook) is
+// counted as both an upload and a delete, because that is what the sync performs.
 type FileCounts struct {
-	Uploaded int
-	Deleted  int
+	Uploaded   int
+	Deleted    int
+	Downloaded int
 }
 
 type SyncOptions struct {
@@ -36,10 +37,28 @@ type SyncOptions struct {
 
 	Full bool
 
+	// Checksum enables comparing file contents to detect changes, so that files
+	// whose last modified time changed but whose contents did not are not uploaded.
+	Checksum bool
+
 	SnapshotBasePath string
 
 	PollInterval time.Duration
 
+	// WatchMode controls how [Sync.RunContinuous] detects local changes.
+	WatchMode WatchMode
+
+	// Direction controls whether local changes are pushed, remote changes are pulled, or both.
+	Direction Direction
+
+	// OnConflict controls how files that changed both locally and remotely are resolved.
+	// Only applies if remote changes are pulled.
+	OnConflict ConflictPolicy
+
+	// BranchCheck controls whether the branch of the local repository is compared
+	// to the branch checked out in the workspace Git folder that contains the remote path.
+	BranchCheck BranchCheck
+
 	WorkspaceClient *databricks.WorkspaceClient
 
 	CurrentUser *iam.User
@@ -88,6 +107,11 @@ func New(ctx context.Context, opts SyncOptions) (*Sync, error) {
 		return nil, err
 	}
 
+	gitFolder, err := openGitFolder(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: The host may be late-initialized in certain Azure setups where we
 	// specify the workspace by its resource ID. tracked in: https://databricks.atlassian.net/browse/DECO-194
 	opts.Host = opts.WorkspaceClient.Config.Host
@@ -110,9 +134,14 @@ func New(ctx context.Context, opts SyncOptions) (*Sync, error) {
 		}
 	}
 
-	filer, err := filer.NewWorkspaceFilesClient(opts.WorkspaceClient, opts.RemotePath)
-	if err != nil {
-		return nil, err
+	var remote filer.Filer
+	if gitFolder != nil {
+		remote = gitFolder
+	} else {
+		remote, err = filer.NewWorkspaceFilesClient(opts.WorkspaceClient, opts.RemotePath)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var notifier EventNotifier
@@ -132,7 +161,7 @@ func New(ctx context.Context, opts SyncOptions) (*Sync, error) {
 
 		fileList:        fileList,
 		snapshot:        snapshot,
-		filer:           filer,
+		filer:           remote,
 		notifier:        notifier,
 		outputWaitGroup: outputWaitGroup,
 		seq:             0,
@@ -153,7 +182,7 @@ func (s *Sync) notifyStart(ctx context.Context, d diff) {
 	if s.seq > 0 && d.IsEmpty() {
 		return
 	}
-	s.notifier.Notify(ctx, newEventStart(s.seq, d.put, d.delete, s.DryRun))
+	s.notifier.Notify(ctx, newEventStart(s.seq, d.put, d.delete, d.pull, s.DryRun))
 }
 
 func (s *Sync) notifyProgress(ctx context.Context, action EventAction, path string, progress float32) {
@@ -165,7 +194,7 @@ func (s *Sync) notifyComplete(ctx context.Context, d diff) {
 	if s.seq > 0 && d.IsEmpty() {
 		return
 	}
-	s.notifier.Notify(ctx, newEventComplete(s.seq, d.put, d.delete, s.DryRun))
+	s.notifier.Notify(ctx, newEventComplete(s.seq, d.put, d.delete, d.pull, s.DryRun))
 	s.seq++
 }
 
@@ -180,33 +209,44 @@ func (s *Sync) RunOnce(ctx context.Context) ([]fileset.File, error) {
 		return files, err
 	}
 
+	if s.Direction.pull() {
+		return files, s.runPull(ctx, files)
+	}
+
 	change, err := s.snapshot.diff(ctx, files)
 	if err != nil {
 		return files, err
 	}
+
+	return files, s.apply(ctx, change)
+}
+
+// apply applies the specified diff to the remote path and persists
+// the updated snapshot once it has been applied successfully.
+func (s *Sync) apply(ctx context.Context, change diff) error {
 	s.fileCounts = FileCounts{Uploaded: len(change.put), Deleted: len(change.delete)}
 
 	s.notifyStart(ctx, change)
 	if change.IsEmpty() {
 		s.notifyComplete(ctx, change)
-		return files, nil
+		return nil
 	}
 
-	err = s.applyDiff(ctx, change)
+	err := s.applyDiff(ctx, change)
 	if err != nil {
-		return files, err
+		return err
 	}
 
 	if !s.DryRun {
 		err = s.snapshot.Save(ctx)
 		if err != nil {
 			log.Errorf(ctx, "cannot store snapshot: %s", err)
-			return files, err
+			return err
 		}
 	}
 
 	s.notifyComplete(ctx, change)
-	return files, nil
+	return nil
 }
 
 // FileCounts returns the upload and delete counts from the most recent run.
@@ -219,7 +259,29 @@ func (s *Sync) GetFileList(ctx context.Context) ([]fileset.File, error) {
 	return s.fileList.Files(ctx)
 }
 
+// RunContinuous keeps the remote path in sync with the local file system until
+// the context is cancelled. Depending on [SyncOptions.WatchMode], it either
+// reacts to file system notifications or polls every [SyncOptions.PollInterval].
 func (s *Sync) RunContinuous(ctx context.Context) error {
+	// Remote changes can only be detected by polling.
+	if s.WatchMode == WatchModePoll || s.Direction.pull() {
+		return s.runPoll(ctx)
+	}
+
+	w, err := newNotifyWatcher(s.LocalRoot.Native(), s.fileList.SkipDirectory)
+	if err != nil {
+		if s.WatchMode == WatchModeNotify {
+			return fmt.Errorf("cannot watch local file system for changes: %w", err)
+		}
+		log.Warnf(ctx, "Cannot watch local file system for changes, falling back to polling: %s", err)
+		return s.runPoll(ctx)
+	}
+	defer w.Close()
+
+	return s.runWatch(ctx, w)
+}
+
+func (s *Sync) runPoll(ctx context.Context) error {
 	ticker := time.NewTicker(s.PollInterval)
 	defer ticker.Stop()
 
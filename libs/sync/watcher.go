@@ -0,0 +1,291 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/databricks/cli/libs/fileset"
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/cli/libs/set"
+)
+
+// WatchMode controls how [Sync.RunContinuous] detects local changes.
+type WatchMode string
+
+const (
+	// WatchModeAuto uses file system notifications where supported
+	// and falls back to polling otherwise. This is the default.
+	WatchModeAuto = WatchMode("auto")
+
+	// WatchModeNotify uses file system notifications and fails if they are not supported.
+	WatchModeNotify = WatchMode("notify")
+
+	// WatchModePoll lists all files every [SyncOptions.PollInterval].
+	WatchModePoll = WatchMode("poll")
+)
+
+func (m *WatchMode) String() string {
+	return string(*m)
+}
+
+func (m *WatchMode) Set(s string) error {
+	switch WatchMode(s) {
+	case WatchModeAuto, WatchModeNotify, WatchModePoll:
+		*m = WatchMode(s)
+	default:
+		return fmt.Errorf("accepted arguments are %s, %s, and %s", WatchModeAuto, WatchModeNotify, WatchModePoll)
+	}
+	return nil
+}
+
+func (m *WatchMode) Type() string {
+	return "mode"
+}
+
+// errNotifyUnsupported is returned if file system notifications
+// are not supported on the current platform.
+var errNotifyUnsupported = errors.New("file system notifications are not supported on this platform")
+
+const (
+	// watchDebounce is how long to wait for more changes after observing a change.
+	// This coalesces bursts of changes (e.g. a git checkout) into a single sync.
+	watchDebounce = 200 * time.Millisecond
+
+	// watchMaxDelay bounds how long a continuous stream of changes can delay a sync.
+	watchMaxDelay = 2 * time.Second
+)
+
+// watchEvent describes a change observed by a [watcher].
+type watchEvent struct {
+	// Path of the file or directory that changed, relative to the watched root.
+	path string
+
+	// isDir is set if a directory was created or moved into the tree.
+	// The watcher must be told to watch it through [watcher.Add].
+	isDir bool
+
+	// overflow is set if the watcher dropped events.
+	// The full tree must be rescanned to find all changes.
+	overflow bool
+}
+
+// watcher reports changes to files and directories in a directory tree.
+type watcher interface {
+	// Add starts watching the specified directory and its subdirectories.
+	// The path is relative to the watched root.
+	Add(dir string) error
+
+	// Events returns the channel that receives changes.
+	// It is closed when the watcher fails or is closed.
+	Events() <-chan watchEvent
+
+	// Err returns the error that caused the events channel to be closed, if any.
+	Err() error
+
+	// Close stops watching and releases associated resources.
+	Close() error
+}
+
+// watchBatch is a set of changes that are synchronized together.
+type watchBatch struct {
+	// paths that changed, relative to the local root.
+	paths []string
+
+	// dirs that were created and must be watched.
+	dirs []string
+
+	// full is set if the full tree must be rescanned.
+	full bool
+}
+
+// nextBatch blocks until the watcher reports a change and then collects
+// subsequent changes until none are reported for [watchDebounce],
+// or [watchMaxDelay] has passed since the first change.
+func nextBatch(ctx context.Context, w watcher) (watchBatch, error) {
+	var batch watchBatch
+	seen := set.NewSet[string]()
+
+	var debounce, deadline <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return batch, ctx.Err()
+		case <-debounce:
+			return batch, nil
+		case <-deadline:
+			return batch, nil
+		case e, ok := <-w.Events():
+			if !ok {
+				err := w.Err()
+				if err == nil {
+					err = errors.New("file system watcher closed unexpectedly")
+				}
+				return batch, err
+			}
+
+			switch {
+			case e.overflow:
+				batch.full = true
+			case e.isDir:
+				batch.dirs = append(batch.dirs, e.path)
+			}
+
+			// Changes to ignore rules can affect any file in the tree.
+			if path.Base(e.path) == ".gitignore" {
+				batch.full = true
+			}
+
+			if e.path != "" && !seen.Has(e.path) {
+				seen.Add(e.path)
+				batch.paths = append(batch.paths, e.path)
+			}
+
+			if deadline == nil {
+				deadline = time.After(watchMaxDelay)
+			}
+			debounce = time.After(watchDebounce)
+		}
+	}
+}
+
+// runWatch synchronizes all files and then synchronizes the changes
+// reported by the watcher until the context is cancelled.
+//
+// If a directory cannot be watched (e.g. because the inotify watch limit has
+// been reached), it falls back to polling in [WatchModeAuto].
+func (s *Sync) runWatch(ctx context.Context, w watcher) error {
+	err := w.Add(".")
+	if err != nil {
+		return s.fallBackToPoll(ctx, w, err)
+	}
+
+	_, err = s.RunOnce(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		batch, err := nextBatch(ctx, w)
+		if err != nil {
+			return err
+		}
+
+		dirs := batch.dirs
+		if batch.full {
+			// Directories may no longer be ignored (e.g. after a .gitignore
+			// change), so the whole tree is walked to watch them.
+			dirs = []string{"."}
+		}
+		for _, dir := range dirs {
+			err = w.Add(dir)
+			if err != nil {
+				return s.fallBackToPoll(ctx, w, err)
+			}
+		}
+
+		if batch.full {
+			log.Debugf(ctx, "Rescanning all files")
+			_, err = s.RunOnce(ctx)
+		} else {
+			log.Debugf(ctx, "Detected changes to %d paths", len(batch.paths))
+			err = s.runPaths(ctx, batch.paths)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// fallBackToPoll closes the watcher and polls for changes instead,
+// unless notifications were explicitly requested with [WatchModeNotify].
+func (s *Sync) fallBackToPoll(ctx context.Context, w watcher, err error) error {
+	if s.WatchMode == WatchModeNotify {
+		return fmt.Errorf("cannot watch local file system for changes: %w", err)
+	}
+
+	log.Warnf(ctx, "Cannot watch local file system for changes, falling back to polling: %s", err)
+	_ = w.Close()
+	return s.runPoll(ctx)
+}
+
+// runPaths synchronizes the specified paths (relative to the local root).
+// A path that refers to a directory covers all files in that directory.
+func (s *Sync) runPaths(ctx context.Context, paths []string) error {
+	var changed []string
+	for _, p := range paths {
+		expanded, err := s.expandPath(p)
+		if err != nil {
+			return err
+		}
+		changed = append(changed, expanded...)
+	}
+
+	// Dedupe; directory expansion may overlap with other paths in the batch.
+	changed = set.NewSetFrom(changed).Values()
+
+	var files []fileset.File
+	for _, name := range changed {
+		f, ok, err := s.fileList.Match(ctx, name)
+		if err != nil {
+			return err
+		}
+		if ok {
+			files = append(files, f)
+		}
+	}
+
+	change, err := s.snapshot.diffPaths(ctx, changed, files)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(ctx, change)
+}
+
+// expandPath returns the files affected by a change to the specified path.
+// If the path is (or was) a directory, these are the files in the snapshot
+// that were in the directory and the files currently in the directory.
+func (s *Sync) expandPath(name string) ([]string, error) {
+	out := []string{name}
+
+	prefix := name + "/"
+	for localName := range s.snapshot.LastModifiedTimes {
+		if strings.HasPrefix(localName, prefix) {
+			out = append(out, localName)
+		}
+	}
+
+	info, err := fs.Stat(s.LocalRoot, name)
+	if err != nil || !info.IsDir() {
+		// The path no longer exists or is a file.
+		return out, nil
+	}
+
+	err = fs.WalkDir(s.LocalRoot, name, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// The tree may change while we walk it; the watcher reports these changes.
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			skip, err := s.fileList.SkipDirectory(p)
+			if err != nil {
+				return err
+			}
+			if skip {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		out = append(out, p)
+		return nil
+	})
+	return out, err
+}
@@ -0,0 +1,202 @@
+//go:build linux
+
+package sync
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	stdsync "sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// inotifyMask is the set of inotify events that indicate a change
+// to a file or directory that may have to be synchronized.
+const inotifyMask = unix.IN_CREATE |
+	unix.IN_CLOSE_WRITE |
+	unix.IN_MODIFY |
+	unix.IN_ATTRIB |
+	unix.IN_DELETE |
+	unix.IN_MOVED_FROM |
+	unix.IN_MOVED_TO |
+	unix.IN_DONT_FOLLOW |
+	unix.IN_EXCL_UNLINK
+
+// notifyWatcher implements [watcher] using inotify(7).
+//
+// Inotify watches are not recursive, so the watcher adds a watch for every
+// directory in the tree, except for directories that the skip function
+// returns true for (e.g. directories ignored by .gitignore).
+type notifyWatcher struct {
+	root string
+	skip func(dir string) (bool, error)
+
+	fd   int
+	file *os.File
+
+	// mu protects the watch descriptor map. It is accessed both by
+	// the reader goroutine and by callers of [notifyWatcher.Add].
+	mu      stdsync.Mutex
+	watches map[int32]string
+
+	events chan watchEvent
+	err    error
+
+	// done is closed when the watcher is closed.
+	done      chan struct{}
+	closeOnce stdsync.Once
+}
+
+func newNotifyWatcher(root string, skip func(dir string) (bool, error)) (watcher, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("inotify_init1: %w", err)
+	}
+
+	w := &notifyWatcher{
+		root: root,
+		skip: skip,
+		fd:   fd,
+
+		// Wrapping the non-blocking descriptor in an [os.File] registers it
+		// with the runtime poller, so that closing the file unblocks reads.
+		file: os.NewFile(uintptr(fd), "inotify"),
+
+		watches: make(map[int32]string),
+		events:  make(chan watchEvent, 1024),
+		done:    make(chan struct{}),
+	}
+
+	go w.read()
+	return w, nil
+}
+
+func (w *notifyWatcher) Add(dir string) error {
+	return fs.WalkDir(os.DirFS(w.root), dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// The tree may change while we walk it; removals are reported as events.
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		skip, err := w.skip(name)
+		if err != nil {
+			return err
+		}
+		if skip {
+			return fs.SkipDir
+		}
+
+		wd, err := unix.InotifyAddWatch(w.fd, filepath.Join(w.root, name), inotifyMask)
+		if errors.Is(err, unix.ENOENT) {
+			return fs.SkipDir
+		}
+		if errors.Is(err, unix.ENOSPC) {
+			return fmt.Errorf("cannot watch %s: the inotify watch limit has been reached (see fs.inotify.max_user_watches)", name)
+		}
+		if err != nil {
+			return fmt.Errorf("cannot watch %s: %w", name, err)
+		}
+
+		w.mu.Lock()
+		w.watches[int32(wd)] = name
+		w.mu.Unlock()
+		return nil
+	})
+}
+
+func (w *notifyWatcher) Events() <-chan watchEvent {
+	return w.events
+}
+
+func (w *notifyWatcher) Err() error {
+	return w.err
+}
+
+func (w *notifyWatcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		err = w.file.Close()
+	})
+	return err
+}
+
+// read decodes inotify events until the file is closed.
+func (w *notifyWatcher) read() {
+	defer close(w.events)
+
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				w.err = fmt.Errorf("cannot read file system events: %w", err)
+			}
+			return
+		}
+
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			// Layout of struct inotify_event; see inotify(7).
+			wd := int32(binary.NativeEndian.Uint32(buf[offset:]))
+			mask := binary.NativeEndian.Uint32(buf[offset+4:])
+			nameLen := int(binary.NativeEndian.Uint32(buf[offset+12:]))
+			name := string(buf[offset+unix.SizeofInotifyEvent : offset+unix.SizeofInotifyEvent+nameLen])
+			offset += unix.SizeofInotifyEvent + nameLen
+
+			e, ok := w.decode(wd, mask, name)
+			if !ok {
+				continue
+			}
+
+			select {
+			case w.events <- e:
+			case <-w.done:
+				return
+			}
+		}
+	}
+}
+
+func (w *notifyWatcher) decode(wd int32, mask uint32, name string) (watchEvent, bool) {
+	if mask&unix.IN_Q_OVERFLOW != 0 {
+		return watchEvent{overflow: true}, true
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	dir, ok := w.watches[wd]
+	if !ok {
+		return watchEvent{}, false
+	}
+
+	// The watch was removed because the directory was deleted or unmounted.
+	// The deletion itself is reported on the parent directory.
+	if mask&unix.IN_IGNORED != 0 {
+		delete(w.watches, wd)
+		return watchEvent{}, false
+	}
+
+	// The name is padded with null bytes.
+	name = strings.TrimRight(name, "\x00")
+	if name == "" {
+		return watchEvent{}, false
+	}
+
+	return watchEvent{
+		path:  path.Join(dir, name),
+		isDir: mask&unix.IN_ISDIR != 0 && mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0,
+	}, true
+}
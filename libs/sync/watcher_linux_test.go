@@ -0,0 +1,51 @@
+//go:build linux
+
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nextEvent(t *testing.T, w watcher) watchEvent {
+	select {
+	case e := <-w.Events():
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for file system event")
+		return watchEvent{}
+	}
+}
+
+func TestNotifyWatcher(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "ignored"), 0o755))
+
+	w, err := newNotifyWatcher(dir, func(dir string) (bool, error) {
+		return dir == "ignored", nil
+	})
+	require.NoError(t, err)
+	defer w.Close()
+	require.NoError(t, w.Add("."))
+
+	// Changes in skipped directories are not reported.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ignored", "a.txt"), []byte("a"), 0o644))
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o755))
+	assert.Equal(t, watchEvent{path: "sub", isDir: true}, nextEvent(t, w))
+
+	require.NoError(t, w.Add("sub"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("b"), 0o644))
+	assert.Equal(t, watchEvent{path: "sub/b.txt"}, nextEvent(t, w))
+
+	require.NoError(t, w.Close())
+	for range w.Events() {
+		// Drain remaining events until the channel is closed.
+	}
+	assert.NoError(t, w.Err())
+}
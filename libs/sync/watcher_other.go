@@ -0,0 +1,7 @@
+//go:build !linux
+
+package sync
+
+func newNotifyWatcher(root string, skip func(dir string) (bool, error)) (watcher, error) {
+	return nil, errNotifyUnsupported
+}
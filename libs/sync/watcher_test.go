@@ -0,0 +1,190 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/databricks/cli/internal/testutil"
+	"github.com/databricks/cli/libs/vfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWatcher struct {
+	events chan watchEvent
+	err    error
+
+	// addErr is returned by Add.
+	addErr error
+	added  []string
+	closed bool
+}
+
+func (w *fakeWatcher) Add(dir string) error {
+	w.added = append(w.added, dir)
+	return w.addErr
+}
+
+func (w *fakeWatcher) Events() <-chan watchEvent {
+	return w.events
+}
+
+func (w *fakeWatcher) Err() error {
+	return w.err
+}
+
+func (w *fakeWatcher) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestWatchModeSet(t *testing.T) {
+	var m WatchMode
+	require.NoError(t, m.Set("poll"))
+	assert.Equal(t, WatchModePoll, m)
+	assert.EqualError(t, m.Set("inotify"), "accepted arguments are auto, notify, and poll")
+}
+
+func TestNextBatchCoalescesEvents(t *testing.T) {
+	w := &fakeWatcher{events: make(chan watchEvent, 10)}
+	w.events <- watchEvent{path: "a.py"}
+	w.events <- watchEvent{path: "dir", isDir: true}
+	w.events <- watchEvent{path: "a.py"}
+
+	batch, err := nextBatch(t.Context(), w)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.py", "dir"}, batch.paths)
+	assert.Equal(t, []string{"dir"}, batch.dirs)
+	assert.False(t, batch.full)
+}
+
+func TestNextBatchFullRescan(t *testing.T) {
+	w := &fakeWatcher{events: make(chan watchEvent, 10)}
+	w.events <- watchEvent{path: "sub/.gitignore"}
+
+	batch, err := nextBatch(t.Context(), w)
+	require.NoError(t, err)
+	assert.True(t, batch.full)
+
+	w.events <- watchEvent{overflow: true}
+	batch, err = nextBatch(t.Context(), w)
+	require.NoError(t, err)
+	assert.True(t, batch.full)
+	assert.Empty(t, batch.paths)
+}
+
+func TestNextBatchWatcherError(t *testing.T) {
+	w := &fakeWatcher{events: make(chan watchEvent), err: errors.New("boom")}
+	close(w.events)
+
+	_, err := nextBatch(t.Context(), w)
+	assert.EqualError(t, err, "boom")
+}
+
+func TestNextBatchContextCancelled(t *testing.T) {
+	w := &fakeWatcher{events: make(chan watchEvent)}
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := nextBatch(ctx, w)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRunPaths(t *testing.T) {
+	ctx := t.Context()
+
+	dir := setupFiles(t)
+	root := vfs.MustNew(dir)
+	fileList, err := NewFileList(ctx, root, root, []string{"."}, nil, []string{"*.txt"})
+	require.NoError(t, err)
+
+	s := &Sync{
+		SyncOptions: &SyncOptions{
+			LocalRoot: root,
+			DryRun:    true,
+		},
+		fileList: fileList,
+		snapshot: &Snapshot{SnapshotState: &SnapshotState{
+			LastModifiedTimes:  make(map[string]time.Time),
+			LocalToRemoteNames: make(map[string]string),
+			RemoteToLocalNames: make(map[string]string),
+		}},
+		notifier: &NopNotifier{},
+	}
+
+	// A directory covers all (non-excluded) files in it.
+	err = s.runPaths(ctx, []string{"a.go", "test"})
+	require.NoError(t, err)
+	assertKeysOfMap(t, s.snapshot.LastModifiedTimes, []string{"a.go", "test/sub1/f.go", "test/sub1/sub2/g.go"})
+	assert.Equal(t, FileCounts{Uploaded: 3}, s.FileCounts())
+
+	// Removing a directory removes all files in it.
+	testutil.Touch(t, dir, "test", "sub1", "new.go")
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "test", "sub1", "sub2")))
+	err = s.runPaths(ctx, []string{"test/sub1/sub2", "test/sub1/new.go"})
+	require.NoError(t, err)
+	assertKeysOfMap(t, s.snapshot.LastModifiedTimes, []string{"a.go", "test/sub1/f.go", "test/sub1/new.go"})
+	assert.Equal(t, FileCounts{Uploaded: 1, Deleted: 1}, s.FileCounts())
+}
+
+func newTestWatchSync(t *testing.T, mode WatchMode) *Sync {
+	root := vfs.MustNew(t.TempDir())
+	fileList, err := NewFileList(t.Context(), root, root, []string{"."}, nil, nil)
+	require.NoError(t, err)
+
+	return &Sync{
+		SyncOptions: &SyncOptions{
+			LocalRoot:    root,
+			DryRun:       true,
+			WatchMode:    mode,
+			PollInterval: time.Hour,
+		},
+		fileList: fileList,
+		snapshot: &Snapshot{SnapshotState: &SnapshotState{
+			LastModifiedTimes:  make(map[string]time.Time),
+			LocalToRemoteNames: make(map[string]string),
+			RemoteToLocalNames: make(map[string]string),
+		}},
+		notifier: &NopNotifier{},
+	}
+}
+
+func TestRunWatchFallsBackToPoll(t *testing.T) {
+	s := newTestWatchSync(t, WatchModeAuto)
+	w := &fakeWatcher{events: make(chan watchEvent), addErr: errors.New("watch limit reached")}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	// The watcher is closed and the sync keeps polling until cancelled.
+	err := s.runWatch(ctx, w)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, w.closed)
+}
+
+func TestRunWatchNotifyModeDoesNotFallBack(t *testing.T) {
+	s := newTestWatchSync(t, WatchModeNotify)
+	w := &fakeWatcher{events: make(chan watchEvent), addErr: errors.New("watch limit reached")}
+
+	err := s.runWatch(t.Context(), w)
+	assert.EqualError(t, err, "cannot watch local file system for changes: watch limit reached")
+}
+
+func TestRunWatchFullRescanWatchesTree(t *testing.T) {
+	s := newTestWatchSync(t, WatchModeAuto)
+	w := &fakeWatcher{events: make(chan watchEvent, 10)}
+	w.events <- watchEvent{path: ".gitignore"}
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	err := s.runWatch(ctx, w)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The tree is walked again, so that directories no longer ignored are watched.
+	assert.Equal(t, []string{".", "."}, w.added)
+}
@@ -1,5 +1,7 @@
 package testserver
 
+import "encoding/json"
+
 // EventualValue provides eventual-consistency read semantics for a single
 // value. After Write, the first Read returns the pre-write value; subsequent
 // Reads return the written value.
@@ -103,3 +105,30 @@ func (m *EventualMap[K, V]) Put(key K, v V) {
 	}
 	ev.Put(v)
 }
+
+// MarshalJSON encodes the current value of every key. Pending stale state is not
+// part of the encoding.
+func (m *EventualMap[K, V]) MarshalJSON() ([]byte, error) {
+	values := make(map[K]V, len(m.values))
+	for key, ev := range m.values {
+		values[key] = ev.current
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON puts every value of the encoded map, keeping the eventual
+// consistency mode of m.
+func (m *EventualMap[K, V]) UnmarshalJSON(data []byte) error {
+	var values map[K]V
+	err := json.Unmarshal(data, &values)
+	if err != nil {
+		return err
+	}
+	if m.values == nil {
+		m.values = map[K]*EventualValue[V]{}
+	}
+	for key, v := range values {
+		m.Put(key, v)
+	}
+	return nil
+}
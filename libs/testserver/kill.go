@@ -6,8 +6,6 @@ import (
 	"os"
 	"sync"
 	"time"
-
-	"github.com/databricks/cli/internal/testutil"
 )
 
 type killRuleKey struct {
@@ -37,7 +35,7 @@ func (kr *killRules) set(token, pattern string, offset, times int) {
 
 // check returns true if the caller should be killed for this request.
 // It also performs the kill.
-func (kr *killRules) check(t testutil.TestingT, method, path, token string, headers http.Header) bool {
+func (kr *killRules) check(t TestingT, method, path, token string, headers http.Header) bool {
 	pattern := method + " " + path
 	key := killRuleKey{token: token, pattern: pattern}
 
@@ -67,7 +65,7 @@ func (kr *killRules) check(t testutil.TestingT, method, path, token string, head
 	return true
 }
 
-func killProcess(t testutil.TestingT, pattern string, headers http.Header) {
+func killProcess(t TestingT, pattern string, headers http.Header) {
 	pid := ExtractPidFromHeaders(headers)
 	if pid == 0 {
 		t.Errorf("kill rule matched %q but test-pid not found in User-Agent", pattern)
@@ -0,0 +1,124 @@
+package testserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+)
+
+// workspaceState is the on-disk form of a FakeWorkspace. The exported
+// collections are stored in Resources as they marshal; the unexported ones are
+// listed explicitly.
+type workspaceState struct {
+	Directories               map[string]workspace.ObjectInfo `json:"directories"`
+	Files                     map[string]FileEntry            `json:"files"`
+	RepoIdByPath              map[string]int64                `json:"repo_id_by_path"`
+	PostgresImplicitBranches  map[string]bool                 `json:"postgres_implicit_branches"`
+	PostgresImplicitEndpoints map[string]bool                 `json:"postgres_implicit_endpoints"`
+	Resources                 json.RawMessage                 `json:"resources"`
+}
+
+// PersistTo makes the server keep the state of its workspaces in dir. A
+// workspace loads its state from dir when it is first used, and saves it after
+// every request that is not a GET or HEAD.
+func (s *Server) PersistTo(dir string) error {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+	s.stateDir = dir
+	return nil
+}
+
+func (s *Server) statePath(token string) string {
+	return filepath.Join(s.stateDir, url.PathEscape(workspaceKeyForToken(token))+".json")
+}
+
+// saveWorkspace writes the state of the workspace for token to the state
+// directory. It writes to a temporary file first so that a crash never leaves a
+// truncated state behind.
+func (s *Server) saveWorkspace(token string, ws *FakeWorkspace) error {
+	data, err := ws.marshalState()
+	if err != nil {
+		return err
+	}
+
+	s.persistMu.Lock()
+	defer s.persistMu.Unlock()
+
+	path := s.statePath(token)
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, data, 0o600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadWorkspace restores the state of the workspace for token from the state
+// directory. A missing state file leaves the workspace as it is.
+func (s *Server) loadWorkspace(token string, ws *FakeWorkspace) error {
+	data, err := os.ReadFile(s.statePath(token))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return ws.unmarshalState(data)
+}
+
+func (s *FakeWorkspace) marshalState() ([]byte, error) {
+	defer s.LockUnlock()()
+
+	resources, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(workspaceState{
+		Directories:               s.directories,
+		Files:                     s.files,
+		RepoIdByPath:              s.repoIdByPath,
+		PostgresImplicitBranches:  s.postgresImplicitBranches,
+		PostgresImplicitEndpoints: s.postgresImplicitEndpoints,
+		Resources:                 resources,
+	}, "", "  ")
+}
+
+func (s *FakeWorkspace) unmarshalState(data []byte) error {
+	defer s.LockUnlock()()
+
+	var state workspaceState
+	err := json.Unmarshal(data, &state)
+	if err != nil {
+		return fmt.Errorf("invalid workspace state: %w", err)
+	}
+	if len(state.Resources) > 0 {
+		err = json.Unmarshal(state.Resources, s)
+		if err != nil {
+			return fmt.Errorf("invalid workspace state: %w", err)
+		}
+	}
+	if state.Directories != nil {
+		s.directories = state.Directories
+	}
+	if state.Files != nil {
+		s.files = state.Files
+	}
+	if state.RepoIdByPath != nil {
+		s.repoIdByPath = state.RepoIdByPath
+	}
+	if state.PostgresImplicitBranches != nil {
+		s.postgresImplicitBranches = state.PostgresImplicitBranches
+	}
+	if state.PostgresImplicitEndpoints != nil {
+		s.postgresImplicitEndpoints = state.PostgresImplicitEndpoints
+	}
+	return nil
+}
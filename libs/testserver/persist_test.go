@@ -0,0 +1,65 @@
+package testserver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/databricks/cli/libs/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPersistentServer(t *testing.T, dir string) *testserver.Server {
+	server := testserver.New(t)
+	testserver.AddDefaultHandlers(server)
+	require.NoError(t, server.PersistTo(dir))
+	return server
+}
+
+func doRequest(t *testing.T, server *testserver.Server, method, path, body string) (int, map[string]any) {
+	req, err := http.NewRequest(method, server.URL+path, bytes.NewReader([]byte(body)))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer dbapi0persist")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var out map[string]any
+	if len(data) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		require.NoError(t, dec.Decode(&out))
+	}
+	return resp.StatusCode, out
+}
+
+func TestServerPersistTo_RestoresStateInANewServer(t *testing.T) {
+	dir := t.TempDir()
+
+	first := newPersistentServer(t, dir)
+	status, created := doRequest(t, first, "POST", "/api/2.2/jobs/create", `{"name": "persisted"}`)
+	require.Equal(t, 200, status)
+	status, _ = doRequest(t, first, "POST", "/api/2.0/workspace/mkdirs", `{"path": "/Workspace/Users/tester@databricks.com/persisted"}`)
+	require.Equal(t, 200, status)
+	first.Close()
+
+	second := newPersistentServer(t, dir)
+	status, job := doRequest(t, second, "GET", "/api/2.2/jobs/get?job_id="+created["job_id"].(json.Number).String(), "")
+	require.Equal(t, 200, status)
+	assert.Equal(t, "persisted", job["settings"].(map[string]any)["name"])
+
+	status, info := doRequest(t, second, "GET", "/api/2.0/workspace/get-status?path=/Workspace/Users/tester@databricks.com/persisted", "")
+	require.Equal(t, 200, status)
+	assert.Equal(t, "DIRECTORY", info["object_type"])
+}
+
+func TestServerPersistTo_StartsEmptyWithoutState(t *testing.T) {
+	server := newPersistentServer(t, t.TempDir())
+	status, _ := doRequest(t, server, "GET", "/api/2.2/jobs/get?job_id=1", "")
+	assert.Equal(t, 404, status)
+}
@@ -9,7 +9,6 @@ import (
 	"maps"
 	"net"
 	"net/http"
-	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"regexp"
@@ -17,7 +16,6 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/databricks/cli/internal/testutil"
 	"github.com/databricks/cli/libs/testserver/testsql"
 )
 
@@ -56,11 +54,51 @@ func ExtractPidFromHeaders(headers http.Header) int {
 	return pid
 }
 
+// TestingT is the subset of [testing.T] the server uses to report problems and
+// register cleanup. It is small enough to be implemented outside of tests, where
+// the server backs "databricks debug local-workspace serve".
+type TestingT interface {
+	Logf(format string, args ...any)
+	Errorf(format string, args ...any)
+	Cleanup(func())
+}
+
+// httpServer serves a handler on a listener. It has the fields of
+// [httptest.Server] that tests use, without linking net/http/httptest and the
+// testing package into the CLI, which serves the fake workspace itself.
+type httpServer struct {
+	// URL is the base URL of the form http://ipaddr:port with no trailing slash.
+	URL      string
+	Listener net.Listener
+
+	server *http.Server
+}
+
+func startHTTPServer(l net.Listener, handler http.Handler) *httpServer {
+	s := &httpServer{
+		URL:      "http://" + l.Addr().String(),
+		Listener: l,
+		server:   &http.Server{Handler: handler},
+	}
+	go func() {
+		_ = s.server.Serve(l)
+	}()
+	return s
+}
+
+// Close shuts down the server and closes its connections.
+func (s *httpServer) Close() {
+	_ = s.server.Close()
+}
+
 type Server struct {
-	*httptest.Server
+	*httpServer
 	*Router
 
-	t testutil.TestingT
+	t TestingT
+
+	// testMode enables the endpoints and behaviors that only tests use; see [New].
+	testMode bool
 
 	fakeWorkspaces map[string]*FakeWorkspace
 	fakeOidc       *FakeOidc
@@ -71,6 +109,10 @@ type Server struct {
 
 	sqlHandler *testsql.Handler
 
+	// stateDir is where workspaces persist their state; see [Server.PersistTo].
+	stateDir  string
+	persistMu sync.Mutex
+
 	RequestCallback  func(request *Request)
 	ResponseCallback func(request *Request, response *EncodedResponse)
 }
@@ -98,7 +140,7 @@ type EncodedResponse struct {
 	Body       []byte
 }
 
-func NewRequest(t testutil.TestingT, r *http.Request, fakeWorkspace *FakeWorkspace) Request {
+func NewRequest(t TestingT, r *http.Request, fakeWorkspace *FakeWorkspace) Request {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		t.Logf("Error while reading request body: %s", err)
@@ -114,7 +156,7 @@ func NewRequest(t testutil.TestingT, r *http.Request, fakeWorkspace *FakeWorkspa
 	}
 }
 
-func normalizeResponse(t testutil.TestingT, resp any) EncodedResponse {
+func normalizeResponse(t TestingT, resp any) EncodedResponse {
 	result := normalizeResponseBody(t, resp)
 	if result.StatusCode == 0 {
 		result.StatusCode = 200
@@ -122,7 +164,7 @@ func normalizeResponse(t testutil.TestingT, resp any) EncodedResponse {
 	return result
 }
 
-func normalizeResponseBody(t testutil.TestingT, resp any) EncodedResponse {
+func normalizeResponseBody(t TestingT, resp any) EncodedResponse {
 	if isNil(resp) {
 		t.Errorf("Handler must not return nil")
 		return EncodedResponse{StatusCode: 500}
@@ -224,28 +266,50 @@ func getHeaders(value []byte) http.Header {
 	}
 }
 
-func New(t testutil.TestingT) *Server {
+// New returns a server for tests on a random port on the loopback interface.
+// Besides the workspace APIs, it serves the endpoints that scripts use to kill
+// the CLI and inject faults, and it reports requests without a handler as errors.
+func New(t TestingT) *Server {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("testserver: failed to listen on a port: %v", err))
+	}
+	return newServer(t, l, true)
+}
+
+// NewLocalWorkspace returns a server on the given listener that only serves the
+// workspace APIs, for "databricks debug local-workspace serve". Requests without
+// a handler get a 404 response.
+func NewLocalWorkspace(t TestingT, l net.Listener) *Server {
+	return newServer(t, l, false)
+}
+
+func newServer(t TestingT, l net.Listener, testMode bool) *Server {
 	router := NewRouter()
 	kills := newKillRules()
 	faults := NewFaultRules()
 
-	// Wrap the router so kill rules fire for ALL requests, including those with
-	// no registered handler that would otherwise bypass serve() entirely.
-	killMiddleware := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token := getToken(r)
-		if kills.check(t, r.Method, r.URL.Path, token, r.Header) {
-			return
-		}
-		router.ServeHTTP(w, r)
-	})
+	var handler http.Handler = router
+	if testMode {
+		// Wrap the router so kill rules fire for ALL requests, including those with
+		// no registered handler that would otherwise bypass serve() entirely.
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := getToken(r)
+			if kills.check(t, r.Method, r.URL.Path, token, r.Header) {
+				return
+			}
+			router.ServeHTTP(w, r)
+		})
+	}
 
-	server := httptest.NewServer(killMiddleware)
+	server := startHTTPServer(l, handler)
 	t.Cleanup(server.Close)
 
 	s := &Server{
-		Server:         server,
+		httpServer:     server,
 		Router:         router,
 		t:              t,
+		testMode:       testMode,
 		fakeWorkspaces: map[string]*FakeWorkspace{},
 		fakeOidc:       &FakeOidc{url: server.URL},
 		kills:          kills,
@@ -270,6 +334,17 @@ func New(t testutil.TestingT) *Server {
 		}
 
 		pattern := r.Method + " " + r.URL.Path
+		if !testMode {
+			respBytes, _ := json.Marshal(map[string]string{
+				"error_code": "ENDPOINT_NOT_FOUND",
+				"message":    "No API found for '" + pattern + "'",
+			})
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write(respBytes)
+			return
+		}
+
 		bodyBytes, err := io.ReadAll(r.Body)
 		var body string
 		if err != nil {
@@ -308,8 +383,10 @@ Response.Body = '<response body here>'
 	router.NotFound = notFoundFunc
 
 	// Register test-only endpoints for setting up kill and fault rules from scripts.
-	s.Handle("POST", "/__testserver/kill", killEndpointHandler(s.kills))
-	s.Handle("POST", "/__testserver/fault", faultEndpointHandler(s.faults))
+	if testMode {
+		s.Handle("POST", "/__testserver/kill", killEndpointHandler(s.kills))
+		s.Handle("POST", "/__testserver/fault", faultEndpointHandler(s.faults))
+	}
 
 	// Register a default handler for the SDK's host metadata discovery endpoint.
 	// The SDK resolves this during config initialization (as of v0.126.0) to
@@ -349,7 +426,14 @@ func (s *Server) getWorkspaceForToken(token string) *FakeWorkspace {
 	defer s.mu.Unlock()
 
 	if _, ok := s.fakeWorkspaces[key]; !ok {
-		s.fakeWorkspaces[key] = NewFakeWorkspace(s.URL, token)
+		ws := NewFakeWorkspace(s.URL, token)
+		if s.stateDir != "" {
+			err := s.loadWorkspace(token, ws)
+			if err != nil {
+				s.t.Errorf("Failed to load workspace state: %s", err)
+			}
+		}
+		s.fakeWorkspaces[key] = ws
 	}
 
 	return s.fakeWorkspaces[key]
@@ -375,7 +459,7 @@ func (s *Server) serve(w http.ResponseWriter, r *http.Request, handler HandlerFu
 	fault := s.faults.Check(r.Method, r.URL.Path, token)
 
 	if fault == nil || fault.AfterHandler {
-		if bytes.Contains(request.Body, []byte("INJECT_ERROR")) {
+		if s.testMode && bytes.Contains(request.Body, []byte("INJECT_ERROR")) {
 			resp = EncodedResponse{
 				StatusCode: 500,
 				Body:       []byte("INJECTED"),
@@ -397,6 +481,15 @@ func (s *Server) serve(w http.ResponseWriter, r *http.Request, handler HandlerFu
 		}
 	}
 
+	// Save before responding, so that a client never sees a change that is not
+	// persisted yet.
+	if s.stateDir != "" && fakeWorkspace != nil && r.Method != http.MethodGet && r.Method != http.MethodHead {
+		err := s.saveWorkspace(token, fakeWorkspace)
+		if err != nil {
+			s.t.Errorf("Failed to save workspace state: %s", err)
+		}
+	}
+
 	maps.Copy(w.Header(), resp.Headers)
 
 	w.WriteHeader(resp.StatusCode)
@@ -1,12 +1,14 @@
 package testserver_test
 
 import (
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/databricks/cli/libs/testserver"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsLocalhostProbe(t *testing.T) {
@@ -33,3 +35,23 @@ func TestIsLocalhostProbe(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalWorkspaceServesOnlyWorkspaceAPIs(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := testserver.NewLocalWorkspace(t, l)
+	testserver.AddDefaultHandlers(server)
+
+	status, _ := doRequest(t, server, "GET", "/api/2.0/preview/scim/v2/Me", "")
+	assert.Equal(t, http.StatusOK, status)
+
+	// The endpoints that kill processes and inject faults are for tests only.
+	for _, path := range []string{"/__testserver/kill", "/__testserver/fault"} {
+		status, body := doRequest(t, server, "POST", path, `{}`)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, map[string]any{
+			"error_code": "ENDPOINT_NOT_FOUND",
+			"message":    "No API found for 'POST " + path + "'",
+		}, body)
+	}
+}
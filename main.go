@@ -27,6 +27,6 @@ func main() {
 	ctx := context.Background()
 	err := root.Execute(ctx, cmd.New(ctx))
 	if err != nil {
-		os.Exit(1)
+		os.Exit(root.ExitCode(err))
 	}
 }
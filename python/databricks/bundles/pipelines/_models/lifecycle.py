@@ -1,9 +1,9 @@
-from dataclasses import dataclass
+from dataclasses import dataclass, field
 from typing import TYPE_CHECKING, TypedDict
 
 from databricks.bundles.core._transform import _transform
 from databricks.bundles.core._transform_to_json import _transform_to_json_value
-from databricks.bundles.core._variable import VariableOrOptional
+from databricks.bundles.core._variable import VariableOrList, VariableOrOptional
 
 if TYPE_CHECKING:
     from typing_extensions import Self
@@ -13,6 +13,16 @@ if TYPE_CHECKING:
 class Lifecycle:
     """"""
 
+    create_before_destroy: VariableOrOptional[bool] = None
+    """
+    Lifecycle setting to create the replacement before destroying the resource when it must be recreated.
+    """
+
+    ignore_changes: VariableOrList[str] = field(default_factory=list)
+    """
+    Field paths whose changes are ignored when updating the resource. The values are only used when the resource is created.
+    """
+
     prevent_destroy: VariableOrOptional[bool] = None
     """
     Lifecycle setting to prevent the resource from being destroyed.
@@ -29,6 +39,16 @@ class Lifecycle:
 class LifecycleDict(TypedDict, total=False):
     """"""
 
+    create_before_destroy: VariableOrOptional[bool]
+    """
+    Lifecycle setting to create the replacement before destroying the resource when it must be recreated.
+    """
+
+    ignore_changes: VariableOrList[str]
+    """
+    Field paths whose changes are ignored when updating the resource. The values are only used when the resource is created.
+    """
+
     prevent_destroy: VariableOrOptional[bool]
     """
     Lifecycle setting to prevent the resource from being destroyed.